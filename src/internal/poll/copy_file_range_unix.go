@@ -65,3 +65,40 @@ func copyFileRange(dst, src *FD, max int) (written int64, err error) {
 		return int64(n), err
 	})
 }
+
+// CopyFileRangeAt copies at most remain bytes of data from src at offset
+// srcOff to dst at offset dstOff, using the copy_file_range system call.
+// It does not use or change the file offsets of src or dst.
+func CopyFileRangeAt(dst, src *FD, srcOff, dstOff, remain int64) (written int64, handled bool, err error) {
+	if !supportCopyFileRange() {
+		return 0, false, nil
+	}
+	// Call incref, not readLock or writeLock, as in Pread and Pwrite:
+	// the offsets are given explicitly, so the copy is independent
+	// of other reads and writes.
+	if err := dst.incref(); err != nil {
+		return 0, true, err
+	}
+	defer dst.decref()
+	if err := src.incref(); err != nil {
+		return 0, true, err
+	}
+	defer src.decref()
+
+	for remain > 0 {
+		max := min(remain, maxCopyFileRangeRound)
+		n, e := ignoringEINTR2(func() (int, error) {
+			return unix.CopyFileRange(src.Sysfd, &srcOff, dst.Sysfd, &dstOff, int(max), 0)
+		})
+		if n > 0 {
+			remain -= int64(n)
+			written += int64(n)
+		}
+		handled, err = handleCopyFileRangeErr(e, int64(n), written)
+		if n == 0 || !handled || err != nil {
+			return
+		}
+	}
+
+	return written, true, nil
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !plan9
+
+package poll
+
+// Fdatasync is the same as Fsync on systems without fdatasync,
+// or, on Darwin, where fdatasync does not flush the drive's cache.
+func (fd *FD) Fdatasync() error {
+	return fd.Fsync()
+}
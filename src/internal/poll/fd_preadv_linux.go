@@ -0,0 +1,76 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"internal/syscall/unix"
+	"io"
+	"syscall"
+)
+
+// maxIovecs is the maximum number of buffers passed to preadv or pwritev,
+// the Linux UIO_MAXIOV.
+const maxIovecs = 1024
+
+// Preadv wraps the preadv system call.
+// It reads into the buffers in order, and may not fill them all.
+func (fd *FD) Preadv(bufs [][]byte, off int64) (int, error) {
+	// Call incref, not readLock, as in Pread.
+	if err := fd.incref(); err != nil {
+		return 0, err
+	}
+	defer fd.decref()
+	iovecs := appendIovecs(nil, bufs)
+	if len(iovecs) == 0 {
+		return 0, nil
+	}
+	n, err := ignoringEINTR2(func() (int, error) {
+		return unix.Preadv(fd.Sysfd, iovecs, off)
+	})
+	if err != nil {
+		n = 0
+	}
+	return n, fd.eofError(n, err)
+}
+
+// Pwritev wraps the pwritev system call.
+// It writes the buffers in order, and may not write them all.
+func (fd *FD) Pwritev(bufs [][]byte, off int64) (int, error) {
+	// Call incref, not writeLock, as in Pwrite.
+	if err := fd.incref(); err != nil {
+		return 0, err
+	}
+	defer fd.decref()
+	iovecs := appendIovecs(nil, bufs)
+	if len(iovecs) == 0 {
+		return 0, nil
+	}
+	n, err := ignoringEINTR2(func() (int, error) {
+		return unix.Pwritev(fd.Sysfd, iovecs, off)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return n, nil
+}
+
+// appendIovecs appends iovecs for the non-empty buffers in bufs to iovecs,
+// up to maxIovecs in total.
+func appendIovecs(iovecs []syscall.Iovec, bufs [][]byte) []syscall.Iovec {
+	for _, b := range bufs {
+		if len(iovecs) == maxIovecs {
+			break
+		}
+		if len(b) == 0 {
+			continue
+		}
+		iovecs = append(iovecs, newIovecWithBase(&b[0]))
+		iovecs[len(iovecs)-1].SetLen(len(b))
+	}
+	return iovecs
+}
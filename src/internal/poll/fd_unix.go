@@ -117,6 +117,10 @@ func (fd *FD) Close() error {
 	return err
 }
 
+// setAtDeadline is a no-op on Unix systems, where positioned IO
+// does not wait outside the runtime poller.
+func (fd *FD) setAtDeadline(d int64, mode int) {}
+
 // SetBlocking puts the file into blocking mode.
 func (fd *FD) SetBlocking() error {
 	if err := fd.incref(); err != nil {
@@ -124,12 +128,45 @@ func (fd *FD) SetBlocking() error {
 	}
 	defer fd.decref()
 	// Atomic store so that concurrent calls to SetBlocking
-	// do not cause a race condition. isBlocking only ever goes
-	// from 0 to 1 so there is no real race here.
+	// do not cause a race condition. isBlocking only goes
+	// from 1 to 0 in SetNonblock, which excludes all I/O,
+	// so there is no real race here.
 	atomic.StoreUint32(&fd.isBlocking, 1)
 	return syscall.SetNonblock(fd.Sysfd, false)
 }
 
+// SetNonblock puts the file into non-blocking mode, adding it to the
+// runtime poller if it is not already. If the poller does not support
+// the file, SetNonblock returns an error and leaves it in blocking mode.
+func (fd *FD) SetNonblock() error {
+	// Hold both locks so that no read or write is waiting
+	// in the poller while its registration changes.
+	if err := fd.readLock(); err != nil {
+		return err
+	}
+	defer fd.readUnlock()
+	if err := fd.writeLock(); err != nil {
+		return err
+	}
+	defer fd.writeUnlock()
+	if !fd.pd.pollable() {
+		if err := fd.pd.init(fd); err != nil {
+			return err
+		}
+	}
+	if err := syscall.SetNonblock(fd.Sysfd, true); err != nil {
+		return err
+	}
+	atomic.StoreUint32(&fd.isBlocking, 0)
+	return nil
+}
+
+// IsNonblock reports whether the file is in non-blocking mode,
+// with reads and writes waiting in the runtime poller.
+func (fd *FD) IsNonblock() bool {
+	return atomic.LoadUint32(&fd.isBlocking) == 0 && fd.pd.pollable()
+}
+
 // Darwin and FreeBSD can't read or write 2GB+ files at a time,
 // even on 64-bit systems.
 // The same is true of socket implementations on many systems.
@@ -328,6 +328,12 @@ type FD struct {
 	isBlocking bool
 
 	disassociated atomic.Bool
+
+	// Positioned IO operations on an overlapped handle in progress,
+	// and the deadlines they observe. See execOverlappedAt.
+	atMu       sync.Mutex
+	atOps      map[*atOperation]struct{}
+	atDeadline [2]int64 // read and write deadlines as runtimeNano, 0 for none, -1 if expired
 }
 
 // setOffset sets the offset fields of the overlapped object
@@ -360,6 +366,12 @@ func (fd *FD) pollable() bool {
 	return fd.pd.pollable() && !fd.disassociated.Load()
 }
 
+// IsNonblock reports whether the file was opened for overlapped I/O,
+// with reads and writes waiting in the runtime poller.
+func (fd *FD) IsNonblock() bool {
+	return !fd.isBlocking && fd.pollable()
+}
+
 // fileKind describes the kind of file.
 type fileKind byte
 
@@ -479,6 +491,7 @@ func (fd *FD) Close() error {
 	}
 	// unblock pending reader and writer
 	fd.pd.evict()
+	fd.wakeAt()
 	err := fd.decref()
 	// Wait until the descriptor is closed. If this was the only
 	// reference, it is already closed.
@@ -634,6 +647,30 @@ func (fd *FD) Pread(b []byte, off int64) (int, error) {
 		b = b[:maxRW]
 	}
 
+	read := func(o *operation) error {
+		return syscall.ReadFile(o.fd.Sysfd, unsafe.Slice(o.buf.Buf, o.buf.Len), &o.qty, &o.o)
+	}
+	var n int
+	var err error
+	if fd.isBlocking {
+		n, err = fd.execBlockingAt(&fd.rop, b, off, read)
+	} else {
+		n, err = fd.execOverlappedAt('r', b, off, read)
+	}
+	if err == syscall.ERROR_HANDLE_EOF {
+		err = io.EOF
+	}
+	if len(b) != 0 {
+		err = fd.eofError(n, err)
+	}
+	return n, err
+}
+
+// execBlockingAt executes a positioned IO operation on a handle
+// not opened for overlapped IO. Synchronous IO with an explicit
+// offset still moves the file pointer, so the previous position is
+// restored afterwards, serializing with other positioned operations.
+func (fd *FD) execBlockingAt(o *operation, b []byte, off int64, submit func(o *operation) error) (int, error) {
 	fd.l.Lock()
 	defer fd.l.Unlock()
 	curoffset, err := syscall.Seek(fd.Sysfd, 0, io.SeekCurrent)
@@ -642,19 +679,156 @@ func (fd *FD) Pread(b []byte, off int64) (int, error) {
 	}
 	defer syscall.Seek(fd.Sysfd, curoffset, io.SeekStart)
 	defer fd.setOffset(curoffset)
-	o := &fd.rop
 	o.InitBuf(b)
 	fd.setOffset(off)
-	n, err := execIO(o, func(o *operation) error {
-		return syscall.ReadFile(o.fd.Sysfd, unsafe.Slice(o.buf.Buf, o.buf.Len), &o.qty, &o.o)
-	})
-	if err == syscall.ERROR_HANDLE_EOF {
-		err = io.EOF
+	return execIO(o, submit)
+}
+
+// execOverlappedAt executes a positioned IO operation on a handle
+// opened for overlapped IO. The offset is carried by a dedicated
+// operation and its completion is signaled through a private event
+// rather than the runtime poller, so concurrent calls at independent
+// offsets proceed in parallel and never touch fd.offset.
+// Close and changes to the deadlines wake the waiting call,
+// which cancels the IO if the file is closing or the deadline has passed.
+func (fd *FD) execOverlappedAt(mode int32, b []byte, off int64, submit func(o *operation) error) (int, error) {
+	o := &atOperation{operation: operation{fd: fd, mode: mode}}
+	o.o.OffsetHigh, o.o.Offset = uint32(off>>32), uint32(off)
+	o.InitBuf(b)
+	o.setEvent()
+	defer o.close()
+	wake, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		return 0, err
 	}
-	if len(b) != 0 {
-		err = fd.eofError(n, err)
+	defer syscall.CloseHandle(wake)
+	o.wake = wake
+
+	fd.atMu.Lock()
+	if fd.atOps == nil {
+		fd.atOps = make(map[*atOperation]struct{})
+	}
+	fd.atOps[o] = struct{}{}
+	fd.atMu.Unlock()
+	defer func() {
+		fd.atMu.Lock()
+		delete(fd.atOps, o)
+		fd.atMu.Unlock()
+	}()
+
+	// Report a closing file or an expired deadline before starting IO.
+	if _, err := fd.atTimeout(mode); err != nil {
+		return 0, err
+	}
+	err = submit(&o.operation)
+	if err != nil && err != syscall.ERROR_IO_PENDING {
+		return int(o.qty), err
+	}
+	waitErr := o.wait()
+	err = windows.GetOverlappedResult(fd.Sysfd, &o.o, &o.qty, false)
+	if err == syscall.ERROR_OPERATION_ABORTED && waitErr != nil {
+		// IO canceled by wait.
+		err = waitErr
+	}
+	return int(o.qty), err
+}
+
+// atOperation is a positioned IO operation on an overlapped handle.
+type atOperation struct {
+	operation
+
+	// Auto-reset event signaled by wakeAt.
+	wake syscall.Handle
+}
+
+// wait waits for the IO to complete. If the file is closing or the
+// deadline passes first, wait cancels the IO, waits for the cancellation
+// to complete, and returns the corresponding error.
+func (o *atOperation) wait() error {
+	fd := o.fd
+	// The low bit of the IO event is set, so no completion packet
+	// is queued to the IOCP; wait for the event to be signaled.
+	handles := [2]syscall.Handle{o.o.HEvent, o.wake}
+	for {
+		timeout, err := fd.atTimeout(o.mode)
+		if err == nil {
+			var s uint32
+			s, err = windows.WaitForMultipleObjects(uint32(len(handles)), &handles[0], false, timeout)
+			switch s {
+			case syscall.WAIT_OBJECT_0:
+				return nil
+			case syscall.WAIT_OBJECT_0 + 1, syscall.WAIT_TIMEOUT:
+				// Woken by wakeAt or the deadline; check again.
+				continue
+			}
+		}
+		// Cancel our request. ERROR_NOT_FOUND means it has completed.
+		syscall.CancelIoEx(fd.Sysfd, &o.o)
+		syscall.WaitForSingleObject(o.o.HEvent, syscall.INFINITE)
+		return err
+	}
+}
+
+// atTimeout returns how long positioned IO in the given mode may wait
+// before its deadline passes, or an error if the file is closing or
+// the deadline has already passed.
+func (fd *FD) atTimeout(mode int32) (uint32, error) {
+	if fd.closing() {
+		return 0, errClosing(fd.isFile)
+	}
+	fd.atMu.Lock()
+	d := fd.atDeadline[atDeadlineIndex(int(mode))]
+	fd.atMu.Unlock()
+	if d == 0 {
+		return syscall.INFINITE, nil
+	}
+	left := d - runtimeNano()
+	if left <= 0 {
+		return 0, ErrDeadlineExceeded
+	}
+	// Round up, so that we wake up after the deadline, not just before it.
+	ms := (left + 1e6 - 1) / 1e6
+	if ms >= syscall.INFINITE {
+		ms = syscall.INFINITE - 1
+	}
+	return uint32(ms), nil
+}
+
+// setAtDeadline records a deadline set by setDeadlineImpl for positioned
+// IO on overlapped handles, and wakes such IO in progress so that it
+// observes the new deadline. d is as for runtime_pollSetDeadline.
+func (fd *FD) setAtDeadline(d int64, mode int) {
+	if d > 0 {
+		d += runtimeNano()
+	} else if d < 0 {
+		d = -1
+	}
+	fd.atMu.Lock()
+	if mode == 'r' || mode == 'r'+'w' {
+		fd.atDeadline[atDeadlineIndex('r')] = d
+	}
+	if mode == 'w' || mode == 'r'+'w' {
+		fd.atDeadline[atDeadlineIndex('w')] = d
+	}
+	fd.atMu.Unlock()
+	fd.wakeAt()
+}
+
+func atDeadlineIndex(mode int) int {
+	if mode == 'w' {
+		return 1
+	}
+	return 0
+}
+
+// wakeAt wakes all positioned IO on overlapped handles
+// waiting in execOverlappedAt.
+func (fd *FD) wakeAt() {
+	fd.atMu.Lock()
+	defer fd.atMu.Unlock()
+	for o := range fd.atOps {
+		windows.SetEvent(o.wake)
 	}
-	return n, err
 }
 
 // ReadFrom wraps the recvfrom network call.
@@ -850,15 +1024,9 @@ func (fd *FD) Pwrite(buf []byte, off int64) (int, error) {
 	}
 	defer fd.decref()
 
-	fd.l.Lock()
-	defer fd.l.Unlock()
-	curoffset, err := syscall.Seek(fd.Sysfd, 0, io.SeekCurrent)
-	if err != nil {
-		return 0, err
+	write := func(o *operation) error {
+		return syscall.WriteFile(o.fd.Sysfd, unsafe.Slice(o.buf.Buf, o.buf.Len), &o.qty, &o.o)
 	}
-	defer syscall.Seek(fd.Sysfd, curoffset, io.SeekStart)
-	defer fd.setOffset(curoffset)
-
 	var ntotal int
 	for {
 		max := len(buf)
@@ -866,12 +1034,13 @@ func (fd *FD) Pwrite(buf []byte, off int64) (int, error) {
 			max = ntotal + maxRW
 		}
 		b := buf[ntotal:max]
-		o := &fd.wop
-		o.InitBuf(b)
-		fd.setOffset(off + int64(ntotal))
-		n, err := execIO(o, func(o *operation) error {
-			return syscall.WriteFile(o.fd.Sysfd, unsafe.Slice(o.buf.Buf, o.buf.Len), &o.qty, &o.o)
-		})
+		var n int
+		var err error
+		if fd.isBlocking {
+			n, err = fd.execBlockingAt(&fd.wop, b, off+int64(ntotal), write)
+		} else {
+			n, err = fd.execOverlappedAt('w', b, off+int64(ntotal), write)
+		}
 		if n > 0 {
 			ntotal += n
 		}
@@ -5,6 +5,7 @@
 #include "textflag.h"
 
 TEXT ·libc_arc4random_buf_trampoline(SB),NOSPLIT,$0-0; JMP libc_arc4random_buf(SB)
+TEXT ·libc_pthread_threadid_np_trampoline(SB),NOSPLIT,$0-0; JMP libc_pthread_threadid_np(SB)
 TEXT ·libc_getaddrinfo_trampoline(SB),NOSPLIT,$0-0; JMP libc_getaddrinfo(SB)
 TEXT ·libc_freeaddrinfo_trampoline(SB),NOSPLIT,$0-0; JMP libc_freeaddrinfo(SB)
 TEXT ·libc_getnameinfo_trampoline(SB),NOSPLIT,$0-0; JMP libc_getnameinfo(SB)
@@ -28,5 +29,13 @@ TEXT ·libc_mkdirat_trampoline(SB),NOSPLIT,$0-0; JMP libc_mkdirat(SB)
 TEXT ·libc_fchmodat_trampoline(SB),NOSPLIT,$0-0; JMP libc_fchmodat(SB)
 TEXT ·libc_fchownat_trampoline(SB),NOSPLIT,$0-0; JMP libc_fchownat(SB)
 TEXT ·libc_renameat_trampoline(SB),NOSPLIT,$0-0; JMP libc_renameat(SB)
+TEXT ·libc_renameatx_np_trampoline(SB),NOSPLIT,$0-0; JMP libc_renameatx_np(SB)
 TEXT ·libc_linkat_trampoline(SB),NOSPLIT,$0-0; JMP libc_linkat(SB)
 TEXT ·libc_symlinkat_trampoline(SB),NOSPLIT,$0-0; JMP libc_symlinkat(SB)
+TEXT ·libc_fclonefileat_trampoline(SB),NOSPLIT,$0-0; JMP libc_fclonefileat(SB)
+TEXT ·libc_fcopyfile_trampoline(SB),NOSPLIT,$0-0; JMP libc_fcopyfile(SB)
+TEXT ·libc_fcntl_trampoline(SB),NOSPLIT,$0-0; JMP libc_fcntl(SB)
+TEXT ·libc_fgetxattr_trampoline(SB),NOSPLIT,$0-0; JMP libc_fgetxattr(SB)
+TEXT ·libc_fsetxattr_trampoline(SB),NOSPLIT,$0-0; JMP libc_fsetxattr(SB)
+TEXT ·libc_flistxattr_trampoline(SB),NOSPLIT,$0-0; JMP libc_flistxattr(SB)
+TEXT ·libc_fremovexattr_trampoline(SB),NOSPLIT,$0-0; JMP libc_fremovexattr(SB)
@@ -24,3 +24,7 @@ TEXT ·libc_linkat_trampoline(SB),NOSPLIT,$0-0
         JMP	libc_linkat(SB)
 TEXT ·libc_symlinkat_trampoline(SB),NOSPLIT,$0-0
         JMP	libc_symlinkat(SB)
+TEXT ·libc_unveil_trampoline(SB),NOSPLIT,$0-0
+        JMP	libc_unveil(SB)
+TEXT ·libc_getthrid_trampoline(SB),NOSPLIT,$0-0
+        JMP	libc_getthrid(SB)
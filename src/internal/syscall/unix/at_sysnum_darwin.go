@@ -12,3 +12,8 @@ const (
 
 	UTIME_OMIT = -0x2
 )
+
+// O_NOFOLLOW_ANY causes open to fail with ELOOP if any
+// component of the path is a symbolic link.
+// It is supported on macOS 11 and later.
+const O_NOFOLLOW_ANY = 0x20000000
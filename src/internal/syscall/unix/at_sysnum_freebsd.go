@@ -10,8 +10,11 @@ const (
 	AT_EACCESS          = 0x100
 	AT_FDCWD            = -0x64
 	AT_REMOVEDIR        = 0x800
+	AT_RESOLVE_BENEATH  = 0x2000
 	AT_SYMLINK_NOFOLLOW = 0x200
 
+	O_RESOLVE_BENEATH = 0x800000
+
 	UTIME_OMIT = -0x2
 
 	unlinkatTrap       uintptr = syscall.SYS_UNLINKAT
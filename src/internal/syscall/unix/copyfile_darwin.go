@@ -0,0 +1,67 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"internal/abi"
+	"syscall"
+	"unsafe"
+)
+
+// Flags for Fcopyfile.
+const (
+	COPYFILE_ACL      = 1 << 0
+	COPYFILE_STAT     = 1 << 1
+	COPYFILE_XATTR    = 1 << 2
+	COPYFILE_DATA     = 1 << 3
+	COPYFILE_SECURITY = COPYFILE_STAT | COPYFILE_ACL
+	COPYFILE_METADATA = COPYFILE_SECURITY | COPYFILE_XATTR
+)
+
+func libc_fclonefileat_trampoline()
+
+//go:cgo_import_dynamic libc_fclonefileat fclonefileat "/usr/lib/libSystem.B.dylib"
+
+// Fclonefileat creates a clone of the file srcfd at path,
+// relative to the directory dstdirfd.
+// The clone shares data blocks with the source, and has the
+// same extended attributes and access control lists.
+func Fclonefileat(srcfd, dstdirfd int, path string, flags int) error {
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_fclonefileat_trampoline),
+		uintptr(srcfd),
+		uintptr(dstdirfd),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(flags),
+		0,
+		0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func libc_fcopyfile_trampoline()
+
+//go:cgo_import_dynamic libc_fcopyfile fcopyfile "/usr/lib/libSystem.B.dylib"
+
+// Fcopyfile copies the parts of the file from described by flags
+// to the file to.
+func Fcopyfile(from, to int, flags uint32) error {
+	_, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_fcopyfile_trampoline),
+		uintptr(from),
+		uintptr(to),
+		0, // copyfile_state_t
+		uintptr(flags),
+		0,
+		0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
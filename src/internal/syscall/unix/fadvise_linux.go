@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "internal/goarch"
+
+// Advice values for Fadvise.
+const (
+	POSIX_FADV_NORMAL     = 0
+	POSIX_FADV_RANDOM     = 1
+	POSIX_FADV_SEQUENTIAL = 2
+	POSIX_FADV_WILLNEED   = 3
+
+	// s390x numbers these differently.
+	POSIX_FADV_DONTNEED = 4 + 2*goarch.IsS390x
+	POSIX_FADV_NOREUSE  = 5 + 2*goarch.IsS390x
+)
@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+func Fadvise(fd int, off int64, n int64, advice int) error {
+	// arm_fadvise64_64 takes the advice before the offset and length,
+	// so that the 64-bit arguments are aligned to register pairs.
+	_, _, errno := syscall.Syscall6(syscall.SYS_ARM_FADVISE64_64, uintptr(fd), uintptr(advice),
+		uintptr(off), uintptr(off>>32),
+		uintptr(n), uintptr(n>>32))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux && (mips || mipsle)
+
+package unix
+
+import "syscall"
+
+// Fadvise is not implemented: the o32 fadvise64 system call
+// takes seven arguments.
+func Fadvise(fd int, off int64, n int64, advice int) error {
+	return syscall.ENOSYS
+}
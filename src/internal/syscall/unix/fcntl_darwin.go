@@ -0,0 +1,48 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"internal/abi"
+	"syscall"
+	"unsafe"
+)
+
+const F_PUNCHHOLE = 99
+
+// Fpunchhole_t is the argument to fcntl F_PUNCHHOLE.
+type Fpunchhole_t struct {
+	Flags    uint32
+	Reserved uint32
+	Offset   int64
+	Length   int64
+}
+
+func libc_fcntl_trampoline()
+
+//go:cgo_import_dynamic libc_fcntl fcntl "/usr/lib/libSystem.B.dylib"
+
+func fcntlPtr(fd int, cmd int, arg unsafe.Pointer) error {
+	_, _, errno := syscall_syscall(abi.FuncPCABI0(libc_fcntl_trampoline),
+		uintptr(fd),
+		uintptr(cmd),
+		uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// FcntlPreallocate allocates storage for the file fd
+// as described by store, using fcntl F_PREALLOCATE.
+func FcntlPreallocate(fd int, store *syscall.Fstore_t) error {
+	return fcntlPtr(fd, syscall.F_PREALLOCATE, unsafe.Pointer(store))
+}
+
+// FcntlPunchhole deallocates the storage of the range
+// of the file fd described by hole, using fcntl F_PUNCHHOLE.
+func FcntlPunchhole(fd int, hole *Fpunchhole_t) error {
+	return fcntlPtr(fd, F_PUNCHHOLE, unsafe.Pointer(hole))
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+// FICLONE is _IOW(0x94, 9, int).
+const FICLONE = iocWrite | 4<<16 | 0x94<<8 | 9
+
+// IoctlFileClone makes the file destFd share the contents of srcFd.
+func IoctlFileClone(destFd, srcFd int) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(destFd), FICLONE, uintptr(srcFd))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"internal/goarch"
+	"syscall"
+	"unsafe"
+)
+
+// Inode flags, read and written by FS_IOC_GETFLAGS and FS_IOC_SETFLAGS.
+const (
+	FS_IMMUTABLE_FL = 0x10
+	FS_APPEND_FL    = 0x20
+	FS_NODUMP_FL    = 0x40
+)
+
+// The ioctl requests are _IOR('f', 1, long) and _IOW('f', 2, long).
+// The direction bits _IOC_READ and _IOC_WRITE are swapped on mips and ppc64.
+const (
+	iocSwap  = goarch.IsMips | goarch.IsMipsle | goarch.IsMips64 | goarch.IsMips64le | goarch.IsPpc64 | goarch.IsPpc64le
+	iocRead  = 0x80000000 >> iocSwap
+	iocWrite = 0x40000000 << iocSwap
+
+	FS_IOC_GETFLAGS = iocRead | goarch.PtrSize<<16 | 'f'<<8 | 1
+	FS_IOC_SETFLAGS = iocWrite | goarch.PtrSize<<16 | 'f'<<8 | 2
+)
+
+// IoctlGetFlags returns the inode flags of the file fd.
+func IoctlGetFlags(fd int) (int32, error) {
+	// Despite the size encoded in the request, the kernel
+	// reads and writes an int.
+	var flags int32
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), FS_IOC_GETFLAGS, uintptr(unsafe.Pointer(&flags)))
+	if errno != 0 {
+		return 0, errno
+	}
+	return flags, nil
+}
+
+// IoctlSetFlags sets the inode flags of the file fd.
+func IoctlSetFlags(fd int, flags int32) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), FS_IOC_SETFLAGS, uintptr(unsafe.Pointer(&flags)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"internal/abi"
+	"unsafe"
+)
+
+//go:cgo_import_dynamic libc_pthread_threadid_np pthread_threadid_np "/usr/lib/libSystem.B.dylib"
+
+func libc_pthread_threadid_np_trampoline()
+
+// Gettid returns the system-wide ID of the calling thread,
+// as reported by pthread_threadid_np(3).
+func Gettid() uint64 {
+	var id uint64
+	syscall_syscall(abi.FuncPCABI0(libc_pthread_threadid_np_trampoline),
+		0, uintptr(unsafe.Pointer(&id)), 0)
+	return id
+}
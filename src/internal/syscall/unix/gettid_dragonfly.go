@@ -0,0 +1,14 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+// Gettid returns the ID of the calling thread (LWP),
+// as reported by lwp_gettid(2).
+func Gettid() uint64 {
+	id, _, _ := syscall.RawSyscall(syscall.SYS_LWP_GETTID, 0, 0, 0)
+	return uint64(id)
+}
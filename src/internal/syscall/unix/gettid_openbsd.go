@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build openbsd && !mips64
+
+package unix
+
+import "internal/abi"
+
+//go:cgo_import_dynamic libc_getthrid getthrid "libc.so"
+
+func libc_getthrid_trampoline()
+
+// Gettid returns the ID of the calling thread, as reported by getthrid(2).
+func Gettid() uint64 {
+	id, _, _ := syscall_syscall(abi.FuncPCABI0(libc_getthrid_trampoline), 0, 0, 0)
+	return uint64(id)
+}
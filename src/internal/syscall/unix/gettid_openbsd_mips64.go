@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build openbsd && mips64
+
+package unix
+
+import "syscall"
+
+// Gettid returns the ID of the calling thread, as reported by getthrid(2).
+func Gettid() uint64 {
+	id, _, _ := syscall.RawSyscall(syscall.SYS_GETTHRID, 0, 0, 0)
+	return uint64(id)
+}
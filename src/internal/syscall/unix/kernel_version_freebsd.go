@@ -46,3 +46,13 @@ var SupportCopyFileRange = sync.OnceValue(func() bool {
 	_, err := CopyFileRange(0, nil, 0, nil, 0, 0)
 	return major >= 13 && err != syscall.ENOSYS
 })
+
+// SupportResolveBeneath reports whether the kernel supports the
+// O_RESOLVE_BENEATH open flag and the AT_RESOLVE_BENEATH *at flag.
+// Older kernels silently ignore unknown open flags,
+// so callers must check this before relying on either flag.
+var SupportResolveBeneath = sync.OnceValue(func() bool {
+	// O_RESOLVE_BENEATH and AT_RESOLVE_BENEATH first appeared in FreeBSD 13.0.
+	major, _ := KernelVersion()
+	return major >= 13
+})
@@ -0,0 +1,10 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+// Open flags missing from the syscall package on some architectures.
+const (
+	O_PATH = 0x200000
+)
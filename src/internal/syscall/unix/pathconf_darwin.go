@@ -0,0 +1,9 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+// PC_CASE_SENSITIVE is the pathconf variable reporting whether
+// the filesystem is case-sensitive.
+const PC_CASE_SENSITIVE = 11
@@ -0,0 +1,29 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// The offset is passed to preadv and pwritev as two longs, low half first.
+// On 64-bit systems the kernel ignores the high half.
+
+func Preadv(fd int, iovs []syscall.Iovec, off int64) (int, error) {
+	n, _, errno := syscall.Syscall6(syscall.SYS_PREADV, uintptr(fd), uintptr(unsafe.Pointer(unsafe.SliceData(iovs))), uintptr(len(iovs)), uintptr(off), uintptr(off>>32), 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func Pwritev(fd int, iovs []syscall.Iovec, off int64) (int, error) {
+	n, _, errno := syscall.Syscall6(syscall.SYS_PWRITEV, uintptr(fd), uintptr(unsafe.Pointer(unsafe.SliceData(iovs))), uintptr(len(iovs)), uintptr(off), uintptr(off>>32), 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
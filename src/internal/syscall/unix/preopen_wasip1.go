@@ -0,0 +1,18 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build wasip1
+
+package unix
+
+import _ "unsafe" // for linkname
+
+// Preopen returns the file descriptor and name of the i'th directory
+// preopened by the WASI runtime, and reports whether it exists.
+//
+// This helper is implemented in the syscall package,
+// which discovers the preopened directories at initialization.
+//
+//go:linkname Preopen syscall.preopen
+func Preopen(i int) (fd int, name string, ok bool)
@@ -0,0 +1,44 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build wasip1
+
+package unix
+
+import (
+	"structs"
+	"syscall"
+)
+
+// https://github.com/WebAssembly/WASI/blob/a2b96e81c0586125cc4dc79a5be0b78d9a059925/legacy/preview1/docs.md#-fdstat-record
+type fdstat struct {
+	_                structs.HostLayout
+	filetype         uint8
+	fdflags          uint16
+	rightsBase       uint64
+	rightsInheriting uint64
+}
+
+//go:wasmimport wasi_snapshot_preview1 fd_fdstat_get
+//go:noescape
+func fd_fdstat_get(fd int32, buf *fdstat) syscall.Errno
+
+//go:wasmimport wasi_snapshot_preview1 fd_fdstat_set_rights
+//go:noescape
+func fd_fdstat_set_rights(fd int32, rightsBase uint64, rightsInheriting uint64) syscall.Errno
+
+// FdstatGetRights returns the base and inheriting rights of fd.
+func FdstatGetRights(fd int) (base, inheriting uint64, err error) {
+	var stat fdstat
+	if errno := fd_fdstat_get(int32(fd), &stat); errno != 0 {
+		return 0, 0, errno
+	}
+	return stat.rightsBase, stat.rightsInheriting, nil
+}
+
+// FdstatSetRights restricts the base and inheriting rights of fd.
+// Rights may only be removed from a descriptor, never added.
+func FdstatSetRights(fd int, base, inheriting uint64) error {
+	return errnoErr(fd_fdstat_set_rights(int32(fd), base, inheriting))
+}
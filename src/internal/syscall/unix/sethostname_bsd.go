@@ -0,0 +1,37 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build dragonfly || freebsd || netbsd
+
+package unix
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Sethostname sets the kern.hostname sysctl to name.
+func Sethostname(name []byte) error {
+	const (
+		ctlKern      = 1  // CTL_KERN
+		kernHostname = 10 // KERN_HOSTNAME
+	)
+	mib := [2]uint32{ctlKern, kernHostname}
+	var p unsafe.Pointer
+	if len(name) > 0 {
+		p = unsafe.Pointer(&name[0])
+	}
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS___SYSCTL,
+		uintptr(unsafe.Pointer(&mib[0])),
+		uintptr(len(mib)),
+		0,                  // olddata
+		0,                  // &oldlen
+		uintptr(p),         // newdata
+		uintptr(len(name))) // newlen
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
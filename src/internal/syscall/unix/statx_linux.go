@@ -0,0 +1,67 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	AT_EMPTY_PATH = 0x1000
+
+	STATX_BASIC_STATS   = 0x7ff
+	STATX_BTIME         = 0x800
+	STATX_MNT_ID        = 0x1000
+	STATX_DIOALIGN      = 0x2000
+	STATX_MNT_ID_UNIQUE = 0x4000
+)
+
+type StatxTimestamp struct {
+	Sec  int64
+	Nsec uint32
+	_    int32
+}
+
+// Statx_t is the struct statx filled in by statx(2).
+type Statx_t struct {
+	Mask           uint32
+	Blksize        uint32
+	Attributes     uint64
+	Nlink          uint32
+	Uid            uint32
+	Gid            uint32
+	Mode           uint16
+	_              uint16
+	Ino            uint64
+	Size           uint64
+	Blocks         uint64
+	AttributesMask uint64
+	Atime          StatxTimestamp
+	Btime          StatxTimestamp
+	Ctime          StatxTimestamp
+	Mtime          StatxTimestamp
+	RdevMajor      uint32
+	RdevMinor      uint32
+	DevMajor       uint32
+	DevMinor       uint32
+	MntID          uint64
+	DioMemAlign    uint32
+	DioOffsetAlign uint32
+	_              [12]uint64
+}
+
+// Statx calls statx(2), which first appeared in Linux 4.11.
+func Statx(dirfd int, path string, flags int, mask int, stat *Statx_t) error {
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(statxTrap, uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(flags), uintptr(mask), uintptr(unsafe.Pointer(stat)), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+func SyncFileRange(fd int, off int64, n int64, flags int) error {
+	// arm_sync_file_range takes the flags before the offset and length,
+	// so that the 64-bit arguments are aligned to register pairs.
+	_, _, errno := syscall.Syscall6(syscall.SYS_ARM_SYNC_FILE_RANGE,
+		uintptr(fd), uintptr(flags),
+		uintptr(off), uintptr(off>>32),
+		uintptr(n), uintptr(n>>32))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+// Syncfs commits the file system containing the file referred to by fd
+// to storage.
+func Syncfs(fd int) error {
+	_, _, errno := syscall.Syscall(syncfsTrap, uintptr(fd), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -10,4 +10,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 383
+	memfdCreateTrap     uintptr = 356
+	syncfsTrap          uintptr = 344
+	renameat2Trap       uintptr = 353
 )
@@ -10,4 +10,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 332
+	memfdCreateTrap     uintptr = 319
+	syncfsTrap          uintptr = 306
+	renameat2Trap       uintptr = 316
 )
@@ -10,4 +10,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 397
+	memfdCreateTrap     uintptr = 385
+	syncfsTrap          uintptr = 373
+	renameat2Trap       uintptr = 382
 )
@@ -16,4 +16,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 291
+	memfdCreateTrap     uintptr = 279
+	syncfsTrap          uintptr = 267
+	renameat2Trap       uintptr = 276
 )
@@ -12,4 +12,8 @@ const (
 	pidfdSendSignalTrap uintptr = 5424
 	pidfdOpenTrap       uintptr = 5434
 	openat2Trap         uintptr = 5437
+	statxTrap           uintptr = 5326
+	memfdCreateTrap     uintptr = 5314
+	syncfsTrap          uintptr = 5301
+	renameat2Trap       uintptr = 5311
 )
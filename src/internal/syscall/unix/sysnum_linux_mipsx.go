@@ -12,4 +12,8 @@ const (
 	pidfdSendSignalTrap uintptr = 4424
 	pidfdOpenTrap       uintptr = 4434
 	openat2Trap         uintptr = 4437
+	statxTrap           uintptr = 4366
+	memfdCreateTrap     uintptr = 4354
+	syncfsTrap          uintptr = 4342
+	renameat2Trap       uintptr = 4351
 )
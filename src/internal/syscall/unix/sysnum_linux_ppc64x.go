@@ -12,4 +12,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 383
+	memfdCreateTrap     uintptr = 360
+	syncfsTrap          uintptr = 348
+	renameat2Trap       uintptr = 357
 )
@@ -10,4 +10,8 @@ const (
 	pidfdSendSignalTrap uintptr = 424
 	pidfdOpenTrap       uintptr = 434
 	openat2Trap         uintptr = 437
+	statxTrap           uintptr = 379
+	memfdCreateTrap     uintptr = 350
+	syncfsTrap          uintptr = 338
+	renameat2Trap       uintptr = 347
 )
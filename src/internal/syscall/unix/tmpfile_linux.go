@@ -0,0 +1,11 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import "syscall"
+
+// O_TMPFILE is __O_TMPFILE | O_DIRECTORY.
+// The syscall package's value is wrong on some architectures.
+const O_TMPFILE = 0x400000 | syscall.O_DIRECTORY
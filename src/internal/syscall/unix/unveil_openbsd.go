@@ -0,0 +1,44 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build openbsd && !mips64
+
+package unix
+
+import (
+	"internal/abi"
+	"syscall"
+	"unsafe"
+)
+
+//go:cgo_import_dynamic libc_unveil unveil "libc.so"
+
+func libc_unveil_trampoline()
+
+// Unveil calls unveil(2) with the given path and permissions.
+func Unveil(path, permissions string) error {
+	p0, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	p1, err := syscall.BytePtrFromString(permissions)
+	if err != nil {
+		return err
+	}
+	return unveil(unsafe.Pointer(p0), unsafe.Pointer(p1))
+}
+
+// UnveilLock calls unveil(2) with NULL arguments,
+// preventing any further calls to unveil.
+func UnveilLock() error {
+	return unveil(nil, nil)
+}
+
+func unveil(path, permissions unsafe.Pointer) error {
+	_, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_unveil_trampoline), uintptr(path), uintptr(permissions), 0, 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build openbsd && mips64
+
+package unix
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Unveil calls unveil(2) with the given path and permissions.
+func Unveil(path, permissions string) error {
+	p0, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	p1, err := syscall.BytePtrFromString(permissions)
+	if err != nil {
+		return err
+	}
+	return unveil(unsafe.Pointer(p0), unsafe.Pointer(p1))
+}
+
+// UnveilLock calls unveil(2) with NULL arguments,
+// preventing any further calls to unveil.
+func UnveilLock() error {
+	return unveil(nil, nil)
+}
+
+func unveil(path, permissions unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_UNVEIL, uintptr(path), uintptr(permissions), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const FS_VERITY_HASH_ALG_SHA256 = 1
+
+// The ioctl requests are _IOW('f', 133, struct fsverity_enable_arg)
+// and _IOWR('f', 134, struct fsverity_digest).
+const (
+	FS_IOC_ENABLE_VERITY  = iocWrite | 128<<16 | 'f'<<8 | 133
+	FS_IOC_MEASURE_VERITY = iocRead | iocWrite | 4<<16 | 'f'<<8 | 134
+)
+
+// fsverityEnableArg is struct fsverity_enable_arg.
+type fsverityEnableArg struct {
+	version       uint32
+	hashAlgorithm uint32
+	blockSize     uint32
+	saltSize      uint32
+	saltPtr       uint64
+	sigSize       uint32
+	_             uint32
+	sigPtr        uint64
+	_             [11]uint64
+}
+
+// IoctlEnableVerity enables fs-verity on the file fd,
+// using the given hash algorithm and Merkle tree block size.
+func IoctlEnableVerity(fd int, hashAlgorithm, blockSize uint32) error {
+	arg := fsverityEnableArg{
+		version:       1,
+		hashAlgorithm: hashAlgorithm,
+		blockSize:     blockSize,
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), FS_IOC_ENABLE_VERITY, uintptr(unsafe.Pointer(&arg)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// fsverityMaxDigestSize is FS_VERITY_MAX_DIGEST_SIZE.
+const fsverityMaxDigestSize = 64
+
+// fsverityDigest is struct fsverity_digest, followed by
+// room for the largest supported digest.
+type fsverityDigest struct {
+	algorithm uint16
+	size      uint16
+	digest    [fsverityMaxDigestSize]byte
+}
+
+// IoctlMeasureVerity returns the hash algorithm and
+// fs-verity digest of the file fd.
+func IoctlMeasureVerity(fd int) (algorithm uint16, digest []byte, err error) {
+	d := fsverityDigest{size: fsverityMaxDigestSize}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), FS_IOC_MEASURE_VERITY, uintptr(unsafe.Pointer(&d)))
+	if errno != 0 {
+		return 0, nil, errno
+	}
+	return d.algorithm, d.digest[:d.size], nil
+}
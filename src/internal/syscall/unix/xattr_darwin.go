@@ -0,0 +1,96 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix
+
+import (
+	"internal/abi"
+	"syscall"
+	"unsafe"
+)
+
+// The position and options arguments of the Darwin xattr functions
+// are always zero: position applies only to resource forks,
+// and the options do not apply to file descriptors.
+
+func libc_fgetxattr_trampoline()
+
+//go:cgo_import_dynamic libc_fgetxattr fgetxattr "/usr/lib/libSystem.B.dylib"
+
+func Fgetxattr(fd int, attr string, dest []byte) (int, error) {
+	p, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return 0, err
+	}
+	n, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_fgetxattr_trampoline),
+		uintptr(fd),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(bufPtr(dest)),
+		uintptr(len(dest)),
+		0,
+		0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func libc_fsetxattr_trampoline()
+
+//go:cgo_import_dynamic libc_fsetxattr fsetxattr "/usr/lib/libSystem.B.dylib"
+
+func Fsetxattr(fd int, attr string, data []byte, flags int) error {
+	p, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_fsetxattr_trampoline),
+		uintptr(fd),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(bufPtr(data)),
+		uintptr(len(data)),
+		0,
+		uintptr(flags))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func libc_flistxattr_trampoline()
+
+//go:cgo_import_dynamic libc_flistxattr flistxattr "/usr/lib/libSystem.B.dylib"
+
+func Flistxattr(fd int, dest []byte) (int, error) {
+	n, _, errno := syscall_syscall6(abi.FuncPCABI0(libc_flistxattr_trampoline),
+		uintptr(fd),
+		uintptr(bufPtr(dest)),
+		uintptr(len(dest)),
+		0,
+		0,
+		0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func libc_fremovexattr_trampoline()
+
+//go:cgo_import_dynamic libc_fremovexattr fremovexattr "/usr/lib/libSystem.B.dylib"
+
+func Fremovexattr(fd int, attr string) error {
+	p, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall_syscall(abi.FuncPCABI0(libc_fremovexattr_trampoline),
+		uintptr(fd),
+		uintptr(unsafe.Pointer(p)),
+		0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
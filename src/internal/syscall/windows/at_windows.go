@@ -23,9 +23,20 @@ const (
 	O_NOFOLLOW_ANY = 0x20000000 // disallow symlinks anywhere in the path
 	O_OPEN_REPARSE = 0x40000000 // FILE_OPEN_REPARSE_POINT, used by Lstat
 	O_WRITE_ATTRS  = 0x80000000 // FILE_WRITE_ATTRIBUTES, used by Chmod
+
+	// O_WRITE_DAC requests READ_CONTROL and WRITE_DAC access in place of
+	// read or write access, for changing a file's permissions.
+	O_WRITE_DAC = 0x100000000
 )
 
 func Openat(dirfd syscall.Handle, name string, flag uint64, perm uint32) (_ syscall.Handle, e1 error) {
+	return OpenatWithOptions(dirfd, name, flag, perm, FILE_SHARE_READ|FILE_SHARE_WRITE|FILE_SHARE_DELETE, 0)
+}
+
+// OpenatWithOptions is Openat with an explicit share mode and
+// a set of CreateFile FILE_FLAG_* values in fileFlags.
+// Flags without an NtCreateFile equivalent result in EINVAL.
+func OpenatWithOptions(dirfd syscall.Handle, name string, flag uint64, perm, shareMode, fileFlags uint32) (_ syscall.Handle, e1 error) {
 	if len(name) == 0 {
 		return syscall.InvalidHandle, syscall.ERROR_FILE_NOT_FOUND
 	}
@@ -67,9 +78,44 @@ func Openat(dirfd syscall.Handle, name string, flag uint64, perm uint32) (_ sysc
 	if flag&O_WRITE_ATTRS != 0 {
 		access |= FILE_WRITE_ATTRIBUTES
 	}
+	if flag&O_WRITE_DAC != 0 {
+		access = READ_CONTROL | WRITE_DAC | access&FILE_WRITE_ATTRIBUTES
+	}
 	// Allow File.Stat.
 	access |= STANDARD_RIGHTS_READ | FILE_READ_ATTRIBUTES | FILE_READ_EA
 
+	const supportedFileFlags = FILE_FLAG_WRITE_THROUGH | FILE_FLAG_SEQUENTIAL_SCAN |
+		FILE_FLAG_RANDOM_ACCESS | FILE_FLAG_NO_BUFFERING | FILE_FLAG_DELETE_ON_CLOSE |
+		FILE_FLAG_OPEN_REPARSE_POINT | FILE_FLAG_OVERLAPPED | FILE_FLAG_BACKUP_SEMANTICS
+	if fileFlags&^supportedFileFlags != 0 {
+		return syscall.InvalidHandle, syscall.EINVAL
+	}
+	if fileFlags&FILE_FLAG_WRITE_THROUGH != 0 {
+		options |= FILE_WRITE_THROUGH
+	}
+	if fileFlags&FILE_FLAG_SEQUENTIAL_SCAN != 0 {
+		options |= FILE_SEQUENTIAL_ONLY
+	}
+	if fileFlags&FILE_FLAG_RANDOM_ACCESS != 0 {
+		options |= FILE_RANDOM_ACCESS
+	}
+	if fileFlags&FILE_FLAG_NO_BUFFERING != 0 {
+		options |= FILE_NO_INTERMEDIATE_BUFFERING
+	}
+	if fileFlags&FILE_FLAG_DELETE_ON_CLOSE != 0 {
+		options |= FILE_DELETE_ON_CLOSE
+		access |= DELETE
+	}
+	if fileFlags&FILE_FLAG_OPEN_REPARSE_POINT != 0 {
+		options |= FILE_OPEN_REPARSE_POINT
+	}
+	// FILE_FLAG_BACKUP_SEMANTICS needs no translation:
+	// we always open with FILE_OPEN_FOR_BACKUP_INTENT.
+	syncOptions := uint32(FILE_SYNCHRONOUS_IO_NONALERT)
+	if fileFlags&FILE_FLAG_OVERLAPPED != 0 {
+		syncOptions = 0
+	}
+
 	objAttrs := &OBJECT_ATTRIBUTES{}
 	if flag&O_NOFOLLOW_ANY != 0 {
 		objAttrs.Attributes |= OBJ_DONT_REPARSE
@@ -114,9 +160,9 @@ func Openat(dirfd syscall.Handle, name string, flag uint64, perm uint32) (_ sysc
 		&IO_STATUS_BLOCK{},
 		nil,
 		fileAttrs,
-		FILE_SHARE_READ|FILE_SHARE_WRITE|FILE_SHARE_DELETE,
+		shareMode,
 		disposition,
-		FILE_SYNCHRONOUS_IO_NONALERT|FILE_OPEN_FOR_BACKUP_INTENT|options,
+		syncOptions|FILE_OPEN_FOR_BACKUP_INTENT|options,
 		nil,
 		0,
 	)
@@ -284,19 +330,14 @@ func Renameat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle,
 	}
 	defer syscall.CloseHandle(h)
 
-	renameInfoEx := FILE_RENAME_INFORMATION_EX{
-		Flags: FILE_RENAME_REPLACE_IF_EXISTS |
-			FILE_RENAME_POSIX_SEMANTICS,
-		RootDirectory: newdirfd,
-	}
 	p16, err := syscall.UTF16FromString(newpath)
 	if err != nil {
 		return err
 	}
-	if len(p16) > len(renameInfoEx.FileName) {
-		return syscall.EINVAL
-	}
-	copy(renameInfoEx.FileName[:], p16)
+	renameInfoEx, renameInfoExSize := newFileNameInfo[FILE_RENAME_INFORMATION_EX](
+		unsafe.Offsetof(FILE_RENAME_INFORMATION_EX{}.FileName), p16)
+	renameInfoEx.Flags = FILE_RENAME_REPLACE_IF_EXISTS | FILE_RENAME_POSIX_SEMANTICS
+	renameInfoEx.RootDirectory = newdirfd
 	renameInfoEx.FileNameLength = uint32((len(p16) - 1) * 2)
 
 	const (
@@ -306,8 +347,8 @@ func Renameat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle,
 	err = NtSetInformationFile(
 		h,
 		&IO_STATUS_BLOCK{},
-		unsafe.Pointer(&renameInfoEx),
-		uint32(unsafe.Sizeof(FILE_RENAME_INFORMATION_EX{})),
+		unsafe.Pointer(renameInfoEx),
+		renameInfoExSize,
 		FileRenameInformationEx,
 	)
 	if err == nil {
@@ -319,18 +360,17 @@ func Renameat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle,
 	// FILE_RENAME_INFORMATION_EX.
 	//
 	// Try again.
-	renameInfo := FILE_RENAME_INFORMATION{
-		ReplaceIfExists: true,
-		RootDirectory:   newdirfd,
-	}
-	copy(renameInfo.FileName[:], p16)
+	renameInfo, renameInfoSize := newFileNameInfo[FILE_RENAME_INFORMATION](
+		unsafe.Offsetof(FILE_RENAME_INFORMATION{}.FileName), p16)
+	renameInfo.ReplaceIfExists = true
+	renameInfo.RootDirectory = newdirfd
 	renameInfo.FileNameLength = renameInfoEx.FileNameLength
 
 	err = NtSetInformationFile(
 		h,
 		&IO_STATUS_BLOCK{},
-		unsafe.Pointer(&renameInfo),
-		uint32(unsafe.Sizeof(FILE_RENAME_INFORMATION{})),
+		unsafe.Pointer(renameInfo),
+		renameInfoSize,
 		FileRenameInformation,
 	)
 	if st, ok := err.(NTStatus); ok {
@@ -358,17 +398,13 @@ func Linkat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle, ne
 	}
 	defer syscall.CloseHandle(h)
 
-	linkInfo := FILE_LINK_INFORMATION{
-		RootDirectory: newdirfd,
-	}
 	p16, err := syscall.UTF16FromString(newpath)
 	if err != nil {
 		return err
 	}
-	if len(p16) > len(linkInfo.FileName) {
-		return syscall.EINVAL
-	}
-	copy(linkInfo.FileName[:], p16)
+	linkInfo, linkInfoSize := newFileNameInfo[FILE_LINK_INFORMATION](
+		unsafe.Offsetof(FILE_LINK_INFORMATION{}.FileName), p16)
+	linkInfo.RootDirectory = newdirfd
 	linkInfo.FileNameLength = uint32((len(p16) - 1) * 2)
 
 	const (
@@ -377,8 +413,8 @@ func Linkat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle, ne
 	err = NtSetInformationFile(
 		h,
 		&IO_STATUS_BLOCK{},
-		unsafe.Pointer(&linkInfo),
-		uint32(unsafe.Sizeof(FILE_LINK_INFORMATION{})),
+		unsafe.Pointer(linkInfo),
+		linkInfoSize,
 		FileLinkInformation,
 	)
 	if st, ok := err.(NTStatus); ok {
@@ -387,6 +423,22 @@ func Linkat(olddirfd syscall.Handle, oldpath string, newdirfd syscall.Handle, ne
 	return err
 }
 
+// newFileNameInfo allocates an information structure of type T,
+// such as FILE_RENAME_INFORMATION, which ends in a variable-length
+// FileName field at offset nameOffset. It copies name into the FileName
+// field and returns the structure and its size in bytes.
+//
+// The FileName field in the Go definitions of these types has a fixed
+// size of MAX_PATH, but the names passed to the system may be longer.
+func newFileNameInfo[T any](nameOffset uintptr, name []uint16) (*T, uint32) {
+	size := max(unsafe.Sizeof(*new(T)), nameOffset+uintptr(len(name))*2)
+	// Allocate a []uint64 to ensure the structure is suitably aligned.
+	buf := make([]uint64, (size+7)/8)
+	info := (*T)(unsafe.Pointer(&buf[0]))
+	copy(unsafe.Slice((*uint16)(unsafe.Add(unsafe.Pointer(info), nameOffset)), len(name)), name)
+	return info, uint32(size)
+}
+
 // SymlinkatFlags configure Symlinkat.
 //
 // Symbolic links have two properties: They may be directory or file links,
@@ -429,29 +481,6 @@ func symlinkat(oldname string, newdirfd syscall.Handle, newname string, flags Sy
 		options |= FILE_NON_DIRECTORY_FILE
 	}
 
-	objAttrs := &OBJECT_ATTRIBUTES{}
-	if err := objAttrs.init(newdirfd, newname); err != nil {
-		return err
-	}
-	var h syscall.Handle
-	err = NtCreateFile(
-		&h,
-		SYNCHRONIZE|FILE_WRITE_ATTRIBUTES|DELETE,
-		objAttrs,
-		&IO_STATUS_BLOCK{},
-		nil,
-		syscall.FILE_ATTRIBUTE_NORMAL,
-		0,
-		FILE_CREATE,
-		FILE_OPEN_REPARSE_POINT|FILE_OPEN_FOR_BACKUP_INTENT|FILE_SYNCHRONOUS_IO_NONALERT|options,
-		nil,
-		0,
-	)
-	if err != nil {
-		return ntCreateFileError(err, 0)
-	}
-	defer syscall.CloseHandle(h)
-
 	// https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntifs/ns-ntifs-_reparse_data_buffer
 	type reparseDataBufferT struct {
 		_ structs.HostLayout
@@ -489,6 +518,94 @@ func symlinkat(oldname string, newdirfd syscall.Handle, newname string, flags Sy
 	namebuf := rdbbuf[bufferSize:]
 	copy(namebuf, unsafe.String((*byte)(unsafe.Pointer(&oldnameu16[0])), 2*len(oldnameu16)))
 
+	return createReparsePoint(newdirfd, newname, options, rdbbuf)
+}
+
+// Junctionat creates a directory junction (mount point) named newname
+// in the directory newdirfd.
+//
+// target is the NT path of the junction's target, such as \??\C:\dir,
+// and printName is its user-visible form, such as C:\dir.
+// Creating a junction does not require any privileges.
+func Junctionat(target, printName string, newdirfd syscall.Handle, newname string) error {
+	targetu16, err := syscall.UTF16FromString(target)
+	if err != nil {
+		return err
+	}
+	printu16, err := syscall.UTF16FromString(printName)
+	if err != nil {
+		return err
+	}
+
+	// https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntifs/ns-ntifs-_reparse_data_buffer
+	type reparseDataBufferT struct {
+		_ structs.HostLayout
+
+		ReparseTag        uint32
+		ReparseDataLength uint16
+		Reserved          uint16
+
+		SubstituteNameOffset uint16
+		SubstituteNameLength uint16
+		PrintNameOffset      uint16
+		PrintNameLength      uint16
+	}
+
+	const (
+		headerSize = uint16(unsafe.Offsetof(reparseDataBufferT{}.SubstituteNameOffset))
+		bufferSize = uint16(unsafe.Sizeof(reparseDataBufferT{}))
+	)
+
+	// Data buffer containing a MountPointReparseBuffer followed by
+	// the NUL-terminated substitute and print names.
+	namesLen := 2 * (len(targetu16) + len(printu16))
+	if int(bufferSize)+namesLen > syscall.MAXIMUM_REPARSE_DATA_BUFFER_SIZE {
+		return syscall.ENAMETOOLONG
+	}
+	rdbbuf := make([]byte, int(bufferSize)+namesLen)
+
+	rdb := (*reparseDataBufferT)(unsafe.Pointer(&rdbbuf[0]))
+	rdb.ReparseTag = IO_REPARSE_TAG_MOUNT_POINT
+	rdb.ReparseDataLength = uint16(len(rdbbuf)) - headerSize
+	rdb.SubstituteNameOffset = 0
+	rdb.SubstituteNameLength = uint16(2 * (len(targetu16) - 1))
+	rdb.PrintNameOffset = uint16(2 * len(targetu16))
+	rdb.PrintNameLength = uint16(2 * (len(printu16) - 1))
+
+	namebuf := rdbbuf[bufferSize:]
+	n := copy(namebuf, unsafe.String((*byte)(unsafe.Pointer(&targetu16[0])), 2*len(targetu16)))
+	copy(namebuf[n:], unsafe.String((*byte)(unsafe.Pointer(&printu16[0])), 2*len(printu16)))
+
+	return createReparsePoint(newdirfd, newname, FILE_DIRECTORY_FILE, rdbbuf)
+}
+
+// createReparsePoint creates a new file or directory named newname
+// in the directory newdirfd and sets its reparse data to rdbbuf.
+// If the reparse data cannot be set, the new file is removed.
+func createReparsePoint(newdirfd syscall.Handle, newname string, options uint32, rdbbuf []byte) error {
+	objAttrs := &OBJECT_ATTRIBUTES{}
+	if err := objAttrs.init(newdirfd, newname); err != nil {
+		return err
+	}
+	var h syscall.Handle
+	err := NtCreateFile(
+		&h,
+		SYNCHRONIZE|FILE_WRITE_ATTRIBUTES|DELETE,
+		objAttrs,
+		&IO_STATUS_BLOCK{},
+		nil,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0,
+		FILE_CREATE,
+		FILE_OPEN_REPARSE_POINT|FILE_OPEN_FOR_BACKUP_INTENT|FILE_SYNCHRONOUS_IO_NONALERT|options,
+		nil,
+		0,
+	)
+	if err != nil {
+		return ntCreateFileError(err, 0)
+	}
+	defer syscall.CloseHandle(h)
+
 	err = syscall.DeviceIoControl(
 		h,
 		FSCTL_SET_REPARSE_POINT,
@@ -499,7 +616,7 @@ func symlinkat(oldname string, newdirfd syscall.Handle, newname string, flags Sy
 		nil,
 		nil)
 	if err != nil {
-		// Creating the symlink has failed, so try to remove the file.
+		// Creating the reparse point has failed, so try to remove the file.
 		const FileDispositionInformation = 13
 		NtSetInformationFile(
 			h,
@@ -0,0 +1,63 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package windows
+
+import "syscall"
+
+// Known folder identifiers.
+// https://learn.microsoft.com/en-us/windows/win32/shell/knownfolderid
+var (
+	FOLDERID_Desktop = syscall.GUID{
+		Data1: 0xb4bfcc3a,
+		Data2: 0xdb2c,
+		Data3: 0x424c,
+		Data4: [8]byte{0xb0, 0x29, 0x7f, 0xe9, 0x9a, 0x87, 0xc6, 0x41},
+	}
+	FOLDERID_Documents = syscall.GUID{
+		Data1: 0xfdd39ad0,
+		Data2: 0x238f,
+		Data3: 0x46af,
+		Data4: [8]byte{0xad, 0xb4, 0x6c, 0x85, 0x48, 0x03, 0x69, 0xc7},
+	}
+	FOLDERID_Downloads = syscall.GUID{
+		Data1: 0x374de290,
+		Data2: 0x123f,
+		Data3: 0x4565,
+		Data4: [8]byte{0x91, 0x64, 0x39, 0xc4, 0x92, 0x5e, 0x46, 0x7b},
+	}
+	FOLDERID_Music = syscall.GUID{
+		Data1: 0x4bd8d571,
+		Data2: 0x6d19,
+		Data3: 0x48d3,
+		Data4: [8]byte{0xbe, 0x97, 0x42, 0x22, 0x20, 0x08, 0x0e, 0x43},
+	}
+	FOLDERID_Pictures = syscall.GUID{
+		Data1: 0x33e28130,
+		Data2: 0x4e1e,
+		Data3: 0x4676,
+		Data4: [8]byte{0x83, 0x5a, 0x98, 0x39, 0x5c, 0x3b, 0xc3, 0xbb},
+	}
+	FOLDERID_Videos = syscall.GUID{
+		Data1: 0x18989b1d,
+		Data2: 0x99b5,
+		Data3: 0x455b,
+		Data4: [8]byte{0x84, 0x1c, 0xab, 0x7c, 0x74, 0xe4, 0xdd, 0xfc},
+	}
+	FOLDERID_Templates = syscall.GUID{
+		Data1: 0xa63293e8,
+		Data2: 0x664e,
+		Data3: 0x48db,
+		Data4: [8]byte{0xa0, 0x79, 0xdf, 0x75, 0x9e, 0x05, 0x09, 0xf7},
+	}
+	FOLDERID_Public = syscall.GUID{
+		Data1: 0xdfdf76a2,
+		Data2: 0xc82a,
+		Data3: 0x4d63,
+		Data4: [8]byte{0x90, 0x6a, 0x56, 0x44, 0xac, 0x45, 0x73, 0x85},
+	}
+)
+
+//sys	SHGetKnownFolderPath(id *syscall.GUID, flags uint32, token syscall.Token, path **uint16) (ret error) = shell32.SHGetKnownFolderPath
+//sys	CoTaskMemFree(address unsafe.Pointer) = ole32.CoTaskMemFree
@@ -262,3 +262,32 @@ func GetSidSubAuthorityCount(sid *syscall.SID) uint8 {
 	defer runtime.KeepAlive(sid)
 	return *(*uint8)(unsafe.Pointer(getSidSubAuthorityCount(sid)))
 }
+
+// Values for the objectType parameter of GetSecurityInfo and SetSecurityInfo.
+const SE_FILE_OBJECT = 1
+
+// Values for the securityInformation parameter of GetSecurityInfo and SetSecurityInfo.
+const (
+	OWNER_SECURITY_INFORMATION          = 0x00000001
+	GROUP_SECURITY_INFORMATION          = 0x00000002
+	DACL_SECURITY_INFORMATION           = 0x00000004
+	PROTECTED_DACL_SECURITY_INFORMATION = 0x80000000
+)
+
+const ACL_REVISION = 2
+
+// ACCESS_ALLOWED_ACE is an access-allowed access control entry.
+// SidStart is the first 4 bytes of the variable-length SID.
+type ACCESS_ALLOWED_ACE struct {
+	AceType  byte
+	AceFlags byte
+	AceSize  uint16
+	Mask     uint32
+	SidStart uint32
+}
+
+//sys	GetSecurityInfo(handle syscall.Handle, objectType uint32, securityInformation uint32, owner **syscall.SID, group **syscall.SID, dacl **ACL, sacl **ACL, sd *syscall.Handle) (ret error) = advapi32.GetSecurityInfo
+//sys	SetSecurityInfo(handle syscall.Handle, objectType uint32, securityInformation uint32, owner *syscall.SID, group *syscall.SID, dacl *ACL, sacl *ACL) (ret error) = advapi32.SetSecurityInfo
+//sys	InitializeAcl(acl *ACL, length uint32, revision uint32) (err error) = advapi32.InitializeAcl
+//sys	AddAccessAllowedAce(acl *ACL, revision uint32, mask uint32, sid *syscall.SID) (err error) = advapi32.AddAccessAllowedAce
+//sys	EqualSid(sid1 *syscall.SID, sid2 *syscall.SID) (equal bool) = advapi32.EqualSid
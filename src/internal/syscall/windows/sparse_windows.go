@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package windows
+
+// Control codes and structures for sparse files, described in
+// https://learn.microsoft.com/en-us/windows/win32/fileio/sparse-file-operations.
+const (
+	FSCTL_SET_SPARSE             = 0x000900C4
+	FSCTL_SET_ZERO_DATA          = 0x000980C8
+	FSCTL_QUERY_ALLOCATED_RANGES = 0x000940CF
+)
+
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-file_zero_data_information
+type FILE_ZERO_DATA_INFORMATION struct {
+	FileOffset      int64
+	BeyondFinalZero int64
+}
+
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-file_allocated_range_buffer
+type FILE_ALLOCATED_RANGE_BUFFER struct {
+	FileOffset int64
+	Length     int64
+}
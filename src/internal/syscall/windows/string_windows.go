@@ -30,3 +30,10 @@ func NewNTUnicodeString(s string) (*NTUnicodeString, error) {
 		Buffer:        &s16[0],
 	}, nil
 }
+
+// RtlEqualUnicodeString reports whether s1 and s2 are equal.
+// If caseInsensitive is true, the strings are compared using the
+// case-insensitive comparison used by the object manager.
+func RtlEqualUnicodeString(s1, s2 *NTUnicodeString, caseInsensitive bool) bool {
+	return rtlEqualUnicodeString(s1, s2, caseInsensitive) != 0
+}
@@ -19,6 +19,8 @@ const (
 	FileBasicInfo                  = 0    // FILE_BASIC_INFO
 	FileStandardInfo               = 1    // FILE_STANDARD_INFO
 	FileNameInfo                   = 2    // FILE_NAME_INFO
+	FileAllocationInfo             = 5    // FILE_ALLOCATION_INFO
+	FileEndOfFileInfo              = 6    // FILE_END_OF_FILE_INFO
 	FileStreamInfo                 = 7    // FILE_STREAM_INFO
 	FileCompressionInfo            = 8    // FILE_COMPRESSION_INFO
 	FileAttributeTagInfo           = 9    // FILE_ATTRIBUTE_TAG_INFO
@@ -32,8 +34,26 @@ const (
 	FileIdInfo                     = 0x12 // FILE_ID_INFO
 	FileIdExtdDirectoryInfo        = 0x13 // FILE_ID_EXTD_DIR_INFO
 	FileIdExtdDirectoryRestartInfo = 0x14 // FILE_ID_EXTD_DIR_INFO
+	FileCaseSensitiveInfo          = 0x17 // FILE_CASE_SENSITIVE_INFO
 )
 
+type FILE_CASE_SENSITIVE_INFO struct {
+	Flags uint32
+}
+
+// Flags for FILE_CASE_SENSITIVE_INFO.
+const FILE_CS_FLAG_CASE_SENSITIVE_DIR = 0x00000001
+
+// FILE_STREAM_INFO is variable-length: StreamName holds
+// StreamNameLength bytes of UTF-16 characters.
+type FILE_STREAM_INFO struct {
+	NextEntryOffset      uint32
+	StreamNameLength     uint32
+	StreamSize           int64
+	StreamAllocationSize int64
+	StreamName           [1]uint16
+}
+
 type FILE_ATTRIBUTE_TAG_INFO struct {
 	FileAttributes uint32
 	ReparseTag     uint32
@@ -16,6 +16,12 @@ import (
 //go:linkname CanUseLongPaths
 var CanUseLongPaths bool
 
+// OpenWithOptions is syscall.Open with an explicit share mode and
+// a set of additional CreateFile FILE_FLAG_* values in fileFlags.
+//
+//go:linkname OpenWithOptions syscall.openWithOptions
+func OpenWithOptions(name string, flag int, perm uint32, shareMode uint32, fileFlags uint32) (fd syscall.Handle, err error)
+
 // UTF16PtrToString is like UTF16ToString, but takes *uint16
 // as a parameter instead of []uint16.
 func UTF16PtrToString(p *uint16) string {
@@ -32,7 +38,9 @@ func UTF16PtrToString(p *uint16) string {
 }
 
 const (
+	ERROR_INVALID_FUNCTION       syscall.Errno = 1
 	ERROR_INVALID_HANDLE         syscall.Errno = 6
+	ERROR_NOT_SAME_DEVICE        syscall.Errno = 17
 	ERROR_BAD_LENGTH             syscall.Errno = 24
 	ERROR_SHARING_VIOLATION      syscall.Errno = 32
 	ERROR_LOCK_VIOLATION         syscall.Errno = 33
@@ -43,6 +51,7 @@ const (
 	ERROR_IO_INCOMPLETE          syscall.Errno = 996
 	ERROR_NO_TOKEN               syscall.Errno = 1008
 	ERROR_NO_UNICODE_TRANSLATION syscall.Errno = 1113
+	ERROR_NOT_ALL_ASSIGNED       syscall.Errno = 1300
 	ERROR_CANT_ACCESS_FILE       syscall.Errno = 1920
 )
 
@@ -159,6 +168,25 @@ type SecurityAttributes struct {
 	InheritHandle      bool
 }
 
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-file_standard_info
+type FILE_STANDARD_INFO struct {
+	AllocationSize int64
+	EndOfFile      int64
+	NumberOfLinks  uint32
+	DeletePending  bool
+	Directory      bool
+}
+
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-file_allocation_info
+type FILE_ALLOCATION_INFO struct {
+	AllocationSize int64
+}
+
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-file_end_of_file_info
+type FILE_END_OF_FILE_INFO struct {
+	EndOfFile int64
+}
+
 type FILE_BASIC_INFO struct {
 	CreationTime   int64
 	LastAccessTime int64
@@ -190,10 +218,13 @@ const (
 
 //sys	GetAdaptersAddresses(family uint32, flags uint32, reserved unsafe.Pointer, adapterAddresses *IpAdapterAddresses, sizePointer *uint32) (errcode error) = iphlpapi.GetAdaptersAddresses
 //sys	GetComputerNameEx(nameformat uint32, buf *uint16, n *uint32) (err error) = GetComputerNameExW
+//sys	SetComputerNameEx(nameformat uint32, name *uint16) (err error) = SetComputerNameExW
 //sys	MoveFileEx(from *uint16, to *uint16, flags uint32) (err error) = MoveFileExW
+//sys	CopyFile(existingFileName *uint16, newFileName *uint16, failIfExists bool) (err error) = CopyFileW
 //sys	GetModuleFileName(module syscall.Handle, fn *uint16, len uint32) (n uint32, err error) = kernel32.GetModuleFileNameW
 //sys	SetFileInformationByHandle(handle syscall.Handle, fileInformationClass uint32, buf unsafe.Pointer, bufsize uint32) (err error) = kernel32.SetFileInformationByHandle
 //sys	VirtualQuery(address uintptr, buffer *MemoryBasicInformation, length uintptr) (err error) = kernel32.VirtualQuery
+//sys	GetSystemInfo(info *SystemInfo) = kernel32.GetSystemInfo
 //sys	GetTempPath2(buflen uint32, buf *uint16) (n uint32, err error) = GetTempPath2W
 
 const (
@@ -378,6 +409,7 @@ const MB_ERR_INVALID_CHARS = 8
 //sys	GetConsoleCP() (ccp uint32) = kernel32.GetConsoleCP
 //sys	MultiByteToWideChar(codePage uint32, dwFlags uint32, str *byte, nstr int32, wchar *uint16, nwchar int32) (nwrite int32, err error) = kernel32.MultiByteToWideChar
 //sys	GetCurrentThread() (pseudoHandle syscall.Handle, err error) = kernel32.GetCurrentThread
+//sys	GetCurrentThreadId() (id uint32) = kernel32.GetCurrentThreadId
 
 // Constants from lmshare.h
 const (
@@ -418,6 +450,8 @@ func ErrorLoadingGetTempPath2() error {
 //sys	CreateEnvironmentBlock(block **uint16, token syscall.Token, inheritExisting bool) (err error) = userenv.CreateEnvironmentBlock
 //sys	DestroyEnvironmentBlock(block *uint16) (err error) = userenv.DestroyEnvironmentBlock
 //sys	CreateEvent(eventAttrs *SecurityAttributes, manualReset uint32, initialState uint32, name *uint16) (handle syscall.Handle, err error) = kernel32.CreateEventW
+//sys	SetEvent(event syscall.Handle) (err error) = kernel32.SetEvent
+//sys	WaitForMultipleObjects(count uint32, handles *syscall.Handle, waitAll bool, waitMilliseconds uint32) (event uint32, err error) [failretval==0xffffffff] = kernel32.WaitForMultipleObjects
 
 //sys	ProcessPrng(buf []byte) (err error) = bcryptprimitives.ProcessPrng
 
@@ -456,6 +490,9 @@ type FILE_FULL_DIR_INFO struct {
 
 //sys	GetVolumeInformationByHandle(file syscall.Handle, volumeNameBuffer *uint16, volumeNameSize uint32, volumeNameSerialNumber *uint32, maximumComponentLength *uint32, fileSystemFlags *uint32, fileSystemNameBuffer *uint16, fileSystemNameSize uint32) (err error) = GetVolumeInformationByHandleW
 //sys	GetVolumeNameForVolumeMountPoint(volumeMountPoint *uint16, volumeName *uint16, bufferlength uint32) (err error) = GetVolumeNameForVolumeMountPointW
+//sys	GetVolumePathName(fileName *uint16, volumePathName *uint16, bufferLength uint32) (err error) = GetVolumePathNameW
+//sys	GetLogicalDrives() (drives uint32, err error) [failretval==0]
+//sys	GetDriveType(rootPathName *uint16) (driveType uint32) = GetDriveTypeW
 
 type RUNTIME_FUNCTION struct {
 	BeginAddress uint32
@@ -571,4 +608,6 @@ type FILE_MODE_INFORMATION struct {
 //sys   rtlNtStatusToDosErrorNoTeb(ntstatus NTStatus) (ret syscall.Errno) = ntdll.RtlNtStatusToDosErrorNoTeb
 //sys   NtSetInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, inBuffer unsafe.Pointer, inBufferLen uint32, class uint32) (ntstatus error) = ntdll.NtSetInformationFile
 //sys	RtlIsDosDeviceName_U(name *uint16) (ret uint32) = ntdll.RtlIsDosDeviceName_U
+//sys	rtlEqualUnicodeString(s1 *NTUnicodeString, s2 *NTUnicodeString, caseInsensitive bool) (ret uint8) = ntdll.RtlEqualUnicodeString
 //sys   NtQueryInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, inBuffer unsafe.Pointer, inBufferLen uint32, class uint32) (ntstatus error) = ntdll.NtQueryInformationFile
+//sys   NtQueryVolumeInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, fsInfo unsafe.Pointer, fsInfoLen uint32, class uint32) (ntstatus error) = ntdll.NtQueryVolumeInformationFile
@@ -34,6 +34,7 @@ const (
 
 	FILE_LIST_DIRECTORY = 0x00000001
 	FILE_TRAVERSE       = 0x00000020
+	FILE_DELETE_CHILD   = 0x00000040
 
 	FILE_SHARE_READ                      = 0x00000001
 	FILE_SHARE_WRITE                     = 0x00000002
@@ -58,6 +59,16 @@ const (
 	FILE_ATTRIBUTE_RECALL_ON_OPEN        = 0x00040000
 	FILE_ATTRIBUTE_RECALL_ON_DATA_ACCESS = 0x00400000
 
+	// Flags for CreateFile.
+	FILE_FLAG_OPEN_REPARSE_POINT = 0x00200000
+	FILE_FLAG_DELETE_ON_CLOSE    = 0x04000000
+	FILE_FLAG_BACKUP_SEMANTICS   = 0x02000000
+	FILE_FLAG_SEQUENTIAL_SCAN    = 0x08000000
+	FILE_FLAG_RANDOM_ACCESS      = 0x10000000
+	FILE_FLAG_NO_BUFFERING       = 0x20000000
+	FILE_FLAG_OVERLAPPED         = 0x40000000
+	FILE_FLAG_WRITE_THROUGH      = 0x80000000
+
 	INVALID_FILE_ATTRIBUTES = 0xffffffff
 )
 
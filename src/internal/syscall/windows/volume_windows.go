@@ -0,0 +1,27 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package windows
+
+// FileFsFullSizeInformation is the FS_INFORMATION_CLASS
+// of FILE_FS_FULL_SIZE_INFORMATION.
+const FileFsFullSizeInformation = 7
+
+// https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntddk/ns-ntddk-_file_fs_full_size_information
+type FILE_FS_FULL_SIZE_INFORMATION struct {
+	TotalAllocationUnits           int64
+	CallerAvailableAllocationUnits int64
+	ActualAvailableAllocationUnits int64
+	SectorsPerAllocationUnit       uint32
+	BytesPerSector                 uint32
+}
+
+// File system flag reported by GetVolumeInformation.
+const FILE_READ_ONLY_VOLUME = 0x00080000
+
+// Drive types reported by GetDriveType.
+const (
+	DRIVE_REMOVABLE = 2
+	DRIVE_FIXED     = 3
+)
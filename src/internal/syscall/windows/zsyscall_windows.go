@@ -43,17 +43,23 @@ var (
 	modkernel32         = syscall.NewLazyDLL(sysdll.Add("kernel32.dll"))
 	modnetapi32         = syscall.NewLazyDLL(sysdll.Add("netapi32.dll"))
 	modntdll            = syscall.NewLazyDLL(sysdll.Add("ntdll.dll"))
+	modole32            = syscall.NewLazyDLL(sysdll.Add("ole32.dll"))
 	modpsapi            = syscall.NewLazyDLL(sysdll.Add("psapi.dll"))
+	modshell32          = syscall.NewLazyDLL(sysdll.Add("shell32.dll"))
 	moduserenv          = syscall.NewLazyDLL(sysdll.Add("userenv.dll"))
 	modws2_32           = syscall.NewLazyDLL(sysdll.Add("ws2_32.dll"))
 
+	procAddAccessAllowedAce               = modadvapi32.NewProc("AddAccessAllowedAce")
 	procAdjustTokenPrivileges             = modadvapi32.NewProc("AdjustTokenPrivileges")
 	procDuplicateTokenEx                  = modadvapi32.NewProc("DuplicateTokenEx")
+	procEqualSid                          = modadvapi32.NewProc("EqualSid")
+	procGetSecurityInfo                   = modadvapi32.NewProc("GetSecurityInfo")
 	procGetSidIdentifierAuthority         = modadvapi32.NewProc("GetSidIdentifierAuthority")
 	procGetSidSubAuthority                = modadvapi32.NewProc("GetSidSubAuthority")
 	procGetSidSubAuthorityCount           = modadvapi32.NewProc("GetSidSubAuthorityCount")
 	procImpersonateLoggedOnUser           = modadvapi32.NewProc("ImpersonateLoggedOnUser")
 	procImpersonateSelf                   = modadvapi32.NewProc("ImpersonateSelf")
+	procInitializeAcl                     = modadvapi32.NewProc("InitializeAcl")
 	procIsValidSid                        = modadvapi32.NewProc("IsValidSid")
 	procLogonUserW                        = modadvapi32.NewProc("LogonUserW")
 	procLookupPrivilegeValueW             = modadvapi32.NewProc("LookupPrivilegeValueW")
@@ -62,9 +68,11 @@ var (
 	procOpenThreadToken                   = modadvapi32.NewProc("OpenThreadToken")
 	procQueryServiceStatus                = modadvapi32.NewProc("QueryServiceStatus")
 	procRevertToSelf                      = modadvapi32.NewProc("RevertToSelf")
+	procSetSecurityInfo                   = modadvapi32.NewProc("SetSecurityInfo")
 	procSetTokenInformation               = modadvapi32.NewProc("SetTokenInformation")
 	procProcessPrng                       = modbcryptprimitives.NewProc("ProcessPrng")
 	procGetAdaptersAddresses              = modiphlpapi.NewProc("GetAdaptersAddresses")
+	procCopyFileW                         = modkernel32.NewProc("CopyFileW")
 	procCreateEventW                      = modkernel32.NewProc("CreateEventW")
 	procCreateIoCompletionPort            = modkernel32.NewProc("CreateIoCompletionPort")
 	procCreateNamedPipeW                  = modkernel32.NewProc("CreateNamedPipeW")
@@ -72,14 +80,19 @@ var (
 	procGetComputerNameExW                = modkernel32.NewProc("GetComputerNameExW")
 	procGetConsoleCP                      = modkernel32.NewProc("GetConsoleCP")
 	procGetCurrentThread                  = modkernel32.NewProc("GetCurrentThread")
+	procGetCurrentThreadId                = modkernel32.NewProc("GetCurrentThreadId")
+	procGetDriveTypeW                     = modkernel32.NewProc("GetDriveTypeW")
 	procGetFileInformationByHandleEx      = modkernel32.NewProc("GetFileInformationByHandleEx")
 	procGetFinalPathNameByHandleW         = modkernel32.NewProc("GetFinalPathNameByHandleW")
+	procGetLogicalDrives                  = modkernel32.NewProc("GetLogicalDrives")
 	procGetModuleFileNameW                = modkernel32.NewProc("GetModuleFileNameW")
 	procGetModuleHandleW                  = modkernel32.NewProc("GetModuleHandleW")
 	procGetOverlappedResult               = modkernel32.NewProc("GetOverlappedResult")
+	procGetSystemInfo                     = modkernel32.NewProc("GetSystemInfo")
 	procGetTempPath2W                     = modkernel32.NewProc("GetTempPath2W")
 	procGetVolumeInformationByHandleW     = modkernel32.NewProc("GetVolumeInformationByHandleW")
 	procGetVolumeNameForVolumeMountPointW = modkernel32.NewProc("GetVolumeNameForVolumeMountPointW")
+	procGetVolumePathNameW                = modkernel32.NewProc("GetVolumePathNameW")
 	procLockFileEx                        = modkernel32.NewProc("LockFileEx")
 	procModule32FirstW                    = modkernel32.NewProc("Module32FirstW")
 	procModule32NextW                     = modkernel32.NewProc("Module32NextW")
@@ -87,9 +100,12 @@ var (
 	procMultiByteToWideChar               = modkernel32.NewProc("MultiByteToWideChar")
 	procRtlLookupFunctionEntry            = modkernel32.NewProc("RtlLookupFunctionEntry")
 	procRtlVirtualUnwind                  = modkernel32.NewProc("RtlVirtualUnwind")
+	procSetComputerNameExW                = modkernel32.NewProc("SetComputerNameExW")
+	procSetEvent                          = modkernel32.NewProc("SetEvent")
 	procSetFileInformationByHandle        = modkernel32.NewProc("SetFileInformationByHandle")
 	procUnlockFileEx                      = modkernel32.NewProc("UnlockFileEx")
 	procVirtualQuery                      = modkernel32.NewProc("VirtualQuery")
+	procWaitForMultipleObjects            = modkernel32.NewProc("WaitForMultipleObjects")
 	procNetShareAdd                       = modnetapi32.NewProc("NetShareAdd")
 	procNetShareDel                       = modnetapi32.NewProc("NetShareDel")
 	procNetUserAdd                        = modnetapi32.NewProc("NetUserAdd")
@@ -98,11 +114,15 @@ var (
 	procNtCreateFile                      = modntdll.NewProc("NtCreateFile")
 	procNtOpenFile                        = modntdll.NewProc("NtOpenFile")
 	procNtQueryInformationFile            = modntdll.NewProc("NtQueryInformationFile")
+	procNtQueryVolumeInformationFile      = modntdll.NewProc("NtQueryVolumeInformationFile")
 	procNtSetInformationFile              = modntdll.NewProc("NtSetInformationFile")
+	procRtlEqualUnicodeString             = modntdll.NewProc("RtlEqualUnicodeString")
 	procRtlGetVersion                     = modntdll.NewProc("RtlGetVersion")
 	procRtlIsDosDeviceName_U              = modntdll.NewProc("RtlIsDosDeviceName_U")
 	procRtlNtStatusToDosErrorNoTeb        = modntdll.NewProc("RtlNtStatusToDosErrorNoTeb")
+	procCoTaskMemFree                     = modole32.NewProc("CoTaskMemFree")
 	procGetProcessMemoryInfo              = modpsapi.NewProc("GetProcessMemoryInfo")
+	procSHGetKnownFolderPath              = modshell32.NewProc("SHGetKnownFolderPath")
 	procCreateEnvironmentBlock            = moduserenv.NewProc("CreateEnvironmentBlock")
 	procDestroyEnvironmentBlock           = moduserenv.NewProc("DestroyEnvironmentBlock")
 	procGetProfilesDirectoryW             = moduserenv.NewProc("GetProfilesDirectoryW")
@@ -111,6 +131,14 @@ var (
 	procWSASocketW                        = modws2_32.NewProc("WSASocketW")
 )
 
+func AddAccessAllowedAce(acl *ACL, revision uint32, mask uint32, sid *syscall.SID) (err error) {
+	r1, _, e1 := syscall.Syscall6(procAddAccessAllowedAce.Addr(), 4, uintptr(unsafe.Pointer(acl)), uintptr(revision), uintptr(mask), uintptr(unsafe.Pointer(sid)), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func adjustTokenPrivileges(token syscall.Token, disableAllPrivileges bool, newstate *TOKEN_PRIVILEGES, buflen uint32, prevstate *TOKEN_PRIVILEGES, returnlen *uint32) (ret uint32, err error) {
 	var _p0 uint32
 	if disableAllPrivileges {
@@ -132,6 +160,20 @@ func DuplicateTokenEx(hExistingToken syscall.Token, dwDesiredAccess uint32, lpTo
 	return
 }
 
+func EqualSid(sid1 *syscall.SID, sid2 *syscall.SID) (equal bool) {
+	r0, _, _ := syscall.Syscall(procEqualSid.Addr(), 2, uintptr(unsafe.Pointer(sid1)), uintptr(unsafe.Pointer(sid2)), 0)
+	equal = r0 != 0
+	return
+}
+
+func GetSecurityInfo(handle syscall.Handle, objectType uint32, securityInformation uint32, owner **syscall.SID, group **syscall.SID, dacl **ACL, sacl **ACL, sd *syscall.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall9(procGetSecurityInfo.Addr(), 8, uintptr(handle), uintptr(objectType), uintptr(securityInformation), uintptr(unsafe.Pointer(owner)), uintptr(unsafe.Pointer(group)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), uintptr(unsafe.Pointer(sd)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func getSidIdentifierAuthority(sid *syscall.SID) (idauth uintptr) {
 	r0, _, _ := syscall.Syscall(procGetSidIdentifierAuthority.Addr(), 1, uintptr(unsafe.Pointer(sid)), 0, 0)
 	idauth = uintptr(r0)
@@ -166,6 +208,14 @@ func ImpersonateSelf(impersonationlevel uint32) (err error) {
 	return
 }
 
+func InitializeAcl(acl *ACL, length uint32, revision uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procInitializeAcl.Addr(), 3, uintptr(unsafe.Pointer(acl)), uintptr(length), uintptr(revision))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func IsValidSid(sid *syscall.SID) (valid bool) {
 	r0, _, _ := syscall.Syscall(procIsValidSid.Addr(), 1, uintptr(unsafe.Pointer(sid)), 0, 0)
 	valid = r0 != 0
@@ -234,6 +284,14 @@ func RevertToSelf() (err error) {
 	return
 }
 
+func SetSecurityInfo(handle syscall.Handle, objectType uint32, securityInformation uint32, owner *syscall.SID, group *syscall.SID, dacl *ACL, sacl *ACL) (ret error) {
+	r0, _, _ := syscall.Syscall9(procSetSecurityInfo.Addr(), 7, uintptr(handle), uintptr(objectType), uintptr(securityInformation), uintptr(unsafe.Pointer(owner)), uintptr(unsafe.Pointer(group)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func SetTokenInformation(tokenHandle syscall.Token, tokenInformationClass uint32, tokenInformation unsafe.Pointer, tokenInformationLength uint32) (err error) {
 	r1, _, e1 := syscall.Syscall6(procSetTokenInformation.Addr(), 4, uintptr(tokenHandle), uintptr(tokenInformationClass), uintptr(tokenInformation), uintptr(tokenInformationLength), 0, 0)
 	if r1 == 0 {
@@ -262,6 +320,18 @@ func GetAdaptersAddresses(family uint32, flags uint32, reserved unsafe.Pointer,
 	return
 }
 
+func CopyFile(existingFileName *uint16, newFileName *uint16, failIfExists bool) (err error) {
+	var _p0 uint32
+	if failIfExists {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.Syscall(procCopyFileW.Addr(), 3, uintptr(unsafe.Pointer(existingFileName)), uintptr(unsafe.Pointer(newFileName)), uintptr(_p0))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func CreateEvent(eventAttrs *SecurityAttributes, manualReset uint32, initialState uint32, name *uint16) (handle syscall.Handle, err error) {
 	r0, _, e1 := syscall.Syscall6(procCreateEventW.Addr(), 4, uintptr(unsafe.Pointer(eventAttrs)), uintptr(manualReset), uintptr(initialState), uintptr(unsafe.Pointer(name)), 0, 0)
 	handle = syscall.Handle(r0)
@@ -318,6 +388,18 @@ func GetCurrentThread() (pseudoHandle syscall.Handle, err error) {
 	return
 }
 
+func GetCurrentThreadId() (id uint32) {
+	r0, _, _ := syscall.Syscall(procGetCurrentThreadId.Addr(), 0, 0, 0, 0)
+	id = uint32(r0)
+	return
+}
+
+func GetDriveType(rootPathName *uint16) (driveType uint32) {
+	r0, _, _ := syscall.Syscall(procGetDriveTypeW.Addr(), 1, uintptr(unsafe.Pointer(rootPathName)), 0, 0)
+	driveType = uint32(r0)
+	return
+}
+
 func GetFileInformationByHandleEx(handle syscall.Handle, class uint32, info *byte, bufsize uint32) (err error) {
 	r1, _, e1 := syscall.Syscall6(procGetFileInformationByHandleEx.Addr(), 4, uintptr(handle), uintptr(class), uintptr(unsafe.Pointer(info)), uintptr(bufsize), 0, 0)
 	if r1 == 0 {
@@ -335,6 +417,15 @@ func GetFinalPathNameByHandle(file syscall.Handle, filePath *uint16, filePathSiz
 	return
 }
 
+func GetLogicalDrives() (drives uint32, err error) {
+	r0, _, e1 := syscall.Syscall(procGetLogicalDrives.Addr(), 0, 0, 0, 0)
+	drives = uint32(r0)
+	if drives == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func GetModuleFileName(module syscall.Handle, fn *uint16, len uint32) (n uint32, err error) {
 	r0, _, e1 := syscall.Syscall(procGetModuleFileNameW.Addr(), 3, uintptr(module), uintptr(unsafe.Pointer(fn)), uintptr(len))
 	n = uint32(r0)
@@ -365,6 +456,11 @@ func GetOverlappedResult(handle syscall.Handle, overlapped *syscall.Overlapped,
 	return
 }
 
+func GetSystemInfo(info *SystemInfo) {
+	syscall.Syscall(procGetSystemInfo.Addr(), 1, uintptr(unsafe.Pointer(info)), 0, 0)
+	return
+}
+
 func GetTempPath2(buflen uint32, buf *uint16) (n uint32, err error) {
 	r0, _, e1 := syscall.Syscall(procGetTempPath2W.Addr(), 2, uintptr(buflen), uintptr(unsafe.Pointer(buf)), 0)
 	n = uint32(r0)
@@ -390,6 +486,14 @@ func GetVolumeNameForVolumeMountPoint(volumeMountPoint *uint16, volumeName *uint
 	return
 }
 
+func GetVolumePathName(fileName *uint16, volumePathName *uint16, bufferLength uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procGetVolumePathNameW.Addr(), 3, uintptr(unsafe.Pointer(fileName)), uintptr(unsafe.Pointer(volumePathName)), uintptr(bufferLength))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func LockFileEx(file syscall.Handle, flags uint32, reserved uint32, bytesLow uint32, bytesHigh uint32, overlapped *syscall.Overlapped) (err error) {
 	r1, _, e1 := syscall.Syscall6(procLockFileEx.Addr(), 6, uintptr(file), uintptr(flags), uintptr(reserved), uintptr(bytesLow), uintptr(bytesHigh), uintptr(unsafe.Pointer(overlapped)))
 	if r1 == 0 {
@@ -443,6 +547,22 @@ func RtlVirtualUnwind(handlerType uint32, baseAddress uintptr, pc uintptr, entry
 	return
 }
 
+func SetComputerNameEx(nameformat uint32, name *uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procSetComputerNameExW.Addr(), 2, uintptr(nameformat), uintptr(unsafe.Pointer(name)), 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func SetEvent(event syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procSetEvent.Addr(), 1, uintptr(event), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func SetFileInformationByHandle(handle syscall.Handle, fileInformationClass uint32, buf unsafe.Pointer, bufsize uint32) (err error) {
 	r1, _, e1 := syscall.Syscall6(procSetFileInformationByHandle.Addr(), 4, uintptr(handle), uintptr(fileInformationClass), uintptr(buf), uintptr(bufsize), 0, 0)
 	if r1 == 0 {
@@ -467,6 +587,19 @@ func VirtualQuery(address uintptr, buffer *MemoryBasicInformation, length uintpt
 	return
 }
 
+func WaitForMultipleObjects(count uint32, handles *syscall.Handle, waitAll bool, waitMilliseconds uint32) (event uint32, err error) {
+	var _p0 uint32
+	if waitAll {
+		_p0 = 1
+	}
+	r0, _, e1 := syscall.Syscall6(procWaitForMultipleObjects.Addr(), 4, uintptr(count), uintptr(unsafe.Pointer(handles)), uintptr(_p0), uintptr(waitMilliseconds), 0, 0)
+	event = uint32(r0)
+	if event == 0xffffffff {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 func NetShareAdd(serverName *uint16, level uint32, buf *byte, parmErr *uint16) (neterr error) {
 	r0, _, _ := syscall.Syscall6(procNetShareAdd.Addr(), 4, uintptr(unsafe.Pointer(serverName)), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(unsafe.Pointer(parmErr)), 0, 0)
 	if r0 != 0 {
@@ -531,6 +664,14 @@ func NtQueryInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, inBuff
 	return
 }
 
+func NtQueryVolumeInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, fsInfo unsafe.Pointer, fsInfoLen uint32, class uint32) (ntstatus error) {
+	r0, _, _ := syscall.Syscall6(procNtQueryVolumeInformationFile.Addr(), 5, uintptr(handle), uintptr(unsafe.Pointer(iosb)), uintptr(fsInfo), uintptr(fsInfoLen), uintptr(class), 0)
+	if r0 != 0 {
+		ntstatus = NTStatus(r0)
+	}
+	return
+}
+
 func NtSetInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, inBuffer unsafe.Pointer, inBufferLen uint32, class uint32) (ntstatus error) {
 	r0, _, _ := syscall.Syscall6(procNtSetInformationFile.Addr(), 5, uintptr(handle), uintptr(unsafe.Pointer(iosb)), uintptr(inBuffer), uintptr(inBufferLen), uintptr(class), 0)
 	if r0 != 0 {
@@ -539,6 +680,16 @@ func NtSetInformationFile(handle syscall.Handle, iosb *IO_STATUS_BLOCK, inBuffer
 	return
 }
 
+func rtlEqualUnicodeString(s1 *NTUnicodeString, s2 *NTUnicodeString, caseInsensitive bool) (ret uint8) {
+	var _p0 uint32
+	if caseInsensitive {
+		_p0 = 1
+	}
+	r0, _, _ := syscall.Syscall(procRtlEqualUnicodeString.Addr(), 3, uintptr(unsafe.Pointer(s1)), uintptr(unsafe.Pointer(s2)), uintptr(_p0))
+	ret = uint8(r0)
+	return
+}
+
 func rtlGetVersion(info *_OSVERSIONINFOEXW) {
 	syscall.Syscall(procRtlGetVersion.Addr(), 1, uintptr(unsafe.Pointer(info)), 0, 0)
 	return
@@ -556,6 +707,11 @@ func rtlNtStatusToDosErrorNoTeb(ntstatus NTStatus) (ret syscall.Errno) {
 	return
 }
 
+func CoTaskMemFree(address unsafe.Pointer) {
+	syscall.Syscall(procCoTaskMemFree.Addr(), 1, uintptr(address), 0, 0)
+	return
+}
+
 func GetProcessMemoryInfo(handle syscall.Handle, memCounters *PROCESS_MEMORY_COUNTERS, cb uint32) (err error) {
 	r1, _, e1 := syscall.Syscall(procGetProcessMemoryInfo.Addr(), 3, uintptr(handle), uintptr(unsafe.Pointer(memCounters)), uintptr(cb))
 	if r1 == 0 {
@@ -564,6 +720,14 @@ func GetProcessMemoryInfo(handle syscall.Handle, memCounters *PROCESS_MEMORY_COU
 	return
 }
 
+func SHGetKnownFolderPath(id *syscall.GUID, flags uint32, token syscall.Token, path **uint16) (ret error) {
+	r0, _, _ := syscall.Syscall6(procSHGetKnownFolderPath.Addr(), 4, uintptr(unsafe.Pointer(id)), uintptr(flags), uintptr(token), uintptr(unsafe.Pointer(path)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func CreateEnvironmentBlock(block **uint16, token syscall.Token, inheritExisting bool) (err error) {
 	var _p0 uint32
 	if inheritExisting {
@@ -0,0 +1,55 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+// An AccessMode is a set of permissions checked by [Access].
+type AccessMode uint32
+
+// The values are those of the Unix access(2) mode bits.
+const (
+	AccessExecute AccessMode = 1 << iota // X_OK: permission to execute, or search a directory
+	AccessWrite                          // W_OK: permission to write
+	AccessRead                           // R_OK: permission to read
+)
+
+// Access checks whether the calling process may access the named file
+// with the permissions in mode, without opening it. A mode of 0 checks
+// only that the file exists. Access follows symbolic links.
+//
+// On Unix systems, Access uses faccessat(2) with AT_EACCESS, and so checks
+// against the effective user and group IDs, as open would, rather than the
+// real ones. Where that check cannot be made, and on systems other than
+// Unix and Windows, Access reports whether the permission bits of the file
+// grant the permissions to any class of user. On Windows, Access checks
+// that the file exists and, for AccessWrite, that it is not read-only.
+//
+// The result describes the file at the time of the call only; a program
+// should not use Access to decide whether a later open is safe, since the
+// file may change in between. If the permissions are not granted,
+// the error satisfies [errors.Is](err, [ErrPermission]).
+// If there is an error, it will be of type [*PathError].
+func Access(name string, mode AccessMode) error {
+	if mode&^(AccessRead|AccessWrite|AccessExecute) != 0 {
+		return &PathError{Op: "access", Path: name, Err: syscall.EINVAL}
+	}
+	if err := access(name, mode); err != nil {
+		return &PathError{Op: "access", Path: name, Err: err}
+	}
+	return nil
+}
+
+// checkPermBits reports whether perm grants the permissions
+// in mode to some class of user.
+func checkPermBits(perm FileMode, mode AccessMode) error {
+	for _, bit := range []AccessMode{AccessRead, AccessWrite, AccessExecute} {
+		// Each bit is repeated for the owner, group, and others.
+		if mode&bit != 0 && perm&(FileMode(bit)*0o111) == 0 {
+			return ErrPermission
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package os
+
+func access(name string, mode AccessMode) error {
+	fi, err := Stat(name)
+	if err != nil {
+		return underlyingError(err)
+	}
+	return checkPermBits(fi.Mode(), mode)
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func access(name string, mode AccessMode) error {
+	err := ignoringEINTR(func() error {
+		return unix.Eaccess(name, uint32(mode))
+	})
+	// ENOSYS means Eaccess is not available or not implemented.
+	// EPERM can be returned by Linux containers employing seccomp.
+	// In both cases, fall back to checking the permission bits.
+	if err != syscall.ENOSYS && err != syscall.EPERM {
+		return err
+	}
+	var st syscall.Stat_t
+	if err := ignoringEINTR(func() error {
+		return syscall.Stat(name, &st)
+	}); err != nil {
+		return err
+	}
+	return checkPermBits(FileMode(st.Mode), mode)
+}
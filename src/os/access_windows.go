@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+func access(name string, mode AccessMode) error {
+	fi, err := Stat(name)
+	if err != nil {
+		return underlyingError(err)
+	}
+	if mode&AccessWrite != 0 && !fi.IsDir() && fi.Mode()&0o200 == 0 {
+		return ErrPermission
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || freebsd || netbsd
+
+package os
+
+import "time"
+
+func birthTime(fs *fileStat) (time.Time, bool) {
+	ts := fs.sys.Birthtimespec
+	// File systems that do not record the birth time report it
+	// as zero or, on FreeBSD, as -1 seconds.
+	if ts.Sec <= 0 && ts.Nsec == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(ts.Unix()), true
+}
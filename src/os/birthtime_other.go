@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !freebsd && !netbsd && !windows
+
+package os
+
+import "time"
+
+func birthTime(fs *fileStat) (time.Time, bool) {
+	return time.Time{}, false
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "time"
+
+func birthTime(fs *fileStat) (time.Time, bool) {
+	ft := fs.CreationTime
+	if ft.HighDateTime == 0 && ft.LowDateTime == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, ft.Nanoseconds()), true
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package os
+
+func chmodACL(name string, mode FileMode) error {
+	return chmod(name, mode)
+}
+
+func rootChmodACL(r *Root, name string, mode FileMode) error {
+	return rootChmod(r, name, mode)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+// Access rights granted by ChmodACL for each permission bit.
+const (
+	aclRead  = windows.FILE_GENERIC_READ
+	aclWrite = windows.FILE_GENERIC_WRITE | windows.FILE_DELETE_CHILD
+	aclExec  = windows.FILE_GENERIC_EXECUTE
+
+	// As on Unix, the owner of a file may always examine it,
+	// change its permissions, and remove it,
+	// regardless of the permission bits.
+	aclOwner = windows.READ_CONTROL | windows.WRITE_DAC | windows.DELETE |
+		windows.FILE_READ_ATTRIBUTES | windows.FILE_WRITE_ATTRIBUTES | windows.FILE_READ_EA |
+		windows.SYNCHRONIZE
+)
+
+// See docs in file.go:ChmodACL.
+func chmodACL(name string, mode FileMode) error {
+	if err := chmod(name, mode); err != nil {
+		return err
+	}
+	p, err := syscall.UTF16PtrFromString(fixLongPath(name))
+	if err != nil {
+		return &PathError{Op: "chmod", Path: name, Err: err}
+	}
+	// Act on a symbolic link itself, as chmod does.
+	h, err := syscall.CreateFile(p, windows.READ_CONTROL|windows.WRITE_DAC,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_EXISTING,
+		syscall.FILE_FLAG_BACKUP_SEMANTICS|syscall.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return &PathError{Op: "chmod", Path: name, Err: err}
+	}
+	defer syscall.CloseHandle(h)
+	if err := setModeACL(h, mode); err != nil {
+		return &PathError{Op: "chmod", Path: name, Err: err}
+	}
+	return nil
+}
+
+// rootChmodACL is Root.Chmod when RootOptions.WindowsChmodACL is set.
+func rootChmodACL(r *Root, name string, mode FileMode) error {
+	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+		// Act on a symbolic link itself, as chmodat does.
+		// Open the file without requesting read access,
+		// which the file's current permissions may not grant.
+		h, err := windows.Openat(parent, name, syscall.O_CLOEXEC|windows.O_OPEN_REPARSE|windows.O_WRITE_ATTRS|windows.O_WRITE_DAC, 0)
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer syscall.CloseHandle(h)
+		if err := chmodHandle(h, mode); err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, setModeACL(h, mode)
+	})
+	if err != nil {
+		return &PathError{Op: "chmodat", Path: name, Err: err}
+	}
+	return nil
+}
+
+// setModeACL replaces the DACL of the file h with one granting
+// the file's owner, its group, and everyone the rights in mode.
+// The new DACL is protected, so that it does not inherit
+// entries from the parent directory.
+func setModeACL(h syscall.Handle, mode FileMode) error {
+	var owner, group *syscall.SID
+	var sd syscall.Handle
+	err := windows.GetSecurityInfo(h, windows.SE_FILE_OBJECT,
+		windows.OWNER_SECURITY_INFORMATION|windows.GROUP_SECURITY_INFORMATION,
+		&owner, &group, nil, nil, &sd)
+	if err != nil {
+		return err
+	}
+	defer syscall.LocalFree(sd)
+	everyone, err := syscall.StringToSid("S-1-1-0")
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		sid  *syscall.SID
+		mask uint32
+	}
+	entries := []entry{{owner, aclOwner | permToAccess(mode>>6)}}
+	if m := permToAccess(mode >> 3); m != 0 && !windows.EqualSid(group, owner) {
+		entries = append(entries, entry{group, m})
+	}
+	if m := permToAccess(mode); m != 0 {
+		entries = append(entries, entry{everyone, m})
+	}
+
+	size := uint32(unsafe.Sizeof(windows.ACL{}))
+	for _, e := range entries {
+		size += uint32(unsafe.Offsetof(windows.ACCESS_ALLOWED_ACE{}.SidStart)) + syscall.GetLengthSid(e.sid)
+	}
+	// Allocate the ACL as []uint32 to ensure alignment.
+	buf := make([]uint32, (size+3)/4)
+	acl := (*windows.ACL)(unsafe.Pointer(&buf[0]))
+	if err := windows.InitializeAcl(acl, uint32(4*len(buf)), windows.ACL_REVISION); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := windows.AddAccessAllowedAce(acl, windows.ACL_REVISION, e.mask, e.sid); err != nil {
+			return err
+		}
+	}
+	return windows.SetSecurityInfo(h, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil, nil, acl, nil)
+}
+
+// permToAccess returns the access rights corresponding to
+// the low three (rwx) permission bits of mode.
+func permToAccess(mode FileMode) uint32 {
+	var mask uint32
+	if mode&0o4 != 0 {
+		mask |= aclRead
+	}
+	if mode&0o2 != 0 {
+		mask |= aclWrite
+	}
+	if mode&0o1 != 0 {
+		mask |= aclExec
+	}
+	return mask
+}
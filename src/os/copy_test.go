@@ -71,6 +71,49 @@ func TestLargeCopyViaNetwork(t *testing.T) {
 	}
 }
 
+func TestFileWriteToSocket(t *testing.T) {
+	const size = 1 * 1024 * 1024
+	const skip = 1000
+	src, err := os.Create(t.TempDir() + "/src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	if _, err := io.CopyN(src, newRandReader(), size); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Seek(skip, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := createSocketPair(t, "tcp")
+	var got bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := io.Copy(&got, server); err != nil {
+			t.Errorf("read from socket: %v", err)
+		}
+	}()
+	// WriteTo sends from the current offset and advances it.
+	if n, err := src.WriteTo(client); n != size-skip || err != nil {
+		t.Errorf("WriteTo = %v, %v; want %v, nil", n, err, size-skip)
+	}
+	client.Close()
+	<-done
+
+	if off, err := src.Seek(0, io.SeekCurrent); off != size || err != nil {
+		t.Errorf("offset after WriteTo = %v, %v; want %v, nil", off, err, size)
+	}
+	want := io.LimitReader(newRandReader(), size)
+	if _, err := io.CopyN(io.Discard, want, skip); err != nil {
+		t.Fatal(err)
+	}
+	if err := compareReaders(&got, want); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCopyFileToFile(t *testing.T) {
 	const size = 1 * 1024 * 1024
 	dir := t.TempDir()
@@ -0,0 +1,197 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"io"
+)
+
+// CopyFileOptions configures [CopyFile] and [Root.CopyFile].
+type CopyFileOptions struct {
+	// DataOnly copies only the contents and permission bits of the file.
+	//
+	// By default, on macOS, the copy also preserves extended attributes,
+	// access control lists, and resource forks, and the file is cloned
+	// when the filesystem supports it. On Windows, the file is copied
+	// with CopyFileW, which also copies its attributes, alternate data
+	// streams, and modification time.
+	// Other systems copy only the contents and permission bits.
+	//
+	// DataOnly does not affect the Preserve options.
+	DataOnly bool
+
+	// PreserveTimes sets the access and modification times
+	// of the new file to those of src.
+	PreserveTimes bool
+
+	// PreserveOwner sets the owner and group of the new file
+	// to those of src. Changing the owner usually requires privilege.
+	// PreserveOwner is ignored on systems other than Unix.
+	PreserveOwner bool
+
+	// PreserveXattrs copies the extended attributes of src, skipping
+	// any which the caller is not permitted to set.
+	// See [File.Getxattr] for the systems which support them.
+	PreserveXattrs bool
+}
+
+// preserves reports whether opts asks for metadata to be preserved.
+func (opts *CopyFileOptions) preserves() bool {
+	return opts.PreserveTimes || opts.PreserveOwner || opts.PreserveXattrs
+}
+
+// CopyFile copies the regular file src to a new file dst.
+// The new file is created with the permission bits of src (before umask).
+// Symbolic links in src are followed.
+//
+// CopyFile will not overwrite an existing file. If dst already exists,
+// CopyFile returns an error such that errors.Is(err, fs.ErrExist) is true.
+// If the copy fails after dst has been created, CopyFile removes dst.
+//
+// On Linux, CopyFile shares the storage of the two files when the file
+// system supports it (see [File.Clone]), and otherwise copies the data
+// with copy_file_range(2), without passing it through user space.
+//
+// A nil opts is equivalent to the zero [CopyFileOptions].
+// If there is an error, it will be of type [*PathError].
+func CopyFile(src, dst string, opts *CopyFileOptions) error {
+	return copyFile(src, dst, opts, Open, cloneFile, OpenFile, Chmod, Remove)
+}
+
+// copyFile implements CopyFile and Root.CopyFile.
+//
+// clone attempts to create dst as a clone of the open file src,
+// and reports whether it did so (or failed in a way which should be reported).
+// openFile opens dst, as OpenFile does, chmod changes its mode,
+// as Chmod does, and remove removes it, as Remove does,
+// if the copy fails after dst was created.
+func copyFile(src, dst string, opts *CopyFileOptions,
+	open func(name string) (*File, error),
+	clone func(src *File, dst string) (bool, error),
+	openFile func(name string, flag int, perm FileMode) (*File, error),
+	chmod func(name string, mode FileMode) error,
+	remove func(name string) error,
+) (err error) {
+	if opts == nil {
+		opts = &CopyFileOptions{}
+	}
+	sf, err := open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	fi, err := sf.Stat()
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return &PathError{Op: "copyfile", Path: src, Err: ErrInvalid}
+	}
+
+	// Once dst has been created, remove it if the copy fails,
+	// so that a partial copy is not left behind to make
+	// a later attempt fail with ErrExist.
+	created := false
+	defer func() {
+		if err != nil && created {
+			remove(dst)
+		}
+	}()
+
+	var df *File
+	restoreMode := false
+	if !opts.DataOnly {
+		if done, err := clone(sf, dst); done {
+			if err != nil {
+				return err
+			}
+			created = true
+			if !opts.preserves() {
+				return nil
+			}
+			// The clone has the mode of src, which may not let it be
+			// opened for writing. Give the owner write permission
+			// until its metadata has been set.
+			if fi.Mode()&0o200 == 0 {
+				if err := chmod(dst, fi.Mode().Perm()|0o200); err != nil {
+					return err
+				}
+				restoreMode = true
+			}
+			if df, err = openFile(dst, O_WRONLY, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	if df == nil {
+		df, err = openFile(dst, O_WRONLY|O_CREATE|O_EXCL, fi.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		created = true
+		// Share the storage of the files if possible.
+		// Otherwise, io.Copy uses copy_file_range(2) where it can.
+		if df.Clone(sf) != nil {
+			if _, err := io.Copy(df, sf); err != nil {
+				df.Close()
+				return &PathError{Op: "copyfile", Path: dst, Err: err}
+			}
+		}
+		if !opts.DataOnly {
+			if err := copyFileMetadata(sf, df); err != nil {
+				df.Close()
+				return &PathError{Op: "copyfile", Path: dst, Err: err}
+			}
+		}
+	}
+
+	if err := preserveFileMetadata(sf, df, fi, opts); err != nil {
+		df.Close()
+		return &PathError{Op: "copyfile", Path: dst, Err: err}
+	}
+	if restoreMode {
+		if err := df.Chmod(fi.Mode().Perm()); err != nil {
+			df.Close()
+			return err
+		}
+	}
+	return df.Close()
+}
+
+// preserveFileMetadata copies the metadata of src, with FileInfo fi,
+// to dst as directed by opts.
+// Metadata which the system does not support is not copied.
+func preserveFileMetadata(src, dst *File, fi FileInfo, opts *CopyFileOptions) error {
+	if opts.PreserveXattrs {
+		names, err := src.Listxattr()
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return underlyingError(err)
+		}
+		for _, name := range names {
+			val, err := src.Getxattr(name)
+			if err == nil {
+				err = dst.Setxattr(name, val)
+			}
+			if err != nil && !errors.Is(err, ErrPermission) && !errors.Is(err, errors.ErrUnsupported) {
+				return underlyingError(err)
+			}
+		}
+	}
+	if opts.PreserveOwner {
+		if err := preserveFileOwner(dst, fi); err != nil {
+			return underlyingError(err)
+		}
+	}
+	// Set the times last, as setting extended attributes may change them.
+	if opts.PreserveTimes {
+		err := dst.Chtimes(atime(fi), fi.ModTime())
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return underlyingError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+// cloneFile attempts to create dst as a clone of src with fclonefileat,
+// which preserves extended attributes and access control lists.
+func cloneFile(src *File, dst string) (bool, error) {
+	return cloneFileAt(src, unix.AT_FDCWD, dst, dst)
+}
+
+// cloneFSFile is cloneFile for CopyFS. A clone has the mode of src,
+// so it is used only when CopyFS is to preserve the mode.
+// The owner is given write permission until then.
+func cloneFSFile(src *File, dst string, fi FileInfo, preserveMode bool) (bool, error) {
+	if !preserveMode {
+		return false, nil
+	}
+	done, err := cloneFile(src, dst)
+	if done && err == nil && fi.Mode()&0o200 == 0 {
+		if err = Chmod(dst, fi.Mode().Perm()|0o200); err != nil {
+			Remove(dst)
+		}
+	}
+	return done, err
+}
+
+// rootCloneFile is cloneFile for Root.CopyFile.
+func rootCloneFile(r *Root, src *File, dst string) (bool, error) {
+	done, err := doInRoot(r, dst, nil, func(parent int, name string) (bool, error) {
+		return cloneFileAt(src, parent, name, dst)
+	})
+	if err != nil {
+		if _, ok := err.(*PathError); !ok {
+			err = &PathError{Op: "copyfile", Path: dst, Err: err}
+		}
+		return true, err
+	}
+	return done, nil
+}
+
+// cloneFileAt clones src to name in the directory dirfd.
+// It reports false when the filesystem does not support cloning
+// src to that location, in which case the caller should copy the file.
+func cloneFileAt(src *File, dirfd int, name, dst string) (bool, error) {
+	var err error
+	cerr := src.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.Fclonefileat(int(fd), dirfd, name, 0)
+		})
+	})
+	if err == nil {
+		err = cerr
+	}
+	switch err {
+	case nil:
+		return true, nil
+	case syscall.ENOTSUP, syscall.EXDEV:
+		return false, nil
+	}
+	return true, &PathError{Op: "copyfile", Path: dst, Err: err}
+}
+
+// copyFileMetadata copies extended attributes and access control lists,
+// including resource forks, from src to dst.
+func copyFileMetadata(src, dst *File) error {
+	var err error
+	cerr := src.pfd.RawControl(func(sfd uintptr) {
+		cerr := dst.pfd.RawControl(func(dfd uintptr) {
+			err = unix.Fcopyfile(int(sfd), int(dfd), unix.COPYFILE_ACL|unix.COPYFILE_XATTR)
+		})
+		if err == nil {
+			err = cerr
+		}
+	})
+	if err == nil {
+		err = cerr
+	}
+	if err == syscall.ENOTSUP {
+		return nil
+	}
+	return err
+}
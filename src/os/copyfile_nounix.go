@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix
+
+package os
+
+// preserveFileOwner does nothing: files have no Unix owner.
+func preserveFileOwner(f *File, fi FileInfo) error {
+	return nil
+}
+
+// preserveLinkOwner does nothing: files have no Unix owner.
+func preserveLinkOwner(name string, fi FileInfo) error {
+	return nil
+}
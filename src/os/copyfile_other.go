@@ -0,0 +1,28 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !windows
+
+package os
+
+// cloneFile reports false: files are copied rather than cloned.
+func cloneFile(src *File, dst string) (bool, error) {
+	return false, nil
+}
+
+// cloneFSFile reports false: CopyFS copies files with File.Clone or io.Copy.
+func cloneFSFile(src *File, dst string, fi FileInfo, preserveMode bool) (bool, error) {
+	return false, nil
+}
+
+// rootCloneFile reports false: files are copied rather than cloned.
+func rootCloneFile(r *Root, src *File, dst string) (bool, error) {
+	return false, nil
+}
+
+// copyFileMetadata does nothing: only file contents
+// and permission bits are copied.
+func copyFileMetadata(src, dst *File) error {
+	return nil
+}
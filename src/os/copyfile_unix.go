@@ -0,0 +1,28 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import "syscall"
+
+// preserveFileOwner sets the owner and group of f to those in fi.
+func preserveFileOwner(f *File, fi FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	return f.Chown(int(st.Uid), int(st.Gid))
+}
+
+// preserveLinkOwner sets the owner and group of the symbolic link name
+// to those in fi.
+func preserveLinkOwner(name string, fi FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	return Lchown(name, int(st.Uid), int(st.Gid))
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+)
+
+// cloneFile creates dst as a copy of src with CopyFileW, which also copies
+// file attributes, alternate data streams, and the modification time,
+// and lets the system copy the data efficiently, for example on the server
+// when both files are on the same network share.
+func cloneFile(src *File, dst string) (bool, error) {
+	from, err := syscall.UTF16PtrFromString(fixLongPath(src.name))
+	if err != nil {
+		return false, nil
+	}
+	to, err := syscall.UTF16PtrFromString(fixLongPath(dst))
+	if err != nil {
+		return true, &PathError{Op: "copyfile", Path: dst, Err: err}
+	}
+	switch err := windows.CopyFile(from, to, true); err {
+	case nil:
+		return true, nil
+	case windows.ERROR_NOT_SUPPORTED, windows.ERROR_INVALID_FUNCTION:
+		return false, nil
+	default:
+		return true, &PathError{Op: "copyfile", Path: dst, Err: err}
+	}
+}
+
+// cloneFSFile is cloneFile for CopyFS. As CopyFileW copies the attributes
+// and modification time of src, it is used only when CopyFS is to preserve
+// the mode. The read-only attribute is cleared until then.
+func cloneFSFile(src *File, dst string, fi FileInfo, preserveMode bool) (bool, error) {
+	if !preserveMode {
+		return false, nil
+	}
+	done, err := cloneFile(src, dst)
+	if done && err == nil && fi.Mode()&0o200 == 0 {
+		if err = Chmod(dst, 0o666); err != nil {
+			Remove(dst)
+		}
+	}
+	return done, err
+}
+
+// rootCloneFile reports false: CopyFileW cannot be confined to a Root,
+// so files are copied rather than cloned.
+func rootCloneFile(r *Root, src *File, dst string) (bool, error) {
+	return false, nil
+}
+
+// copyFileMetadata does nothing: only file contents
+// and permission bits are copied.
+func copyFileMetadata(src, dst *File) error {
+	return nil
+}
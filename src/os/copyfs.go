@@ -0,0 +1,434 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/filepathlite"
+	"io"
+	"io/fs"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CopyFSOptions configures [CopyFSWithOptions].
+type CopyFSOptions struct {
+	// Symlinks says how symbolic links in the source are copied.
+	Symlinks SymlinkPolicy
+
+	// PreserveMode sets the permission bits of each new file and
+	// directory to those of the source, regardless of the umask.
+	PreserveMode bool
+
+	// PreserveTimes sets the modification time of each new file,
+	// directory, and symbolic link to that of the source. When the
+	// source file system opens files as [*File], as [DirFS] does,
+	// the access time is preserved too.
+	PreserveTimes bool
+
+	// PreserveOwner sets the owner and group of each new file,
+	// directory, and symbolic link to those of the source, when the
+	// source reports them as [DirFS] does. Changing the owner usually
+	// requires privilege. PreserveOwner is ignored on systems other
+	// than Unix.
+	PreserveOwner bool
+
+	// PreserveXattrs copies the extended attributes of each file and
+	// directory, as [CopyFileOptions.PreserveXattrs] does, when the
+	// source file system opens files as [*File].
+	PreserveXattrs bool
+
+	// Concurrency is the maximum number of files to copy at once.
+	// Directories and symbolic links are always created in order,
+	// before the files within them. Zero or one copies one file at a time.
+	Concurrency int
+
+	// Progress, if not nil, is called after each regular file is copied
+	// with the file's name in the source file system and the number of
+	// bytes copied. Calls are not made concurrently.
+	Progress func(name string, n int64)
+}
+
+// preserves reports whether opts asks for metadata to be preserved.
+func (opts *CopyFSOptions) preserves() bool {
+	return opts.PreserveMode || opts.PreserveTimes || opts.PreserveOwner || opts.PreserveXattrs
+}
+
+// A SymlinkPolicy says how [CopyFSWithOptions] copies a symbolic link.
+type SymlinkPolicy int
+
+const (
+	// CopySymlinks recreates the link in the destination,
+	// with the same target. It is the default.
+	CopySymlinks SymlinkPolicy = iota
+
+	// FollowSymlinks copies the file or directory tree which
+	// the link refers to, as if it were in place of the link.
+	// A link which does not refer to an existing file is an error.
+	FollowSymlinks
+
+	// SkipSymlinks does not copy the link.
+	SkipSymlinks
+)
+
+// maxCopyFSFollows is the maximum number of symbolic links to directories
+// which CopyFSWithOptions follows within one another, to stop it copying
+// a cycle of links indefinitely.
+const maxCopyFSFollows = 40
+
+var errCopyFSLoop = errors.New("too many levels of symbolic links")
+
+// CopyFSWithOptions is like [CopyFS], but accepts additional options.
+// A nil opts is equivalent to the zero [CopyFSOptions],
+// with which CopyFSWithOptions behaves exactly as CopyFS does.
+//
+// When opts.PreserveMode is set, files from a [DirFS] are copied as
+// [CopyFile] does on macOS and Windows, which also copies their extended
+// attributes on macOS, and their attributes, alternate data streams,
+// and modification time on Windows.
+//
+// The metadata of each directory is set after the files within it
+// have been copied. When files are copied concurrently, the first
+// error stops the copy once the files already being copied are done.
+func CopyFSWithOptions(dir string, fsys fs.FS, opts *CopyFSOptions) error {
+	if opts == nil {
+		opts = &CopyFSOptions{}
+	}
+	c := &fsCopier{
+		dir:  dir,
+		fsys: fsys,
+		opts: opts,
+		fileOpts: CopyFileOptions{
+			PreserveTimes:  opts.PreserveTimes,
+			PreserveOwner:  opts.PreserveOwner,
+			PreserveXattrs: opts.PreserveXattrs,
+		},
+	}
+	_, c.cloneByName = fsys.(dirFS)
+	if opts.Concurrency > 1 {
+		c.sem = make(chan struct{}, opts.Concurrency)
+	}
+	err := c.copyTree(".", 0)
+	c.wg.Wait()
+	if err == nil {
+		err = c.err
+	}
+	if err == nil {
+		err = c.finishDirs()
+	}
+	return err
+}
+
+// An fsCopier holds the state of a CopyFSWithOptions call.
+type fsCopier struct {
+	dir      string
+	fsys     fs.FS
+	opts     *CopyFSOptions
+	fileOpts CopyFileOptions // for preserveFileMetadata
+
+	// cloneByName is set if files may be copied with cloneFSFile,
+	// which names the source file rather than using the open file.
+	// This is not done for a Root's file system, which could then
+	// copy a file from outside the Root.
+	cloneByName bool
+
+	sem chan struct{} // limits concurrent copies; nil if sequential
+	wg  sync.WaitGroup
+
+	mu  sync.Mutex // guards err and calls to opts.Progress
+	err error      // first error from a concurrent copy
+
+	dirs []copiedDir // directories whose metadata is to be set
+}
+
+// A copiedDir is a directory created by CopyFSWithOptions.
+type copiedDir struct {
+	path    string // name in the source file system
+	newPath string
+}
+
+// copyTree copies the tree rooted at root in c.fsys.
+// follows is the number of symbolic links to directories
+// which were followed to reach root.
+func (c *fsCopier) copyTree(root string, follows int) error {
+	return fs.WalkDir(c.fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		fpath, err := filepathlite.Localize(path)
+		if err != nil {
+			return err
+		}
+		newPath := joinPath(c.dir, fpath)
+
+		typ := d.Type()
+		if typ == ModeSymlink {
+			switch c.opts.Symlinks {
+			case SkipSymlinks:
+				return nil
+			case FollowSymlinks:
+				info, err := fs.Stat(c.fsys, path)
+				if err != nil {
+					return err
+				}
+				if info.IsDir() {
+					if follows >= maxCopyFSFollows {
+						return &PathError{Op: "CopyFS", Path: path, Err: errCopyFSLoop}
+					}
+					return c.copyTree(path, follows+1)
+				}
+				typ = info.Mode().Type()
+			}
+		}
+
+		switch typ {
+		case ModeDir:
+			if err := MkdirAll(newPath, 0777); err != nil {
+				return err
+			}
+			if c.opts.preserves() {
+				c.dirs = append(c.dirs, copiedDir{path, newPath})
+			}
+			return nil
+		case ModeSymlink:
+			target, err := fs.ReadLink(c.fsys, path)
+			if err != nil {
+				return err
+			}
+			if err := Symlink(target, newPath); err != nil {
+				return err
+			}
+			return c.preserveLink(d, newPath)
+		case 0:
+			if c.sem == nil {
+				return c.copyFile(path, newPath)
+			}
+			if err := c.firstErr(); err != nil {
+				return err
+			}
+			c.sem <- struct{}{}
+			c.wg.Go(func() {
+				defer func() { <-c.sem }()
+				if err := c.copyFile(path, newPath); err != nil {
+					c.setErr(err)
+				}
+			})
+			return nil
+		default:
+			return &PathError{Op: "CopyFS", Path: path, Err: ErrInvalid}
+		}
+	})
+}
+
+// copyFile copies the regular file path in c.fsys to newPath.
+func (c *fsCopier) copyFile(path, newPath string) error {
+	r, err := c.fsys.Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	info, err := r.Stat()
+	if err != nil {
+		return err
+	}
+	sf, _ := r.(*File)
+
+	w, n, err := c.copyData(r, sf, info, newPath)
+	if err != nil {
+		return err
+	}
+	if c.opts.preserves() {
+		if err := c.preserve(sf, w, info); err != nil {
+			w.Close()
+			Remove(newPath)
+			return &PathError{Op: "CopyFS", Path: newPath, Err: err}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if c.opts.Progress != nil {
+		c.mu.Lock()
+		c.opts.Progress(path, n)
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// copyData creates newPath with the contents of r, which has FileInfo info
+// and is sf if the source file system opened it as a *File. It returns
+// the new file, open for writing, and the number of bytes copied.
+//
+// When both files are on the real file system, the data is not passed
+// through user space where the system can avoid it: the new file shares
+// storage with the source if possible (see File.Clone), and otherwise is
+// created by the system (see cloneFSFile) or filled by io.Copy, which
+// uses copy_file_range(2) where it can.
+func (c *fsCopier) copyData(r fs.File, sf *File, info FileInfo, newPath string) (*File, int64, error) {
+	if sf != nil && c.cloneByName {
+		done, err := cloneFSFile(sf, newPath, info, c.opts.PreserveMode)
+		if err != nil {
+			return nil, 0, err
+		}
+		if done {
+			w, err := OpenFile(newPath, O_WRONLY, 0)
+			if err != nil {
+				Remove(newPath)
+				return nil, 0, err
+			}
+			return w, info.Size(), nil
+		}
+	}
+	w, err := OpenFile(newPath, O_CREATE|O_EXCL|O_WRONLY, 0666|info.Mode()&0777)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sf != nil && w.Clone(sf) == nil {
+		return w, info.Size(), nil
+	}
+	n, err := io.Copy(w, r)
+	if err != nil {
+		w.Close()
+		Remove(newPath)
+		return nil, 0, &PathError{Op: "Copy", Path: newPath, Err: err}
+	}
+	return w, n, nil
+}
+
+// preserve sets the metadata of dst, a copy of the file with FileInfo fi,
+// as directed by c.opts. src is the open source file, or nil if the source
+// file system did not open it as a *File.
+func (c *fsCopier) preserve(src, dst *File, fi FileInfo) error {
+	if src != nil {
+		if err := preserveFileMetadata(src, dst, fi, &c.fileOpts); err != nil {
+			return err
+		}
+	} else {
+		// Without a *File, fi may not hold an access time,
+		// and there are no extended attributes to copy.
+		if c.opts.PreserveOwner {
+			if err := preserveFileOwner(dst, fi); err != nil {
+				return underlyingError(err)
+			}
+		}
+		if c.opts.PreserveTimes {
+			err := dst.Chtimes(time.Time{}, fi.ModTime())
+			if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+				return underlyingError(err)
+			}
+		}
+	}
+	// Set the mode last, as the new mode may not permit
+	// setting extended attributes.
+	if c.opts.PreserveMode {
+		if err := dst.Chmod(fi.Mode().Perm()); err != nil {
+			return underlyingError(err)
+		}
+	}
+	return nil
+}
+
+// preserveLink sets the owner and modification time of the new
+// symbolic link newPath to those of the link with DirEntry d,
+// as directed by c.opts.
+func (c *fsCopier) preserveLink(d fs.DirEntry, newPath string) error {
+	if !c.opts.PreserveOwner && !c.opts.PreserveTimes {
+		return nil
+	}
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	if c.opts.PreserveOwner {
+		if err := preserveLinkOwner(newPath, info); err != nil {
+			return err
+		}
+	}
+	if c.opts.PreserveTimes {
+		err := Lchtimes(newPath, time.Time{}, info.ModTime())
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishDirs sets the metadata of the copied directories,
+// those deepest in the tree first, so that setting the metadata
+// of a directory does not change the times of its parent.
+func (c *fsCopier) finishDirs() error {
+	for _, d := range slices.Backward(c.dirs) {
+		if err := c.finishDir(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishDir sets the metadata of the copied directory d.
+// It sets the times and mode by name, as an open directory
+// may not permit them to be set on Windows.
+func (c *fsCopier) finishDir(d copiedDir) error {
+	r, err := c.fsys.Open(d.path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	info, err := r.Stat()
+	if err != nil {
+		return err
+	}
+	sf, _ := r.(*File)
+
+	if c.opts.PreserveOwner || c.opts.PreserveXattrs {
+		w, err := Open(d.newPath)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		if sf != nil {
+			opts := c.fileOpts
+			opts.PreserveTimes = false
+			err = preserveFileMetadata(sf, w, info, &opts)
+		} else if c.opts.PreserveOwner {
+			err = preserveFileOwner(w, info)
+		}
+		if err != nil {
+			return &PathError{Op: "CopyFS", Path: d.newPath, Err: underlyingError(err)}
+		}
+	}
+	if c.opts.PreserveTimes {
+		var atim time.Time
+		if sf != nil {
+			atim = atime(info)
+		}
+		err := Chtimes(d.newPath, atim, info.ModTime())
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+	if c.opts.PreserveMode {
+		return Chmod(d.newPath, info.Mode().Perm())
+	}
+	return nil
+}
+
+// firstErr returns the first error from a concurrent copy, if any.
+func (c *fsCopier) firstErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// setErr records err, if it is the first error from a concurrent copy.
+func (c *fsCopier) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+}
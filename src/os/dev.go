@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// Mkdev returns the device number with the given major and minor numbers,
+// in the encoding used by the system for the Rdev and Dev fields of the
+// [syscall.Stat_t] returned by [FileInfo.Sys], and by [Mknod].
+// On systems without device numbers, Mkdev places major in the high
+// 32 bits of the result and minor in the low 32 bits.
+func Mkdev(major, minor uint32) uint64 { return mkdev(major, minor) }
+
+// Major returns the major component of the device number dev,
+// encoded as by [Mkdev].
+func Major(dev uint64) uint32 { return devMajor(dev) }
+
+// Minor returns the minor component of the device number dev,
+// encoded as by [Mkdev].
+func Minor(dev uint64) uint32 { return devMinor(dev) }
@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// devno64 marks a 64-bit AIX device number.
+const devno64 = 0x8000000000000000
+
+func mkdev(major, minor uint32) uint64 {
+	return uint64(major)<<32 | uint64(minor) | devno64
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32((dev & 0x3fffffff00000000) >> 32)
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32(dev)
+}
@@ -0,0 +1,23 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func mkdev(major, minor uint32) uint64 {
+	return uint64(major)<<24 | uint64(minor)
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32((dev >> 24) & 0xff)
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32(dev & 0xffffff)
+}
+
+func sysMknod(name string, mode uint32, dev uint64) error {
+	return syscall.Mknod(name, mode, int(dev))
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func mkdev(major, minor uint32) uint64 {
+	dev := (uint64(major) & 0xffffff00) << 32
+	dev |= (uint64(major) & 0xff) << 8
+	dev |= (uint64(minor) & 0xff00) << 24
+	dev |= uint64(minor) & 0xffff00ff
+	return dev
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32(((dev >> 32) & 0xffffff00) | ((dev >> 8) & 0xff))
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32(((dev >> 24) & 0xff00) | (dev & 0xffff00ff))
+}
+
+func sysMknod(name string, mode uint32, dev uint64) error {
+	return syscall.Mknod(name, mode, dev)
+}
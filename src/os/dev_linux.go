@@ -0,0 +1,35 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+// The encoding of glibc's makedev: the low 8 bits of the minor number
+// and 12 bits of the major number form the traditional 20-bit device
+// number, and the remaining bits of each are placed above them.
+
+func mkdev(major, minor uint32) uint64 {
+	dev := uint64(major&0x00000fff) << 8
+	dev |= uint64(major&0xfffff000) << 32
+	dev |= uint64(minor&0x000000ff) << 0
+	dev |= uint64(minor&0xffffff00) << 12
+	return dev
+}
+
+func devMajor(dev uint64) uint32 {
+	major := uint32((dev & 0x00000000000fff00) >> 8)
+	major |= uint32((dev & 0xfffff00000000000) >> 32)
+	return major
+}
+
+func devMinor(dev uint64) uint32 {
+	minor := uint32((dev & 0x00000000000000ff) >> 0)
+	minor |= uint32((dev & 0x00000ffffff00000) >> 12)
+	return minor
+}
+
+func sysMknod(name string, mode uint32, dev uint64) error {
+	return syscall.Mknod(name, mode, int(dev))
+}
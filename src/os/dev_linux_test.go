@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestMkdevStatRdev(t *testing.T) {
+	fi, err := os.Stat("/dev/null")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdev := uint64(fi.Sys().(*syscall.Stat_t).Rdev)
+	if major, minor := os.Major(rdev), os.Minor(rdev); major != 1 || minor != 3 {
+		t.Errorf("Major, Minor of /dev/null = %d, %d, want 1, 3", major, minor)
+	}
+	if dev := os.Mkdev(1, 3); dev != rdev {
+		t.Errorf("Mkdev(1, 3) = %#x, want %#x", dev, rdev)
+	}
+}
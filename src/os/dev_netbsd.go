@@ -0,0 +1,26 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func mkdev(major, minor uint32) uint64 {
+	dev := (uint64(major) << 8) & 0x000fff00
+	dev |= (uint64(minor) << 12) & 0xfff00000
+	dev |= uint64(minor) & 0x000000ff
+	return dev
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32((dev & 0x000fff00) >> 8)
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32(dev&0x000000ff) | uint32((dev&0xfff00000)>>12)
+}
+
+func sysMknod(name string, mode uint32, dev uint64) error {
+	return syscall.Mknod(name, mode, int(dev))
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix
+
+package os
+
+func mkdev(major, minor uint32) uint64 {
+	return uint64(major)<<32 | uint64(minor)
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32(dev >> 32)
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32(dev)
+}
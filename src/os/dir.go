@@ -6,9 +6,9 @@ package os
 
 import (
 	"internal/bytealg"
-	"internal/filepathlite"
 	"io"
 	"io/fs"
+	"iter"
 	"slices"
 )
 
@@ -125,6 +125,97 @@ func ReadDir(name string) ([]DirEntry, error) {
 	return dirs, err
 }
 
+// SetReadDirBufferSize sets the size, in bytes, of the buffer used to
+// read entries from the directory associated with the file f by
+// [File.ReadDir], [File.Readdir], [File.Readdirnames], and
+// [File.DirEntries]. Each system call fills at most one buffer, so a
+// larger buffer reduces the number of system calls needed to list a
+// large directory, which matters most on network file systems, at
+// the cost of memory held while the directory is being read.
+//
+// A size of 0 restores the default, and sizes smaller than the
+// default are treated as the default. The new size takes effect
+// once the entries already buffered have been consumed.
+//
+// On Linux and most other Unix systems the default is 8 KiB and the
+// buffer is passed to getdents(2) or getdirentries(2). On Windows the
+// default is 64 KiB and the buffer is passed to GetFileInformationByHandleEx;
+// Windows 8.1 and earlier do not support buffers larger than 64 KiB.
+// On Darwin and Plan 9, the size is ignored.
+func (f *File) SetReadDirBufferSize(size int) error {
+	if err := f.checkValid("setreaddirbuffersize"); err != nil {
+		return err
+	}
+	if size < 0 {
+		return f.wrapErr("setreaddirbuffersize", ErrInvalid)
+	}
+	f.setReadDirBufferSize(size)
+	return nil
+}
+
+// dirEntriesBatch is the number of entries DirEntries reads at a time.
+const dirEntriesBatch = 256
+
+// DirEntries returns an iterator over the [DirEntry] records remaining
+// in the directory associated with the file f, in directory order.
+// Entries are read a batch at a time, so iterating over a large
+// directory does not hold all its entries in memory, and stopping
+// early avoids reading the rest of the directory.
+//
+// Iteration advances the directory position of f: after stopping
+// early, a later call to DirEntries or [File.ReadDir] continues
+// after the last entry read from the system, which may be past
+// the last entry yielded.
+//
+// If an error occurs, the iterator yields it and stops.
+func (f *File) DirEntries() iter.Seq2[DirEntry, error] {
+	return func(yield func(DirEntry, error) bool) {
+		if f == nil {
+			yield(nil, ErrInvalid)
+			return
+		}
+		for {
+			_, dirents, _, err := f.readdir(dirEntriesBatch, readdirDirEntry)
+			for _, d := range dirents {
+				if !yield(d, nil) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// ReadDirSeq returns an iterator over the entries of the named
+// directory, in directory order. Unlike [ReadDir], it does not sort
+// the entries, and it reads them a batch at a time rather than all
+// at once. The directory is opened when iteration starts and closed
+// when it ends.
+//
+// If an error occurs, including when opening the directory,
+// the iterator yields it and stops.
+func ReadDirSeq(name string) iter.Seq2[DirEntry, error] {
+	return func(yield func(DirEntry, error) bool) {
+		f, err := openDir(name)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer f.Close()
+		for d, err := range f.DirEntries() {
+			if !yield(d, err) {
+				return
+			}
+		}
+	}
+}
+
 // CopyFS copies the file system fsys into the directory dir,
 // creating dir if necessary.
 //
@@ -136,55 +227,20 @@ func ReadDir(name string) ([]DirEntry, error) {
 // already exists in the destination, CopyFS will return an error
 // such that errors.Is(err, fs.ErrExist) will be true.
 //
+// Symbolic links in fsys are recreated in dir with the same targets,
+// which requires fsys to implement [io/fs.ReadLinkFS].
+// [CopyFSWithOptions] can instead follow or skip them.
 // Symbolic links in dir are followed.
 //
+// When fsys is a [DirFS] or the file system of a [Root], file data is
+// copied without passing through user space where the system allows:
+// on Linux, files share storage when the file system supports it (see
+// [File.Clone]).
+//
 // New files added to fsys (including if dir is a subdirectory of fsys)
 // while CopyFS is running are not guaranteed to be copied.
 //
 // Copying stops at and returns the first error encountered.
 func CopyFS(dir string, fsys fs.FS) error {
-	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		fpath, err := filepathlite.Localize(path)
-		if err != nil {
-			return err
-		}
-		newPath := joinPath(dir, fpath)
-
-		switch d.Type() {
-		case ModeDir:
-			return MkdirAll(newPath, 0777)
-		case ModeSymlink:
-			target, err := fs.ReadLink(fsys, path)
-			if err != nil {
-				return err
-			}
-			return Symlink(target, newPath)
-		case 0:
-			r, err := fsys.Open(path)
-			if err != nil {
-				return err
-			}
-			defer r.Close()
-			info, err := r.Stat()
-			if err != nil {
-				return err
-			}
-			w, err := OpenFile(newPath, O_CREATE|O_EXCL|O_WRONLY, 0666|info.Mode()&0777)
-			if err != nil {
-				return err
-			}
-
-			if _, err := io.Copy(w, r); err != nil {
-				w.Close()
-				return &PathError{Op: "Copy", Path: newPath, Err: err}
-			}
-			return w.Close()
-		default:
-			return &PathError{Op: "CopyFS", Path: path, Err: ErrInvalid}
-		}
-	})
+	return CopyFSWithOptions(dir, fsys, nil)
 }
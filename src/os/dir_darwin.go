@@ -24,6 +24,9 @@ func (d *dirInfo) close() {
 	d.dir = 0
 }
 
+// setReadDirBufferSize does nothing: readdir_r(3) manages its own buffer.
+func (f *File) setReadDirBufferSize(size int) {}
+
 func (f *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
 	// If this file has no dirinfo, create one.
 	var d *dirInfo
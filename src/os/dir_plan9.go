@@ -10,6 +10,10 @@ import (
 	"syscall"
 )
 
+// setReadDirBufferSize does nothing: directory reads
+// return whole entries of at most blockSize bytes.
+func (file *File) setReadDirBufferSize(size int) {}
+
 func (file *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
 	var d *dirInfo
 	for {
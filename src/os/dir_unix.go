@@ -18,10 +18,11 @@ import (
 
 // Auxiliary information if the File describes a directory
 type dirInfo struct {
-	mu   sync.Mutex
-	buf  *[]byte // buffer for directory I/O
-	nbuf int     // length of buf; return value from Getdirentries
-	bufp int     // location of next record in buf.
+	mu      sync.Mutex
+	buf     *[]byte // buffer for directory I/O
+	nbuf    int     // length of buf; return value from Getdirentries
+	bufp    int     // location of next record in buf.
+	bufSize int     // size of buf to allocate, or 0 for blockSize
 }
 
 const (
@@ -39,30 +40,61 @@ var dirBufPool = sync.Pool{
 
 func (d *dirInfo) close() {
 	if d.buf != nil {
+		d.putBuf()
+	}
+}
+
+// getBuf allocates d.buf, taking it from dirBufPool
+// unless a larger buffer was requested.
+func (d *dirInfo) getBuf() {
+	if d.bufSize > blockSize {
+		buf := make([]byte, d.bufSize)
+		d.buf = &buf
+		return
+	}
+	d.buf = dirBufPool.Get().(*[]byte)
+}
+
+// putBuf releases d.buf, returning it to dirBufPool
+// if it has the default size.
+func (d *dirInfo) putBuf() {
+	if len(*d.buf) == blockSize {
 		dirBufPool.Put(d.buf)
-		d.buf = nil
 	}
+	d.buf = nil
 }
 
-func (f *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
-	// If this file has no dirInfo, create one.
-	var d *dirInfo
+// loadDirInfo returns the dirInfo of f, creating it if necessary.
+func (f *File) loadDirInfo() *dirInfo {
 	for {
-		d = f.dirinfo.Load()
+		d := f.dirinfo.Load()
 		if d != nil {
-			break
+			return d
 		}
 		newD := new(dirInfo)
 		if f.dirinfo.CompareAndSwap(nil, newD) {
-			d = newD
-			break
+			return newD
 		}
 	}
+}
+
+func (f *File) setReadDirBufferSize(size int) {
+	d := f.loadDirInfo()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bufSize = size
+	if d.buf != nil && d.bufp >= d.nbuf {
+		// The buffer is drained; the next read allocates one of the new size.
+		d.putBuf()
+	}
+}
 
+func (f *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
+	d := f.loadDirInfo()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if d.buf == nil {
-		d.buf = dirBufPool.Get().(*[]byte)
+		d.getBuf()
 	}
 
 	// Change the meaning of n for the implementation below.
@@ -90,8 +122,7 @@ func (f *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEn
 			}
 			if d.nbuf <= 0 {
 				// Optimization: we can return the buffer to the pool, there is nothing else to read.
-				dirBufPool.Put(d.buf)
-				d.buf = nil
+				d.putBuf()
 				break // EOF
 			}
 		}
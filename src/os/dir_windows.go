@@ -20,12 +20,13 @@ type dirInfo struct {
 	// buf is a slice pointer so the slice header
 	// does not escape to the heap when returning
 	// buf to dirBufPool.
-	buf   *[]byte // buffer for directory I/O
-	bufp  int     // location of next record in buf
-	h     syscall.Handle
-	vol   uint32
-	class uint32 // type of entries in buf
-	path  string // absolute directory path, empty if the file system supports FILE_ID_BOTH_DIR_INFO
+	buf     *[]byte // buffer for directory I/O
+	bufp    int     // location of next record in buf
+	bufSize int     // size of buf to allocate, or 0 for dirBufSize
+	h       syscall.Handle
+	vol     uint32
+	class   uint32 // type of entries in buf
+	path    string // absolute directory path, empty if the file system supports FILE_ID_BOTH_DIR_INFO
 }
 
 const (
@@ -49,9 +50,28 @@ var dirBufPool = sync.Pool{
 func (d *dirInfo) close() {
 	d.h = 0
 	if d.buf != nil {
+		d.putBuf()
+	}
+}
+
+// getBuf allocates d.buf, taking it from dirBufPool
+// unless a larger buffer was requested.
+func (d *dirInfo) getBuf() {
+	if d.bufSize > dirBufSize {
+		buf := make([]byte, d.bufSize)
+		d.buf = &buf
+		return
+	}
+	d.buf = dirBufPool.Get().(*[]byte)
+}
+
+// putBuf releases d.buf, returning it to dirBufPool
+// if it has the default size.
+func (d *dirInfo) putBuf() {
+	if len(*d.buf) == dirBufSize {
 		dirBufPool.Put(d.buf)
-		d.buf = nil
 	}
+	d.buf = nil
 }
 
 // allowReadDirFileID indicates whether File.readdir should try to use FILE_ID_BOTH_DIR_INFO
@@ -93,26 +113,40 @@ func (d *dirInfo) init(h syscall.Handle) {
 	}
 }
 
-func (file *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
-	// If this file has no dirInfo, create one.
-	var d *dirInfo
+// loadDirInfo returns the dirInfo of file, creating it if necessary.
+func (file *File) loadDirInfo() *dirInfo {
 	for {
-		d = file.dirinfo.Load()
+		d := file.dirinfo.Load()
 		if d != nil {
-			break
+			return d
 		}
 		d = new(dirInfo)
 		d.init(file.pfd.Sysfd)
 		if file.dirinfo.CompareAndSwap(nil, d) {
-			break
+			return d
 		}
 		// We lost the race: try again.
 		d.close()
 	}
+}
+
+func (file *File) setReadDirBufferSize(size int) {
+	d := file.loadDirInfo()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bufSize = size
+	if d.buf != nil && d.bufp == 0 {
+		// The buffer is drained; the next read allocates one of the new size.
+		d.putBuf()
+	}
+}
+
+func (file *File) readdir(n int, mode readdirMode) (names []string, dirents []DirEntry, infos []FileInfo, err error) {
+	d := file.loadDirInfo()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if d.buf == nil {
-		d.buf = dirBufPool.Get().(*[]byte)
+		d.getBuf()
 	}
 
 	wantAll := n <= 0
@@ -127,8 +161,7 @@ func (file *File) readdir(n int, mode readdirMode) (names []string, dirents []Di
 			if err != nil {
 				if err == syscall.ERROR_NO_MORE_FILES {
 					// Optimization: we can return the buffer to the pool, there is nothing else to read.
-					dirBufPool.Put(d.buf)
-					d.buf = nil
+					d.putBuf()
 					break
 				}
 				if err == syscall.ERROR_FILE_NOT_FOUND &&
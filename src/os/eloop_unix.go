@@ -2,17 +2,23 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build aix || darwin || dragonfly || freebsd || linux || openbsd || solaris || wasip1
+//go:build unix || wasip1
 
 package os
 
 import (
+	"internal/syscall/unix"
 	"runtime"
 	"syscall"
 )
 
 // isNoFollowErr reports whether err may result from O_NOFOLLOW blocking an open operation.
 func isNoFollowErr(err error) bool {
+	// unix.NoFollowErrno is the documented error for the platform
+	// (for example, EFTYPE on NetBSD), but check the other possibilities as well.
+	if err == unix.NoFollowErrno {
+		return true
+	}
 	switch err {
 	case syscall.ELOOP, syscall.EMLINK:
 		return true
@@ -7,13 +7,57 @@
 package os
 
 import (
+	"internal/stringslite"
 	"internal/testlog"
+	"iter"
+	"runtime"
 	"syscall"
 )
 
 // Expand replaces ${var} or $var in the string based on the mapping function.
 // For example, [os.ExpandEnv](s) is equivalent to [os.Expand](s, [os.Getenv]).
 func Expand(s string, mapping func(string) string) string {
+	return ExpandWithOptions(s, func(name string) (string, bool) {
+		return mapping(name), true
+	}, nil)
+}
+
+// ExpandEnv replaces ${var} or $var in the string according to the values
+// of the current environment variables. References to undefined
+// variables are replaced by the empty string.
+func ExpandEnv(s string) string {
+	return Expand(s, Getenv)
+}
+
+// ExpandOptions enables extensions to the syntax accepted by
+// [ExpandWithOptions]. The zero value accepts the same syntax as [Expand].
+type ExpandOptions struct {
+	// Defaults enables the default and alternate value forms:
+	//
+	//	${var:-word}  word if var is unset or empty, else the value of var
+	//	${var-word}   word if var is unset, else the value of var
+	//	${var:+word}  word if var is set and not empty, else the empty string
+	//	${var+word}   word if var is set, else the empty string
+	//
+	// The word is itself expanded, so it may refer to other variables.
+	// Only the word that is used is expanded.
+	Defaults bool
+
+	// EscapeDollar makes $$ expand to a literal dollar sign
+	// instead of the value of the special variable $.
+	EscapeDollar bool
+}
+
+// ExpandWithOptions is like [Expand] but accepts the syntax extensions
+// enabled by opts, as used by shells and by tools such as Docker Compose.
+// The lookup function reports the value of a variable and whether it is set,
+// which distinguishes ${var-word} from ${var:-word}; [LookupEnv] may be used
+// to expand using the current environment.
+// A nil opts is equivalent to the zero ExpandOptions.
+func ExpandWithOptions(s string, lookup func(string) (string, bool), opts *ExpandOptions) string {
+	if opts == nil {
+		opts = &ExpandOptions{}
+	}
 	var buf []byte
 	// ${} is all ASCII, so bytes are fine for this operation.
 	i := 0
@@ -23,6 +67,29 @@ func Expand(s string, mapping func(string) string) string {
 				buf = make([]byte, 0, 2*len(s))
 			}
 			buf = append(buf, s[i:j]...)
+			if opts.EscapeDollar && s[j+1] == '$' {
+				buf = append(buf, '$')
+				j++
+				i = j + 1
+				continue
+			}
+			if opts.Defaults {
+				if name, op, word, w := getShellModifier(s[j+1:], opts.EscapeDollar); w > 0 {
+					val, ok := lookup(name)
+					if op.colon {
+						ok = val != ""
+					}
+					switch {
+					case op.alternate && ok, !op.alternate && !ok:
+						buf = append(buf, ExpandWithOptions(word, lookup, opts)...)
+					case !op.alternate:
+						buf = append(buf, val...)
+					}
+					j += w
+					i = j + 1
+					continue
+				}
+			}
 			name, w := getShellName(s[j+1:])
 			if name == "" && w > 0 {
 				// Encountered invalid syntax; eat the
@@ -32,7 +99,8 @@ func Expand(s string, mapping func(string) string) string {
 				// name. Leave the dollar character untouched.
 				buf = append(buf, s[j])
 			} else {
-				buf = append(buf, mapping(name)...)
+				val, _ := lookup(name)
+				buf = append(buf, val...)
 			}
 			j += w
 			i = j + 1
@@ -44,13 +112,6 @@ func Expand(s string, mapping func(string) string) string {
 	return string(buf) + s[i:]
 }
 
-// ExpandEnv replaces ${var} or $var in the string according to the values
-// of the current environment variables. References to undefined
-// variables are replaced by the empty string.
-func ExpandEnv(s string) string {
-	return Expand(s, Getenv)
-}
-
 // isShellSpecialVar reports whether the character identifies a special
 // shell variable such as $*.
 func isShellSpecialVar(c uint8) bool {
@@ -95,6 +156,56 @@ func getShellName(s string) (string, int) {
 	return s[:i], i
 }
 
+// shellModifier is the operator of a ${var:-word} style expansion.
+type shellModifier struct {
+	colon     bool // the operator starts with ':', so empty counts as unset
+	alternate bool // the operator is '+' rather than '-'
+}
+
+// getShellModifier parses a ${var:-word} style expansion at the start of s,
+// returning the variable name, the operator, the unexpanded word, and the
+// number of bytes consumed. It returns w == 0 if s does not start with
+// such an expansion. Braces of nested ${} expansions in the word are
+// matched; if escapeDollar is set, $$ in the word does not start one.
+func getShellModifier(s string, escapeDollar bool) (name string, op shellModifier, word string, w int) {
+	if len(s) < 2 || s[0] != '{' {
+		return "", op, "", 0
+	}
+	k := 1
+	for k < len(s) && isAlphaNum(s[k]) {
+		k++
+	}
+	if k == 1 || k == len(s) {
+		return "", op, "", 0
+	}
+	name = s[1:k]
+	if s[k] == ':' {
+		op.colon = true
+		k++
+	}
+	if k == len(s) || s[k] != '-' && s[k] != '+' {
+		return "", op, "", 0
+	}
+	op.alternate = s[k] == '+'
+	k++
+	depth := 0
+	for m := k; m < len(s); m++ {
+		switch {
+		case s[m] == '$' && m+1 < len(s) && s[m+1] == '$' && escapeDollar:
+			m++
+		case s[m] == '$' && m+1 < len(s) && s[m+1] == '{':
+			depth++
+			m++
+		case s[m] == '}':
+			if depth == 0 {
+				return name, op, s[k:m], m + 1
+			}
+			depth--
+		}
+	}
+	return "", op, "", 0
+}
+
 // Getenv retrieves the value of the environment variable named by the key.
 // It returns the value, which will be empty if the variable is not present.
 // To distinguish between an empty value and an unset value, use [LookupEnv].
@@ -114,6 +225,47 @@ func LookupEnv(key string) (string, bool) {
 	return syscall.Getenv(key)
 }
 
+// LookupEnvFold is like [LookupEnv], but if no variable is named exactly
+// key, it returns the value of the first variable in the environment whose
+// name matches key ignoring case, such that "PATH", "Path", and "path" all
+// find the same variable.
+// On Windows, where names are always compared ignoring case, it is
+// equivalent to LookupEnv. Elsewhere, names are compared using ASCII
+// case folding.
+func LookupEnvFold(key string) (string, bool) {
+	if v, ok := LookupEnv(key); ok || runtime.GOOS == "windows" {
+		return v, ok
+	}
+	for _, kv := range syscall.Environ() {
+		k, v, ok := splitEnv(kv)
+		if ok && equalFoldASCII(k, key) {
+			testlog.Getenv(k)
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// equalFoldASCII reports whether s and t are equal under ASCII case folding.
+func equalFoldASCII(s, t string) bool {
+	if len(s) != len(t) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if lowerASCII(s[i]) != lowerASCII(t[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
+
 // Setenv sets the value of the environment variable named by the key.
 // It returns an error, if any.
 func Setenv(key, value string) error {
@@ -139,3 +291,33 @@ func Clearenv() {
 func Environ() []string {
 	return syscall.Environ()
 }
+
+// EnvironSeq returns an iterator over the names and values of the
+// environment variables. Unlike [Environ], it does not build a slice of
+// "key=value" strings for the caller.
+// The iterator reads a snapshot of the environment taken when iteration
+// begins; changes made to the environment during iteration are not seen.
+func EnvironSeq() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, kv := range syscall.Environ() {
+			k, v, ok := splitEnv(kv)
+			if ok && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// splitEnv splits an environment entry of the form "key=value".
+// On Windows, names of some hidden variables start with '=',
+// such as "=C:", which is part of the name.
+func splitEnv(kv string) (key, value string, ok bool) {
+	i := stringslite.IndexByte(kv, '=')
+	if i == 0 && runtime.GOOS == "windows" {
+		i = stringslite.IndexByte(kv[1:], '=') + 1
+	}
+	if i <= 0 {
+		return "", "", false
+	}
+	return kv[:i], kv[i+1:], true
+}
@@ -6,6 +6,7 @@ package os_test
 
 import (
 	. "os"
+	"runtime"
 	"slices"
 	"strings"
 	"testing"
@@ -66,6 +67,60 @@ func TestExpand(t *testing.T) {
 	}
 }
 
+// testLookupEnv is like testGetenv but reports EMPTY as set to "".
+func testLookupEnv(s string) (string, bool) {
+	if s == "EMPTY" {
+		return "", true
+	}
+	v := testGetenv(s)
+	return v, v != ""
+}
+
+var expandWithOptionsTests = []struct {
+	in, out string
+}{
+	{"${HOME:-/root}", "/usr/gopher"},
+	{"${UNSET:-/root}", "/root"},
+	{"${EMPTY:-/root}", "/root"},
+	{"${EMPTY-/root}", ""},
+	{"${UNSET-/root}", "/root"},
+	{"${HOME:+set}", "set"},
+	{"${EMPTY:+set}", ""},
+	{"${EMPTY+set}", "set"},
+	{"${UNSET+set}", ""},
+	{"${UNSET:-}", ""},
+	{"${UNSET:-$H}", "(Value of H)"},
+	{"${UNSET:-${UNSET2:-${HOME}}/x}y", "/usr/gopher/xy"},
+	{"${UNSET:-a}}", "a}"},
+	{"${HOME:-${UNSET}", ""}, // invalid syntax; eat up the characters
+	{"$$", "$"},
+	{"$$HOME", "$HOME"},
+	{"$$$HOME", "$/usr/gopher"},
+	{"${UNSET:-$${}}", "${}"},
+	{"${HOME:x}", ""},
+	{"${#}", "NARGS"},
+	{"${", ""},
+	{"$", "$"},
+}
+
+func TestExpandWithOptions(t *testing.T) {
+	opts := &ExpandOptions{Defaults: true, EscapeDollar: true}
+	for _, test := range expandWithOptionsTests {
+		result := ExpandWithOptions(test.in, testLookupEnv, opts)
+		if result != test.out {
+			t.Errorf("ExpandWithOptions(%q)=%q; expected %q", test.in, result, test.out)
+		}
+	}
+
+	// Without options, ExpandWithOptions behaves like Expand.
+	for _, test := range expandTests {
+		result := ExpandWithOptions(test.in, testLookupEnv, nil)
+		if result != test.out {
+			t.Errorf("ExpandWithOptions(%q, nil)=%q; expected %q", test.in, result, test.out)
+		}
+	}
+}
+
 var global any
 
 func BenchmarkExpand(b *testing.B) {
@@ -167,6 +222,47 @@ func TestLookupEnv(t *testing.T) {
 	}
 }
 
+func TestLookupEnvFold(t *testing.T) {
+	const key = "GO_TEST_LOOKUPENVFOLD"
+	t.Setenv(key, "upper")
+
+	for _, k := range []string{key, "go_test_lookupenvfold", "Go_Test_LookupEnvFold"} {
+		if v, ok := LookupEnvFold(k); !ok || v != "upper" {
+			t.Errorf("LookupEnvFold(%q) = %q, %t; want %q, true", k, v, ok, "upper")
+		}
+	}
+	if v, ok := LookupEnvFold(key + "_UNSET"); ok {
+		t.Errorf("LookupEnvFold(%q) = %q, %t; want not found", key+"_UNSET", v, ok)
+	}
+
+	if runtime.GOOS != "windows" {
+		// An exact match is preferred to a match ignoring case.
+		t.Setenv("go_test_lookupenvfold", "lower")
+		if v, ok := LookupEnvFold("go_test_lookupenvfold"); !ok || v != "lower" {
+			t.Errorf("LookupEnvFold(%q) = %q, %t; want %q, true", "go_test_lookupenvfold", v, ok, "lower")
+		}
+	}
+}
+
+func TestEnvironSeq(t *testing.T) {
+	t.Setenv("GO_TEST_ENVIRONSEQ", "a=b")
+
+	var env []string
+	for k, v := range EnvironSeq() {
+		env = append(env, k+"="+v)
+		if k == "GO_TEST_ENVIRONSEQ" && v != "a=b" {
+			t.Errorf("EnvironSeq yielded %s=%q; want %q", k, v, "a=b")
+		}
+	}
+	if want := Environ(); !slices.Equal(env, want) {
+		t.Errorf("EnvironSeq yielded %q; want %q", env, want)
+	}
+
+	for range EnvironSeq() {
+		break // stopping early must not panic
+	}
+}
+
 // On Windows, Environ was observed to report keys with a single leading "=".
 // Check that they are properly reported by LookupEnv and can be set by SetEnv.
 // See https://golang.org/issue/49886.
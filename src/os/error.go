@@ -129,13 +129,17 @@ func underlyingErrorIs(err, target error) bool {
 
 // underlyingError returns the underlying error for known os error types.
 func underlyingError(err error) error {
-	switch err := err.(type) {
+	switch e := err.(type) {
 	case *PathError:
-		return err.Err
+		err = e.Err
 	case *LinkError:
-		return err.Err
+		err = e.Err
 	case *SyscallError:
-		return err.Err
+		err = e.Err
+	}
+	if e, ok := err.(*RootPathError); ok {
+		// Root methods may wrap errors in a RootPathError.
+		err = e.Err
 	}
 	return err
 }
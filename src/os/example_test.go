@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"os"
@@ -41,6 +42,34 @@ func ExampleOpenFile_append() {
 	}
 }
 
+func ExampleFile_LockRange() {
+	// Update one page of a data file shared with other processes,
+	// holding an exclusive lock on just that page so that processes
+	// working on other pages are not blocked.
+	const pageSize = 4096
+	f, err := os.OpenFile("data.db", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	page := int64(3)
+	if err := f.LockRange(page*pageSize, pageSize); err != nil {
+		log.Fatal(err)
+	}
+	buf := make([]byte, pageSize)
+	if _, err := f.ReadAt(buf, page*pageSize); err != nil && !errors.Is(err, io.EOF) {
+		log.Fatal(err)
+	}
+	buf[0]++
+	if _, err := f.WriteAt(buf, page*pageSize); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.UnlockRange(page*pageSize, pageSize); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func ExampleChmod() {
 	if err := os.Chmod("some-filename", 0644); err != nil {
 		log.Fatal(err)
@@ -5,6 +5,7 @@
 package os
 
 import (
+	"context"
 	"errors"
 	"internal/testlog"
 	"runtime"
@@ -235,6 +236,19 @@ func Getpid() int { return syscall.Getpid() }
 // Getppid returns the process id of the caller's parent.
 func Getppid() int { return syscall.Getppid() }
 
+// Gettid returns the operating system's ID of the calling thread,
+// such as the one reported by gettid(2) on Linux or GetCurrentThreadId
+// on Windows. It can be used to correlate the caller with system tools
+// such as debuggers, profilers, and tracers.
+//
+// Goroutines may move between threads at any time, so the result is only
+// meaningful while the calling goroutine is locked to its thread by
+// [runtime.LockOSThread].
+//
+// On systems without thread IDs, and on Solaris, illumos, AIX, and Plan 9,
+// it returns -1.
+func Gettid() int { return gettid() }
+
 // FindProcess looks for a running process by its pid.
 //
 // The [Process] it returns can be used to obtain information
@@ -340,12 +354,58 @@ func (p *Process) Wait() (*ProcessState, error) {
 	return p.wait()
 }
 
+// WaitContext is like [Process.Wait], but returns early with the
+// context's error if ctx is done before the Process exits.
+// In that case the Process is left running and is not released,
+// so it may be waited for again.
+//
+// On Plan 9, WaitContext returns an error wrapping
+// [errors.ErrUnsupported] unless ctx can never be done.
+func (p *Process) WaitContext(ctx context.Context) (*ProcessState, error) {
+	if ctx.Done() == nil {
+		return p.wait()
+	}
+	return p.waitContext(ctx)
+}
+
 // Signal sends a signal to the [Process].
 // Sending [Interrupt] on Windows is not implemented.
 func (p *Process) Signal(sig Signal) error {
 	return p.signal(sig)
 }
 
+// PidFD returns a new Linux pidfd referring to the [Process].
+// The caller owns the returned file descriptor and must close it.
+// Unlike the PID, a pidfd always refers to the same process,
+// so it may be used to signal or wait for the process without
+// races caused by PID reuse.
+//
+// PidFD returns [ErrProcessDone] if the Process has already been
+// waited for. It returns an error wrapping [errors.ErrUnsupported]
+// if the Process is not tracked by a pidfd, which is always the case
+// on systems other than Linux.
+func (p *Process) PidFD() (uintptr, error) {
+	return p.pidfd()
+}
+
+// NewProcessFromPidFD returns a [Process] for the process referred to
+// by the Linux pidfd fd, such as one received from another process
+// over a Unix domain socket.
+//
+// On success, the returned Process takes ownership of fd,
+// which is closed when the Process is released or waited for.
+// On failure, fd is left open and remains owned by the caller.
+// NewProcessFromPidFD returns an error if the process is not visible
+// in the PID namespace of the current process.
+// As with [FindProcess], [Process.Wait] only succeeds if the
+// process is a child of the current process.
+//
+// On systems other than Linux, NewProcessFromPidFD returns an error
+// wrapping [errors.ErrUnsupported].
+func NewProcessFromPidFD(fd uintptr) (*Process, error) {
+	return newProcessFromPidfd(fd)
+}
+
 // UserTime returns the user CPU time of the exited process and its children.
 func (p *ProcessState) UserTime() time.Duration {
 	return p.userTime()
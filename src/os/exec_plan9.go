@@ -5,6 +5,8 @@
 package os
 
 import (
+	"context"
+	"errors"
 	"internal/itoa"
 	"syscall"
 	"time"
@@ -88,6 +90,21 @@ func (p *Process) wait() (ps *ProcessState, err error) {
 	return ps, nil
 }
 
+func (p *Process) pidfd() (uintptr, error) {
+	return 0, NewSyscallError("pidfd", errors.ErrUnsupported)
+}
+
+func newProcessFromPidfd(fd uintptr) (*Process, error) {
+	return nil, NewSyscallError("pidfd", errors.ErrUnsupported)
+}
+
+func (p *Process) waitContext(ctx context.Context) (*ProcessState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, NewSyscallError("wait", errors.ErrUnsupported)
+}
+
 func findProcess(pid int) (p *Process, err error) {
 	// NOOP for Plan 9.
 	return newPIDProcess(pid), nil
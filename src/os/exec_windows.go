@@ -5,6 +5,7 @@
 package os
 
 import (
+	"context"
 	"errors"
 	"internal/syscall/windows"
 	"runtime"
@@ -16,6 +17,10 @@ import (
 // a handle. A manually-created Process literal is not valid.
 
 func (p *Process) wait() (ps *ProcessState, err error) {
+	return p.waitContext(context.Background())
+}
+
+func (p *Process) waitContext(ctx context.Context) (ps *ProcessState, err error) {
 	handle, status := p.handleTransientAcquire()
 	switch status {
 	case statusDone:
@@ -25,17 +30,44 @@ func (p *Process) wait() (ps *ProcessState, err error) {
 	}
 	defer p.handleTransientRelease()
 
-	s, e := syscall.WaitForSingleObject(syscall.Handle(handle), syscall.INFINITE)
-	switch s {
-	case syscall.WAIT_OBJECT_0:
-		break
-	case syscall.WAIT_FAILED:
-		return nil, NewSyscallError("WaitForSingleObject", e)
-	default:
-		return nil, errors.New("os: unexpected result from WaitForSingleObject")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ctx.Done() == nil {
+		s, e := syscall.WaitForSingleObject(syscall.Handle(handle), syscall.INFINITE)
+		switch s {
+		case syscall.WAIT_OBJECT_0:
+		case syscall.WAIT_FAILED:
+			return nil, NewSyscallError("WaitForSingleObject", e)
+		default:
+			return nil, errors.New("os: unexpected result from WaitForSingleObject")
+		}
+	} else {
+		// Wait for either the process to exit or the cancel event,
+		// which is signaled when ctx is done.
+		cancel, e := windows.CreateEvent(nil, 1, 0, nil)
+		if e != nil {
+			return nil, NewSyscallError("CreateEvent", e)
+		}
+		defer syscall.CloseHandle(cancel)
+		stop := context.AfterFunc(ctx, func() {
+			windows.SetEvent(cancel)
+		})
+		defer stop()
+		handles := [2]syscall.Handle{syscall.Handle(handle), cancel}
+		s, e := windows.WaitForMultipleObjects(uint32(len(handles)), &handles[0], false, syscall.INFINITE)
+		switch s {
+		case syscall.WAIT_OBJECT_0:
+		case syscall.WAIT_OBJECT_0 + 1:
+			return nil, ctx.Err()
+		case syscall.WAIT_FAILED:
+			return nil, NewSyscallError("WaitForMultipleObjects", e)
+		default:
+			return nil, errors.New("os: unexpected result from WaitForMultipleObjects")
+		}
 	}
 	var ec uint32
-	e = syscall.GetExitCodeProcess(syscall.Handle(handle), &ec)
+	e := syscall.GetExitCodeProcess(syscall.Handle(handle), &ec)
 	if e != nil {
 		return nil, NewSyscallError("GetExitCodeProcess", e)
 	}
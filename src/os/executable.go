@@ -4,12 +4,14 @@
 
 package os
 
+import "errors"
+
 // Executable returns the path name for the executable that started
 // the current process. There is no guarantee that the path is still
 // pointing to the correct executable. If a symlink was used to start
 // the process, depending on the operating system, the result might
 // be the symlink or the path it pointed to. If a stable result is
-// needed, [path/filepath.EvalSymlinks] might help.
+// needed, use [ExecutableReal].
 //
 // Executable returns an absolute path unless an error occurred.
 //
@@ -18,3 +20,21 @@ package os
 func Executable() (string, error) {
 	return executable()
 }
+
+// ErrExecutableDeleted is returned by [ExecutableReal] when the file
+// that the current process was started from has been removed.
+var ErrExecutableDeleted = errors.New("os: executable file has been deleted")
+
+// ExecutableReal is like [Executable], but returns the path of the
+// executable with all symbolic links resolved, so that the result does
+// not depend on how the process was started or on the operating system.
+//
+// If the executable file has been removed since the process started,
+// for example by a program updating itself, ExecutableReal returns the
+// path the executable had together with [ErrExecutableDeleted]. On Linux,
+// this is also reported when another file has been renamed into its place,
+// and the " (deleted)" suffix that the kernel appends is removed from the
+// path. Such a path no longer refers to the running program.
+func ExecutableReal() (string, error) {
+	return executableReal()
+}
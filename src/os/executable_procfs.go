@@ -26,3 +26,25 @@ func executable() (string, error) {
 	// path appended with " (deleted)".
 	return stringslite.TrimSuffix(path, " (deleted)"), err
 }
+
+func executableReal() (string, error) {
+	path, err := Readlink("/proc/self/exe")
+	if err != nil {
+		return "", err
+	}
+	// The kernel reports the resolved path of the running executable,
+	// with " (deleted)" appended if it has been removed or replaced.
+	// The name may also really end that way, so check the file itself.
+	p, ok := stringslite.CutSuffix(path, " (deleted)")
+	if !ok {
+		return path, nil
+	}
+	exe, err := Stat("/proc/self/exe")
+	if err != nil {
+		return "", err
+	}
+	if fi, err := Stat(path); err == nil && SameFile(fi, exe) {
+		return path, nil
+	}
+	return p, ErrExecutableDeleted
+}
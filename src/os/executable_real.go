@@ -0,0 +1,72 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !windows
+
+package os
+
+import (
+	"errors"
+	"internal/bytealg"
+	"internal/stringslite"
+)
+
+func executableReal() (string, error) {
+	path, err := executable()
+	if err != nil {
+		return "", err
+	}
+	real, err := evalSymlinks(path)
+	if IsNotExist(err) {
+		return path, ErrExecutableDeleted
+	}
+	return real, err
+}
+
+// evalSymlinks returns the absolute path name with all symbolic links
+// resolved, like path/filepath.EvalSymlinks.
+func evalSymlinks(path string) (string, error) {
+	const maxLinks = 255
+	links := 0
+	resolved := "" // without a trailing slash, so "" is the root
+	rest := path
+	for rest != "" {
+		var name string
+		name, rest, _ = stringslite.Cut(rest, "/")
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			if i := bytealg.LastIndexByteString(resolved, '/'); i >= 0 {
+				resolved = resolved[:i]
+			}
+			continue
+		}
+		next := resolved + "/" + name
+		fi, err := Lstat(next)
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		links++
+		if links > maxLinks {
+			return "", &PathError{Op: "evalsymlinks", Path: path, Err: errors.New("too many links")}
+		}
+		target, err := Readlink(next)
+		if err != nil {
+			return "", err
+		}
+		if stringslite.HasPrefix(target, "/") {
+			resolved = ""
+		}
+		rest = target + "/" + rest
+	}
+	if resolved == "" {
+		return "/", nil
+	}
+	return resolved, nil
+}
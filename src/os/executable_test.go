@@ -69,6 +69,31 @@ func TestExecutable(t *testing.T) {
 	}
 }
 
+func TestExecutableReal(t *testing.T) {
+	t.Parallel()
+
+	ep, err := os.Executable()
+	if err != nil {
+		t.Skipf("Executable failed: %v", err)
+	}
+	real, err := os.ExecutableReal()
+	if err != nil {
+		t.Fatalf("ExecutableReal: %v", err)
+	}
+	if !filepath.IsAbs(real) || !sameFile(real, ep) {
+		t.Fatalf("ExecutableReal() = %q; want an absolute path to the same file as %q", real, ep)
+	}
+	if runtime.GOOS != "windows" {
+		want, err := filepath.EvalSymlinks(ep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if real != want {
+			t.Errorf("ExecutableReal() = %q; want %q", real, want)
+		}
+	}
+}
+
 func sameFile(fn1, fn2 string) bool {
 	fi1, err := os.Stat(fn1)
 	if err != nil {
@@ -117,11 +142,19 @@ func TestExecutableDeleted(t *testing.T) {
 const testExecutableDeletion = `package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"runtime"
 )
 
 func main() {
+	realBefore, err := os.ExecutableReal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ExecutableReal failed before deletion: %v\n", err)
+		os.Exit(1)
+	}
+
 	before, err := os.Executable()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to read executable name before deletion: %v\n", err)
@@ -144,5 +177,15 @@ func main() {
 		fmt.Fprintf(os.Stderr, "before and after do not match: %v != %v\n", before, after)
 		os.Exit(1)
 	}
+
+	realAfter, err := os.ExecutableReal()
+	if !errors.Is(err, os.ErrExecutableDeleted) {
+		fmt.Fprintf(os.Stderr, "ExecutableReal after deletion returned error %v, want ErrExecutableDeleted\n", err)
+		os.Exit(1)
+	}
+	if realAfter == "" || runtime.GOOS == "linux" && realAfter != realBefore {
+		fmt.Fprintf(os.Stderr, "ExecutableReal after deletion returned %q, want %q\n", realAfter, realBefore)
+		os.Exit(1)
+	}
 }
 `
@@ -30,3 +30,25 @@ func getModuleFileName(handle syscall.Handle) (string, error) {
 func executable() (string, error) {
 	return getModuleFileName(0)
 }
+
+func executableReal() (string, error) {
+	path, err := executable()
+	if err != nil {
+		return "", err
+	}
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return "", err
+	}
+	// A running executable cannot be removed on Windows, but it can be
+	// renamed, after which a different file may take its place.
+	h, err := syscall.CreateFile(p, 0, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE, nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err == syscall.ERROR_FILE_NOT_FOUND || err == syscall.ERROR_PATH_NOT_FOUND {
+		return path, ErrExecutableDeleted
+	}
+	if err != nil {
+		return "", &PathError{Op: "open", Path: path, Err: err}
+	}
+	defer syscall.CloseHandle(h)
+	return finalPathName(h)
+}
@@ -7,3 +7,8 @@ package os
 var (
 	PollCopyFileRangeP = &pollCopyFileRange
 )
+
+var (
+	RootOpenFileFast = rootOpenFileFast
+	RootStatFast     = rootStatFast
+)
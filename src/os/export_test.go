@@ -16,3 +16,5 @@ func init() {
 }
 
 var ExportReadFileContents = readFileContents
+
+var MoveByCopy = moveByCopy
@@ -7,3 +7,4 @@
 package os
 
 var SplitPath = splitPath
+var HostsCanonicalName = hostsCanonicalName
@@ -12,4 +12,6 @@ var (
 	CommandLineToArgv  = commandLineToArgv
 	AllowReadDirFileID = &allowReadDirFileID
 	SplitPath          = splitPath
+
+	JunctionRelativeTarget = junctionRelativeTarget
 )
@@ -87,6 +87,23 @@ const (
 	O_EXCL   int = syscall.O_EXCL   // used with O_CREATE, file must not exist.
 	O_SYNC   int = syscall.O_SYNC   // open for synchronous I/O.
 	O_TRUNC  int = syscall.O_TRUNC  // truncate regular writable file when opened.
+	// O_DSYNC opens the file for synchronized data I/O: writes complete
+	// once the data, and the metadata needed to read it back, are durable,
+	// without waiting for other metadata such as the modification time.
+	// Where the system has no such mode it is the same as O_SYNC.
+	O_DSYNC int = o_DSYNC
+	// O_RSYNC, combined with O_SYNC or O_DSYNC, extends their guarantee
+	// to reads. It is zero on systems that do not support it.
+	O_RSYNC int = o_RSYNC
+	// O_NOATIME does not update the access time of the file when it is
+	// read. It is only supported on Linux, where it requires the caller
+	// to own the file, and is zero on other systems.
+	O_NOATIME int = o_NOATIME
+	// O_PATH opens a location in the file system without opening the file
+	// for I/O, and without needing permission to read it. The File supports
+	// Stat, Chdir, Fd and Close, but Read and Write fail. On systems other
+	// than Linux, O_PATH is zero and the file is opened as requested.
+	O_PATH int = o_PATH
 )
 
 // Seek whence values.
@@ -98,6 +115,19 @@ const (
 	SEEK_END int = 2 // seek relative to the end
 )
 
+// Additional whence values for [File.Seek], for files which may contain
+// holes: unallocated ranges which read as zeros.
+// Not all systems and file systems support them; see [File.SparseRegions].
+const (
+	// SeekData seeks to the start of the first range containing data
+	// at or after offset. If there is none, Seek returns an error.
+	SeekData = 3
+
+	// SeekHole seeks to the start of the first hole at or after offset.
+	// The end of the file is considered to be a hole.
+	SeekHole = 4
+)
+
 // LinkError records an error during a link or symlink or rename
 // system call and the paths that caused it.
 type LinkError struct {
@@ -261,6 +291,97 @@ func (f *File) WriteAt(b []byte, off int64) (n int, err error) {
 	return
 }
 
+// ReadVAt reads from the File starting at byte offset off into the buffers
+// in bufs, in order, as [File.ReadAt] would read into their concatenation.
+// It returns the total number of bytes read and the error, if any.
+// ReadVAt always returns a non-nil error when it reads fewer bytes than
+// the total length of bufs. At end of file, that error is io.EOF.
+//
+// On Linux, ReadVAt uses preadv(2) to read into many buffers with
+// one system call. On other systems, it reads into each buffer in turn.
+func (f *File) ReadVAt(bufs [][]byte, off int64) (n int, err error) {
+	if err := f.checkValid("read"); err != nil {
+		return 0, err
+	}
+
+	if off < 0 {
+		return 0, &PathError{Op: "readat", Path: f.name, Err: errors.New("negative offset")}
+	}
+
+	bufs = slices.Clone(bufs)
+	for {
+		bufs = consumeBufs(bufs, 0)
+		if len(bufs) == 0 {
+			break
+		}
+		m, e := f.preadv(bufs, off)
+		if e != nil {
+			err = f.wrapErr("read", e)
+			break
+		}
+		n += m
+		bufs = consumeBufs(bufs, m)
+		off += int64(m)
+	}
+	return
+}
+
+// WriteVAt writes the buffers in bufs, in order, to the File starting at
+// byte offset off, as [File.WriteAt] would write their concatenation.
+// It returns the total number of bytes written and the error, if any.
+// WriteVAt returns a non-nil error when it writes fewer bytes than
+// the total length of bufs.
+//
+// If file was opened with the [O_APPEND] flag, WriteVAt returns an error.
+//
+// On Linux, WriteVAt uses pwritev(2) to write many buffers with
+// one system call. On other systems, it writes each buffer in turn.
+func (f *File) WriteVAt(bufs [][]byte, off int64) (n int, err error) {
+	if err := f.checkValid("write"); err != nil {
+		return 0, err
+	}
+	if f.appendMode {
+		return 0, errWriteAtInAppendMode
+	}
+
+	if off < 0 {
+		return 0, &PathError{Op: "writeat", Path: f.name, Err: errors.New("negative offset")}
+	}
+
+	bufs = slices.Clone(bufs)
+	for {
+		bufs = consumeBufs(bufs, 0)
+		if len(bufs) == 0 {
+			break
+		}
+		m, e := f.pwritev(bufs, off)
+		if e != nil {
+			err = f.wrapErr("write", e)
+			break
+		}
+		n += m
+		bufs = consumeBufs(bufs, m)
+		off += int64(m)
+	}
+	return
+}
+
+// consumeBufs removes n bytes from the front of bufs,
+// and any empty buffers which then begin it.
+func consumeBufs(bufs [][]byte, n int) [][]byte {
+	for len(bufs) > 0 {
+		if m := min(n, len(bufs[0])); m > 0 {
+			bufs[0] = bufs[0][m:]
+			n -= m
+		}
+		if len(bufs[0]) > 0 {
+			break
+		}
+		bufs = bufs[1:]
+	}
+	return bufs
+}
+
 // WriteTo implements io.WriterTo.
 func (f *File) WriteTo(w io.Writer) (n int64, err error) {
 	if err := f.checkValid("read"); err != nil {
@@ -356,6 +477,40 @@ func setStickyBit(name string) error {
 	return Chmod(name, fi.Mode()|ModeSticky)
 }
 
+// Mkfifo creates a new named pipe (FIFO) with the specified name and
+// permission bits (before umask).
+//
+// On Windows, named pipes live in their own namespace, \\.\pipe\, and
+// are created by the server end with CreateNamedPipe rather than in the
+// file system, so Mkfifo returns an error wrapping [errors.ErrUnsupported],
+// as it does on other systems without FIFOs.
+// If there is an error, it will be of type [*PathError].
+func Mkfifo(name string, perm FileMode) error {
+	if e := mkfifo(name, perm); e != nil {
+		return &PathError{Op: "mkfifo", Path: name, Err: e}
+	}
+	return nil
+}
+
+// Mknod creates a file system node with the specified name. The type of
+// the node is given by the type bits of mode: [ModeDevice] for a block
+// device, [ModeDevice]|[ModeCharDevice] for a character device,
+// [ModeNamedPipe] for a FIFO, [ModeSocket] for a socket, and none for a
+// regular file. Its permission bits (before umask) are given by the
+// permission bits of mode. For a device, dev is the device number, as
+// built by [Mkdev]; it is otherwise ignored.
+// Creating a device usually requires privilege.
+//
+// On Windows, Plan 9, AIX, and other systems without mknod(2), Mknod
+// returns an error wrapping [errors.ErrUnsupported].
+// If there is an error, it will be of type [*PathError].
+func Mknod(name string, mode FileMode, dev uint64) error {
+	if e := mknod(name, mode, dev); e != nil {
+		return &PathError{Op: "mknod", Path: name, Err: e}
+	}
+	return nil
+}
+
 // Chdir changes the current working directory to the named directory.
 // If there is an error, it will be of type [*PathError].
 func Chdir(dir string) error {
@@ -363,6 +518,13 @@ func Chdir(dir string) error {
 		testlog.Open(dir) // observe likely non-existent directory
 		return &PathError{Op: "chdir", Path: dir, Err: e}
 	}
+	chdirDone(dir)
+	return nil
+}
+
+// chdirDone records that the working directory has been changed to dir,
+// which is "" if the caller does not know its name.
+func chdirDone(dir string) {
 	if runtime.GOOS == "windows" {
 		abs := filepathlite.IsAbs(dir)
 		getwdCache.Lock()
@@ -379,7 +541,6 @@ func Chdir(dir string) error {
 			log.Chdir(wd)
 		}
 	}
-	return nil
 }
 
 // Open opens the named file for reading. If successful, methods on
@@ -408,8 +569,57 @@ func Create(name string) (*File, error) {
 // methods on the returned File can be used for I/O.
 // If there is an error, it will be of type [*PathError].
 func OpenFile(name string, flag int, perm FileMode) (*File, error) {
+	return OpenFileWithOptions(name, flag, perm, nil)
+}
+
+// OpenFileOptions contains optional parameters, some of them
+// platform-specific, for [OpenFileWithOptions] and [Root.OpenFileWithOptions].
+// The zero value requests the same behavior as [OpenFile].
+type OpenFileOptions struct {
+	// WindowsShareMode is the share mode passed to CreateFile,
+	// a combination of the FILE_SHARE_READ, FILE_SHARE_WRITE and
+	// FILE_SHARE_DELETE flags. If zero, the default share mode is used:
+	// FILE_SHARE_READ|FILE_SHARE_WRITE for [OpenFile], and additionally
+	// FILE_SHARE_DELETE for [Root.OpenFile].
+	// It is ignored on other systems.
+	WindowsShareMode uint32
+
+	// WindowsFileFlags is a set of additional FILE_FLAG_* values
+	// passed to CreateFile, such as FILE_FLAG_SEQUENTIAL_SCAN or
+	// FILE_FLAG_DELETE_ON_CLOSE.
+	// If FILE_FLAG_OVERLAPPED is set, the returned File is
+	// opened for asynchronous I/O.
+	// It is ignored on other systems.
+	WindowsFileFlags uint32
+
+	// TmpFile opens an unnamed regular file in the named directory,
+	// which can be given a name once it has been written with
+	// [File.LinkInto]. The flag must include O_WRONLY or O_RDWR.
+	//
+	// On Linux, the file is opened with the O_TMPFILE flag to open(2);
+	// kernels before Linux 3.11 do not support it, and report EISDIR.
+	// On other systems, and on file systems which do not support
+	// O_TMPFILE, the file is created in the directory with a temporary
+	// name, which is removed when LinkInto succeeds or when the file
+	// is closed.
+	//
+	// TmpFile is not supported by [Root.OpenFileWithOptions].
+	TmpFile bool
+}
+
+// OpenFileWithOptions is like [OpenFile], but accepts additional
+// platform-specific options. A nil opts is equivalent to the zero
+// [OpenFileOptions].
+// If there is an error, it will be of type [*PathError].
+func OpenFileWithOptions(name string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
 	testlog.Open(name)
-	f, err := openFileNolog(name, flag, perm)
+	var f *File
+	var err error
+	if opts != nil && opts.TmpFile {
+		f, err = openTmpFile(name, flag, perm, opts)
+	} else {
+		f, err = openFileNolog(name, flag, perm, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -418,8 +628,6 @@ func OpenFile(name string, flag int, perm FileMode) (*File, error) {
 	return f, nil
 }
 
-var errPathEscapes = errors.New("path escapes from parent")
-
 // openDir opens a file which is assumed to be a directory. As such, it skips
 // the syscalls that make the file descriptor non-blocking as these take time
 // and will fail on file descriptors for directories.
@@ -441,6 +649,43 @@ func Rename(oldpath, newpath string) error {
 	return rename(oldpath, newpath)
 }
 
+// RenameNoReplace renames (moves) oldpath to newpath, like [Rename],
+// but fails with an error satisfying [errors.Is](err, [ErrExist]) if
+// newpath already exists. The check and the rename are a single atomic
+// step, so RenameNoReplace can be used to claim a name.
+//
+// On Linux, RenameNoReplace uses renameat2(2) with RENAME_NOREPLACE, and
+// on Darwin, renameatx_np with RENAME_EXCL. Where those are not supported,
+// and on other Unix systems, it makes a hard link to oldpath at newpath
+// and then removes oldpath, which does not work for directories and
+// briefly leaves the file at both names. On Windows, it uses MoveFileEx
+// without MOVEFILE_REPLACE_EXISTING. On other systems, RenameNoReplace
+// returns an error wrapping [errors.ErrUnsupported].
+// If there is an error, it will be of type [*LinkError].
+func RenameNoReplace(oldpath, newpath string) error {
+	if e := renameNoReplace(oldpath, newpath); e != nil {
+		return &LinkError{"rename", oldpath, newpath, e}
+	}
+	return nil
+}
+
+// RenameExchange atomically exchanges oldpath and newpath, both of which
+// must exist. They may be of different types; for example, one may be a
+// directory and the other a file.
+//
+// On Linux, RenameExchange uses renameat2(2) with RENAME_EXCHANGE, and
+// on Darwin, renameatx_np with RENAME_SWAP. The exchange cannot be
+// emulated atomically, so where those are not supported, and on other
+// systems, RenameExchange returns an error wrapping
+// [errors.ErrUnsupported].
+// If there is an error, it will be of type [*LinkError].
+func RenameExchange(oldpath, newpath string) error {
+	if e := renameExchange(oldpath, newpath); e != nil {
+		return &LinkError{"rename", oldpath, newpath, e}
+	}
+	return nil
+}
+
 // Readlink returns the destination of the named symbolic link.
 // If there is an error, it will be of type [*PathError].
 //
@@ -597,6 +842,108 @@ func UserConfigDir() (string, error) {
 	return dir, nil
 }
 
+// UserStateDir returns the default root directory to use for user-specific
+// state data, such as logs, history, and undo data, that should persist
+// between restarts but is not important enough to store in [UserConfigDir].
+// Users should create their own application-specific subdirectory within
+// this one and use that.
+//
+// On Unix systems, it returns $XDG_STATE_HOME as specified by
+// https://specifications.freedesktop.org/basedir-spec/basedir-spec-latest.html if
+// non-empty, else $HOME/.local/state.
+// On Darwin, it returns $HOME/Library/Application Support.
+// On Windows, it returns %LocalAppData%.
+// On Plan 9, it returns $home/lib/state.
+//
+// If the location cannot be determined (for example, $HOME is not defined) or
+// the path in $XDG_STATE_HOME is relative, then it will return an error.
+func UserStateDir() (string, error) {
+	var dir string
+
+	switch runtime.GOOS {
+	case "windows":
+		dir = Getenv("LocalAppData")
+		if dir == "" {
+			return "", errors.New("%LocalAppData% is not defined")
+		}
+
+	case "darwin", "ios":
+		dir = Getenv("HOME")
+		if dir == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		dir += "/Library/Application Support"
+
+	case "plan9":
+		dir = Getenv("home")
+		if dir == "" {
+			return "", errors.New("$home is not defined")
+		}
+		dir += "/lib/state"
+
+	default: // Unix
+		dir = Getenv("XDG_STATE_HOME")
+		if dir == "" {
+			dir = Getenv("HOME")
+			if dir == "" {
+				return "", errors.New("neither $XDG_STATE_HOME nor $HOME are defined")
+			}
+			dir += "/.local/state"
+		} else if !filepathlite.IsAbs(dir) {
+			return "", errors.New("path in $XDG_STATE_HOME is relative")
+		}
+	}
+
+	return dir, nil
+}
+
+// UserRuntimeDir returns the default root directory to use for user-specific
+// runtime files such as sockets, named pipes, and lock files. Users should
+// create their own application-specific subdirectory within this one and
+// use that.
+//
+// On Unix systems, it returns $XDG_RUNTIME_DIR as specified by
+// https://specifications.freedesktop.org/basedir-spec/basedir-spec-latest.html.
+// On Darwin, it returns $TMPDIR, which the system sets to a private
+// per-user temporary directory.
+// On Windows, it returns the per-user temporary directory reported by [TempDir].
+// On Plan 9, it returns /tmp, which is private to each user's namespace.
+//
+// Unlike [UserCacheDir] and [UserConfigDir], there is no fallback to a
+// directory under $HOME, which would not be cleaned up when the user logs out.
+// If the variable is not defined or the path in $XDG_RUNTIME_DIR is relative,
+// then it will return an error, and callers should choose their own fallback.
+func UserRuntimeDir() (string, error) {
+	var dir string
+
+	switch runtime.GOOS {
+	case "windows":
+		dir = TempDir()
+
+	case "darwin", "ios":
+		dir = Getenv("TMPDIR")
+		if dir == "" {
+			return "", errors.New("$TMPDIR is not defined")
+		}
+		if len(dir) > 1 && dir[len(dir)-1] == '/' {
+			dir = dir[:len(dir)-1]
+		}
+
+	case "plan9":
+		dir = "/tmp"
+
+	default: // Unix
+		dir = Getenv("XDG_RUNTIME_DIR")
+		if dir == "" {
+			return "", errors.New("$XDG_RUNTIME_DIR is not defined")
+		} else if !filepathlite.IsAbs(dir) {
+			return "", errors.New("path in $XDG_RUNTIME_DIR is relative")
+		}
+	}
+
+	return dir, nil
+}
+
 // UserHomeDir returns the current user's home directory.
 //
 // On Unix, including macOS, it returns the $HOME environment variable.
@@ -646,6 +993,37 @@ func UserHomeDir() (string, error) {
 // and [ModeTemporary] are used.
 func Chmod(name string, mode FileMode) error { return chmod(name, mode) }
 
+// Lchmod changes the mode of the named file to mode, like [Chmod].
+// If the file is a symbolic link, it changes the mode of the link itself
+// rather than of its target.
+//
+// On Darwin and BSD systems, Lchmod uses fchmodat(2) with
+// AT_SYMLINK_NOFOLLOW. Other systems, including Linux, cannot change the
+// mode of a symbolic link, and Lchmod returns an error wrapping
+// [errors.ErrUnsupported].
+// If there is an error, it will be of type [*PathError].
+func Lchmod(name string, mode FileMode) error {
+	if e := lchmod(name, mode); e != nil {
+		return &PathError{Op: "lchmod", Path: name, Err: e}
+	}
+	return nil
+}
+
+// ChmodACL is like [Chmod], but on Windows it also replaces the
+// file's access control list with one granting the file's owner,
+// its primary group, and everyone the rights named by the
+// corresponding permission bits of mode.
+// The owner is always permitted to read the file's attributes,
+// change its permissions, and delete it.
+// Because Windows access rights are additive, a user who is both
+// in the file's group and the owner receives the union of both sets
+// of rights. The new access control list does not inherit entries
+// from the parent directory.
+// If the file is a symbolic link, ChmodACL acts on the link itself.
+//
+// On other systems, ChmodACL is identical to Chmod.
+func ChmodACL(name string, mode FileMode) error { return chmodACL(name, mode) }
+
 // Chmod changes the mode of the file to mode.
 // If there is an error, it will be of type [*PathError].
 func (f *File) Chmod(mode FileMode) error { return f.chmod(mode) }
@@ -705,6 +1083,35 @@ func (f *File) SyscallConn() (syscall.RawConn, error) {
 	return newRawConn(f)
 }
 
+// Dup returns a new File referring to the same open file as f, with its
+// own file descriptor or handle, so that closing either File does not
+// affect the other.
+//
+// The two Files share the file offset and status flags, as descriptors
+// duplicated with dup(2) do: reading from one advances the offset of both.
+// Programs which need independent offsets should open the file again,
+// or use [File.ReadAt] and [File.WriteAt].
+//
+// The new descriptor is close-on-exec. Like a File returned by [NewFile],
+// it is added to the runtime poller if the file is in non-blocking mode.
+//
+// On Unix systems, Dup uses fcntl(2) with F_DUPFD_CLOEXEC.
+// On Windows, it uses DuplicateHandle.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Dup() (*File, error) {
+	if err := f.checkValid("dup"); err != nil {
+		return nil, err
+	}
+	nf, err := f.dup()
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, f.wrapErr("dup", err)
+	}
+	nf.appendMode = f.appendMode
+	return nf, nil
+}
+
 // Fd returns the system file descriptor or handle referencing the open file.
 // If f is closed, the descriptor becomes invalid.
 // If f is garbage collected, a finalizer may close the descriptor,
@@ -737,7 +1144,8 @@ func (f *File) Fd() uintptr {
 // a general substitute for a chroot-style security mechanism when the directory tree
 // contains arbitrary content.
 //
-// Use [Root.FS] to obtain a fs.FS that prevents escapes from the tree via symbolic links.
+// Use [Root.FS] or [DirFSRooted] to obtain a fs.FS that prevents escapes
+// from the tree via symbolic links.
 //
 // The directory dir must not be "".
 //
@@ -874,6 +1282,23 @@ func ReadFile(name string) ([]byte, error) {
 	return readFileContents(statOrZero(f), f.Read)
 }
 
+// ReadFileInto reads the named file, like [ReadFile], and returns the
+// contents. If buf has the capacity to hold the whole file, ReadFileInto
+// reads into buf, overwriting its contents, and returns a slice of it;
+// otherwise it allocates a new slice, which may be passed to a later call.
+//
+// ReadFileInto lets a program which reads a file repeatedly,
+// such as one which polls a configuration file, reuse one buffer.
+func ReadFileInto(name string, buf []byte) ([]byte, error) {
+	f, err := Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readFileContentsInto(buf, statOrZero(f), f.Read)
+}
+
 func statOrZero(f *File) int64 {
 	if fi, err := f.Stat(); err == nil {
 		return fi.Size()
@@ -887,6 +1312,12 @@ func statOrZero(f *File) int64 {
 // The provided size is the stat size of the file, which might be 0 for a
 // /proc-like file that doesn't report a size.
 func readFileContents(statSize int64, read func([]byte) (int, error)) ([]byte, error) {
+	return readFileContentsInto(nil, statSize, read)
+}
+
+// readFileContentsInto is like readFileContents, but reads into buf,
+// overwriting its contents, if it has room for the whole file.
+func readFileContentsInto(buf []byte, statSize int64, read func([]byte) (int, error)) ([]byte, error) {
 	zeroSize := statSize == 0
 
 	// Figure out how big to make the initial slice. For files with known size
@@ -906,7 +1337,10 @@ func readFileContents(statSize int64, read func([]byte) (int, error)) ([]byte, e
 		size = minBuf
 	}
 
-	data := make([]byte, 0, size)
+	data := buf[:0]
+	if cap(data) < size {
+		data = make([]byte, 0, size)
+	}
 	for {
 		n, err := read(data[len(data):cap(data)])
 		data = data[:len(data)+n]
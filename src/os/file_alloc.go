@@ -0,0 +1,113 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+)
+
+// Preallocate reserves storage for length bytes of the file starting at
+// offset off, so that later writes within that range do not fail for lack
+// of space. If off+length is beyond the end of the file, the file is
+// extended to that size, and the added bytes read as zeros.
+// Preallocate does not change the I/O offset.
+//
+// On Linux, Preallocate uses fallocate(2), and on FreeBSD,
+// posix_fallocate(2). On Darwin, it uses fcntl F_PREALLOCATE,
+// which reserves storage at the end of the file and so does not
+// allocate holes before it. On Windows, it sets the allocation size
+// and end of the file.
+//
+// If preallocation is not supported by the system or the file system,
+// Preallocate returns an error wrapping [errors.ErrUnsupported].
+// If there is an error, it will be of type [*PathError].
+func (f *File) Preallocate(off, length int64) error {
+	if err := f.checkValid("preallocate"); err != nil {
+		return err
+	}
+	if off < 0 || length <= 0 || off+length < 0 {
+		return &PathError{Op: "preallocate", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := preallocate(f, off, length)
+	runtime.KeepAlive(f)
+	return f.wrapErr("preallocate", err)
+}
+
+// PunchHole deallocates the storage for length bytes of the file starting
+// at offset off, so that the range reads as zeros and no longer occupies
+// space on disk. PunchHole changes neither the size of the file nor
+// the I/O offset.
+//
+// On Linux, PunchHole uses fallocate(2) with FALLOC_FL_PUNCH_HOLE.
+// On Darwin, it uses fcntl F_PUNCHHOLE, and the file system may require
+// the range to be aligned to its block size. On Windows, it marks the file
+// as sparse and uses FSCTL_SET_ZERO_DATA.
+//
+// If punching holes is not supported by the system or the file system,
+// PunchHole returns an error wrapping [errors.ErrUnsupported].
+// If there is an error, it will be of type [*PathError].
+func (f *File) PunchHole(off, length int64) error {
+	if err := f.checkValid("punchhole"); err != nil {
+		return err
+	}
+	if off < 0 || length <= 0 || off+length < 0 {
+		return &PathError{Op: "punchhole", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := punchHole(f, off, length)
+	runtime.KeepAlive(f)
+	return f.wrapErr("punchhole", err)
+}
+
+// ZeroRange sets length bytes of the file starting at offset off to zero.
+// If off+length is beyond the end of the file, the file is extended to
+// that size. ZeroRange does not change the I/O offset.
+//
+// On Linux, ZeroRange uses fallocate(2) with FALLOC_FL_ZERO_RANGE.
+// On Windows, it uses FSCTL_SET_ZERO_DATA. Where the system or the file
+// system cannot zero the range itself, ZeroRange writes zeros to it a
+// block at a time, so zeroing a large range does not require a large
+// buffer. Unlike [File.PunchHole], ZeroRange may leave the range
+// allocated on disk.
+//
+// ZeroRange returns an error if the file was opened with O_APPEND and
+// the range must be written explicitly.
+// If there is an error, it will be of type [*PathError].
+func (f *File) ZeroRange(off, length int64) error {
+	if err := f.checkValid("zerorange"); err != nil {
+		return err
+	}
+	if off < 0 || length <= 0 || off+length < 0 {
+		return &PathError{Op: "zerorange", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := zeroRange(f, off, length)
+	if errors.Is(err, errors.ErrUnsupported) {
+		err = writeZeros(f, off, length)
+	}
+	runtime.KeepAlive(f)
+	return f.wrapErr("zerorange", err)
+}
+
+// zeroRangeBufSize is the size of the largest write
+// writeZeros makes.
+const zeroRangeBufSize = 64 << 10
+
+// writeZeros zeroes the range by writing to it.
+func writeZeros(f *File, off, length int64) error {
+	if f.appendMode {
+		return errWriteAtInAppendMode
+	}
+	buf := make([]byte, min(length, zeroRangeBufSize))
+	for length > 0 {
+		n, err := f.pwrite(buf[:min(length, int64(len(buf)))], off)
+		if err != nil {
+			return err
+		}
+		off += int64(n)
+		length -= int64(n)
+	}
+	return nil
+}
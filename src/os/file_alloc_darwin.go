@@ -0,0 +1,74 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func preallocate(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var st syscall.Stat_t
+		if err = ignoringEINTR(func() error {
+			return syscall.Fstat(int(fd), &st)
+		}); err != nil {
+			return
+		}
+		// F_PEOFPOSMODE allocates from the end of the storage
+		// already allocated to the file, rather than its size.
+		end := off + length
+		if allocated := st.Blocks * 512; end > allocated {
+			store := syscall.Fstore_t{
+				Flags:   syscall.F_ALLOCATECONTIG | syscall.F_ALLOCATEALL,
+				Posmode: syscall.F_PEOFPOSMODE,
+				Length:  end - allocated,
+			}
+			err = ignoringEINTR(func() error {
+				return unix.FcntlPreallocate(int(fd), &store)
+			})
+			if err == syscall.ENOSPC {
+				// Contiguous storage is not available; take any.
+				store.Flags = syscall.F_ALLOCATEALL
+				err = ignoringEINTR(func() error {
+					return unix.FcntlPreallocate(int(fd), &store)
+				})
+			}
+			if err != nil {
+				return
+			}
+		}
+		if end > st.Size {
+			err = ignoringEINTR(func() error {
+				return syscall.Ftruncate(int(fd), end)
+			})
+		}
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func punchHole(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		hole := unix.Fpunchhole_t{
+			Offset: off,
+			Length: length,
+		}
+		err = ignoringEINTR(func() error {
+			return unix.FcntlPunchhole(int(fd), &hole)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func zeroRange(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+)
+
+func preallocate(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.PosixFallocate(int(fd), off, length)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func punchHole(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
+
+func zeroRange(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
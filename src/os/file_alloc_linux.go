@@ -0,0 +1,46 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func preallocate(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.Fallocate(int(fd), 0, off, length)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func punchHole(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.Fallocate(int(fd), unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, off, length)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func zeroRange(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.Fallocate(int(fd), unix.FALLOC_FL_ZERO_RANGE, off, length)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
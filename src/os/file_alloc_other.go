@@ -0,0 +1,21 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !freebsd && !linux && !windows
+
+package os
+
+import "errors"
+
+func preallocate(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
+
+func punchHole(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
+
+func zeroRange(f *File, off, length int64) error {
+	return errors.ErrUnsupported
+}
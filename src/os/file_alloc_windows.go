@@ -0,0 +1,96 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+func preallocate(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		h := syscall.Handle(fd)
+		var info windows.FILE_STANDARD_INFO
+		if err = windows.GetFileInformationByHandleEx(h, windows.FileStandardInfo, (*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+			return
+		}
+		end := off + length
+		if end > info.AllocationSize {
+			alloc := windows.FILE_ALLOCATION_INFO{AllocationSize: end}
+			if err = windows.SetFileInformationByHandle(h, windows.FileAllocationInfo, unsafe.Pointer(&alloc), uint32(unsafe.Sizeof(alloc))); err != nil {
+				return
+			}
+		}
+		if end > info.EndOfFile {
+			// The file system zeroes the new bytes when they are
+			// first read, rather than when the file is extended.
+			eof := windows.FILE_END_OF_FILE_INFO{EndOfFile: end}
+			err = windows.SetFileInformationByHandle(h, windows.FileEndOfFileInfo, unsafe.Pointer(&eof), uint32(unsafe.Sizeof(eof)))
+		}
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func punchHole(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		h := syscall.Handle(fd)
+		// FSCTL_SET_ZERO_DATA only releases storage in a sparse file.
+		var n uint32
+		if err = syscall.DeviceIoControl(h, windows.FSCTL_SET_SPARSE, nil, 0, nil, 0, &n, nil); err != nil {
+			return
+		}
+		zero := windows.FILE_ZERO_DATA_INFORMATION{
+			FileOffset:      off,
+			BeyondFinalZero: off + length,
+		}
+		err = syscall.DeviceIoControl(h, windows.FSCTL_SET_ZERO_DATA, (*byte)(unsafe.Pointer(&zero)), uint32(unsafe.Sizeof(zero)), nil, 0, &n, nil)
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func zeroRange(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		h := syscall.Handle(fd)
+		var info windows.FILE_STANDARD_INFO
+		if err = windows.GetFileInformationByHandleEx(h, windows.FileStandardInfo, (*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+			return
+		}
+		// FSCTL_SET_ZERO_DATA does not extend the file, but the bytes
+		// added by extending it already read as zeros.
+		end := off + length
+		if end > info.EndOfFile {
+			eof := windows.FILE_END_OF_FILE_INFO{EndOfFile: end}
+			if err = windows.SetFileInformationByHandle(h, windows.FileEndOfFileInfo, unsafe.Pointer(&eof), uint32(unsafe.Sizeof(eof))); err != nil {
+				return
+			}
+			end = info.EndOfFile
+		}
+		if off >= end {
+			return
+		}
+		zero := windows.FILE_ZERO_DATA_INFORMATION{
+			FileOffset:      off,
+			BeyondFinalZero: end,
+		}
+		var n uint32
+		err = syscall.DeviceIoControl(h, windows.FSCTL_SET_ZERO_DATA, (*byte)(unsafe.Pointer(&zero)), uint32(unsafe.Sizeof(zero)), nil, 0, &n, nil)
+		switch err {
+		case windows.ERROR_NOT_SUPPORTED, windows.ERROR_INVALID_FUNCTION:
+			err = errors.ErrUnsupported
+		}
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// FileAttrs is a set of file attribute flags,
+// read and written by [File.GetAttrs] and [File.SetAttrs].
+type FileAttrs uint32
+
+const (
+	// AttrImmutable marks a file which may not be modified,
+	// renamed, linked to, or removed.
+	AttrImmutable FileAttrs = 1 << iota
+
+	// AttrAppendOnly marks a file which may be written only
+	// by appending to it.
+	AttrAppendOnly
+
+	// AttrNoDump marks a file to be skipped by backups made with dump(8).
+	AttrNoDump
+)
+
+// GetAttrs returns the attribute flags of the file.
+//
+// On Linux, GetAttrs uses the FS_IOC_GETFLAGS ioctl. On BSD systems
+// and Darwin, it reports the user flags UF_IMMUTABLE, UF_APPEND, and
+// UF_NODUMP. On other systems, and for files on file systems which do not
+// support the flags, GetAttrs returns an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) GetAttrs() (FileAttrs, error) {
+	if err := f.checkValid("getattrs"); err != nil {
+		return 0, err
+	}
+	attrs, err := getAttrs(f)
+	runtime.KeepAlive(f)
+	if err != nil {
+		return 0, f.wrapErr("getattrs", err)
+	}
+	return attrs, nil
+}
+
+// SetAttrs sets the attribute flags of the file to attrs,
+// leaving any other flags the system supports unchanged.
+//
+// On Linux, SetAttrs uses the FS_IOC_SETFLAGS ioctl, and setting
+// AttrImmutable or AttrAppendOnly requires the CAP_LINUX_IMMUTABLE
+// capability. On BSD systems and Darwin, it sets the user flags with
+// fchflags(2), and the caller must own the file.
+// See [File.GetAttrs] for details.
+func (f *File) SetAttrs(attrs FileAttrs) error {
+	if err := f.checkValid("setattrs"); err != nil {
+		return err
+	}
+	err := setAttrs(f, attrs)
+	runtime.KeepAlive(f)
+	return f.wrapErr("setattrs", err)
+}
+
+// Chflags sets the file flags of the file to flags, which are the
+// system's values for the st_flags field of struct stat, such as
+// UF_APPEND or SF_IMMUTABLE.
+//
+// Chflags is implemented on BSD systems and Darwin, using fchflags(2).
+// On other systems, Chflags returns an error wrapping [errors.ErrUnsupported];
+// use [File.SetAttrs] instead.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Chflags(flags int) error {
+	if err := f.checkValid("chflags"); err != nil {
+		return err
+	}
+	err := chflags(f, flags)
+	runtime.KeepAlive(f)
+	return f.wrapErr("chflags", err)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package os
+
+import "syscall"
+
+// User file flags, the same on all BSD systems and Darwin.
+const (
+	_UF_NODUMP    = 0x1
+	_UF_IMMUTABLE = 0x2
+	_UF_APPEND    = 0x4
+)
+
+var fileAttrFlags = [...]struct {
+	attr FileAttrs
+	flag uint32
+}{
+	{AttrImmutable, _UF_IMMUTABLE},
+	{AttrAppendOnly, _UF_APPEND},
+	{AttrNoDump, _UF_NODUMP},
+}
+
+func getAttrs(f *File) (attrs FileAttrs, err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var flags uint32
+		if flags, err = fstatFlags(fd); err != nil {
+			return
+		}
+		for _, a := range fileAttrFlags {
+			if flags&a.flag != 0 {
+				attrs |= a.attr
+			}
+		}
+	})
+	if cerr != nil {
+		return 0, cerr
+	}
+	return attrs, err
+}
+
+func setAttrs(f *File, attrs FileAttrs) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var flags uint32
+		if flags, err = fstatFlags(fd); err != nil {
+			return
+		}
+		for _, a := range fileAttrFlags {
+			flags &^= a.flag
+			if attrs&a.attr != 0 {
+				flags |= a.flag
+			}
+		}
+		err = fchflags(fd, int(flags))
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func chflags(f *File, flags int) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = fchflags(fd, flags)
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func fstatFlags(fd uintptr) (uint32, error) {
+	var st syscall.Stat_t
+	err := ignoringEINTR(func() error {
+		return syscall.Fstat(int(fd), &st)
+	})
+	return uint32(st.Flags), err
+}
+
+func fchflags(fd uintptr, flags int) error {
+	return ignoringEINTR(func() error {
+		return syscall.Fchflags(int(fd), flags)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+var fileAttrFlags = [...]struct {
+	attr FileAttrs
+	flag int32
+}{
+	{AttrImmutable, unix.FS_IMMUTABLE_FL},
+	{AttrAppendOnly, unix.FS_APPEND_FL},
+	{AttrNoDump, unix.FS_NODUMP_FL},
+}
+
+func getAttrs(f *File) (attrs FileAttrs, err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var flags int32
+		if flags, err = ioctlGetFlags(fd); err != nil {
+			return
+		}
+		for _, a := range fileAttrFlags {
+			if flags&a.flag != 0 {
+				attrs |= a.attr
+			}
+		}
+	})
+	if cerr != nil {
+		return 0, cerr
+	}
+	return attrs, err
+}
+
+func setAttrs(f *File, attrs FileAttrs) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var flags int32
+		if flags, err = ioctlGetFlags(fd); err != nil {
+			return
+		}
+		for _, a := range fileAttrFlags {
+			flags &^= a.flag
+			if attrs&a.attr != 0 {
+				flags |= a.flag
+			}
+		}
+		err = ignoringEINTR(func() error {
+			return unix.IoctlSetFlags(int(fd), flags)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	if err == syscall.ENOTTY {
+		return errors.ErrUnsupported
+	}
+	return err
+}
+
+func ioctlGetFlags(fd uintptr) (flags int32, err error) {
+	err = ignoringEINTR(func() error {
+		flags, err = unix.IoctlGetFlags(int(fd))
+		return err
+	})
+	if err == syscall.ENOTTY {
+		// The file system does not support inode flags.
+		return 0, errors.ErrUnsupported
+	}
+	return flags, err
+}
+
+func chflags(f *File, flags int) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,21 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package os
+
+import "errors"
+
+func getAttrs(f *File) (FileAttrs, error) {
+	return 0, errors.ErrUnsupported
+}
+
+func setAttrs(f *File, attrs FileAttrs) error {
+	return errors.ErrUnsupported
+}
+
+func chflags(f *File, flags int) error {
+	return errors.ErrUnsupported
+}
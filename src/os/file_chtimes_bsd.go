@@ -0,0 +1,39 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package os
+
+import (
+	"syscall"
+	"time"
+)
+
+func fchtimes(f *File, fd uintptr, at, mt time.Time) error {
+	if at.IsZero() || mt.IsZero() {
+		// futimes cannot leave a time unchanged,
+		// so set it to its current value.
+		fs := &fileStat{}
+		if err := ignoringEINTR(func() error {
+			return syscall.Fstat(int(fd), &fs.sys)
+		}); err != nil {
+			return err
+		}
+		fillFileStatFromSys(fs, f.name)
+		if at.IsZero() {
+			at = atime(fs)
+		}
+		if mt.IsZero() {
+			mt = fs.ModTime()
+		}
+	}
+	tv := []syscall.Timeval{
+		syscall.NsecToTimeval(at.UnixNano()),
+		syscall.NsecToTimeval(mt.UnixNano()),
+	}
+	return ignoringEINTR(func() error {
+		return syscall.Futimes(int(fd), tv)
+	})
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"time"
+)
+
+func fchtimes(f *File, fd uintptr, atime, mtime time.Time) error {
+	utimes := chtimesUtimes(atime, mtime)
+	return ignoringEINTR(func() error {
+		return unix.Futimens(int(fd), &utimes)
+	})
+}
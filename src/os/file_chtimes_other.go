@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || (js && wasm) || solaris || wasip1
+
+package os
+
+import (
+	"errors"
+	"time"
+)
+
+func fchtimes(f *File, fd uintptr, atime, mtime time.Time) error {
+	return errors.ErrUnsupported
+}
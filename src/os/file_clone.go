@@ -0,0 +1,31 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// Clone replaces the contents of the file with those of src,
+// sharing storage with src until either file is modified,
+// so that the copy is made without reading or writing the data.
+// The file must be open for writing, and src for reading.
+//
+// On Linux, Clone uses the FICLONE ioctl. When the file system does not
+// support cloning, or the files are on different file systems, and on
+// other systems, Clone returns an error wrapping [errors.ErrUnsupported].
+// Callers can then fall back to [File.CopyRange] or [io.Copy].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Clone(src *File) error {
+	if err := f.checkValid("clone"); err != nil {
+		return err
+	}
+	if err := src.checkValid("clone"); err != nil {
+		return err
+	}
+	err := cloneFrom(f, src)
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(src)
+	return f.wrapErr("clone", err)
+}
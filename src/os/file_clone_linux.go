@@ -0,0 +1,34 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func cloneFrom(f, src *File) (err error) {
+	cerr := f.pfd.RawControl(func(dfd uintptr) {
+		cerr := src.pfd.RawControl(func(sfd uintptr) {
+			err = ignoringEINTR(func() error {
+				return unix.IoctlFileClone(int(dfd), int(sfd))
+			})
+		})
+		if err == nil {
+			err = cerr
+		}
+	})
+	if err == nil {
+		err = cerr
+	}
+	switch err {
+	case syscall.EOPNOTSUPP, syscall.EXDEV, syscall.EINVAL, syscall.ENOTTY:
+		// EINVAL is reported by file systems which
+		// do not support cloning the files.
+		return errors.ErrUnsupported
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"io"
+	"runtime"
+	"syscall"
+)
+
+// CopyRange copies n bytes from the File starting at byte offset srcOff
+// to dst starting at byte offset dstOff. It does not use or change
+// the file offset of either file. It returns the number of bytes copied
+// and the first error encountered while copying, if any.
+// On return, written == n if and only if err == nil.
+// If the File ends before n bytes have been copied, CopyRange returns io.EOF.
+//
+// On Linux and FreeBSD, CopyRange uses copy_file_range(2), which lets
+// the kernel or filesystem copy the data without passing it through
+// user space, and on some filesystems share the storage of the two ranges.
+// When the system call is not supported for the two files, for example
+// because they are on different filesystems of an older Linux kernel,
+// CopyRange falls back to reading the data and writing it to dst, as it
+// does on other systems.
+//
+// If f and dst refer to the same file, the two ranges must not overlap.
+// If dst was opened with the [O_APPEND] flag, CopyRange returns an error.
+func (f *File) CopyRange(dst *File, srcOff, dstOff, n int64) (written int64, err error) {
+	if err := f.checkValid("copyrange"); err != nil {
+		return 0, err
+	}
+	if err := dst.checkValid("copyrange"); err != nil {
+		return 0, err
+	}
+	if dst.appendMode {
+		return 0, errWriteAtInAppendMode
+	}
+	if srcOff < 0 || dstOff < 0 || n < 0 {
+		return 0, &PathError{Op: "copyrange", Path: f.name, Err: syscall.EINVAL}
+	}
+
+	written, handled, err := copyRange(f, dst, srcOff, dstOff, n)
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(dst)
+	if handled {
+		if err != nil {
+			return written, f.wrapErr("copyrange", err)
+		}
+		if written < n {
+			return written, io.EOF
+		}
+		return written, nil
+	}
+
+	buf := make([]byte, min(n-written, 32*1024))
+	for written < n {
+		b := buf[:min(n-written, int64(len(buf)))]
+		m, rerr := f.ReadAt(b, srcOff+written)
+		if m > 0 {
+			if _, err := dst.WriteAt(b[:m], dstOff+written); err != nil {
+				return written, err
+			}
+			written += int64(m)
+		}
+		if rerr != nil {
+			return written, rerr
+		}
+	}
+	return written, nil
+}
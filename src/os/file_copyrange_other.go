@@ -0,0 +1,11 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !freebsd && !linux
+
+package os
+
+func copyRange(src, dst *File, srcOff, dstOff, n int64) (written int64, handled bool, err error) {
+	return 0, false, nil
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build freebsd || linux
+
+package os
+
+import "internal/poll"
+
+// copyRange copies n bytes from src to dst with copy_file_range(2).
+// It reports whether the copy was handled; if not, the caller should
+// copy the remainder of the range, after the first written bytes.
+func copyRange(src, dst *File, srcOff, dstOff, n int64) (written int64, handled bool, err error) {
+	return poll.CopyFileRangeAt(&dst.pfd, &src.pfd, srcOff, dstOff, n)
+}
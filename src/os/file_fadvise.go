@@ -0,0 +1,60 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// FadviseAdvice is advice to the system about how a program will access
+// a range of a file, for [File.Fadvise].
+type FadviseAdvice int
+
+const (
+	// FadviseNormal indicates no particular pattern of access.
+	// It undoes any earlier advice.
+	FadviseNormal FadviseAdvice = iota
+
+	// FadviseRandom indicates that the range will be accessed at random,
+	// so that reading ahead is not useful.
+	FadviseRandom
+
+	// FadviseSequential indicates that the range will be accessed
+	// sequentially, from lower offsets to higher ones.
+	FadviseSequential
+
+	// FadviseWillNeed indicates that the range will be accessed soon,
+	// so that it may be read into the cache now.
+	FadviseWillNeed
+
+	// FadviseDontNeed indicates that the range will not be accessed soon,
+	// so that it may be dropped from the cache.
+	FadviseDontNeed
+
+	// FadviseNoReuse indicates that the range will be accessed only once.
+	FadviseNoReuse
+)
+
+// Fadvise advises the system how the program will access length bytes of
+// the file starting at offset off. A length of zero means the range from
+// off to the end of the file. The advice affects only performance, such as
+// how much the system reads ahead and what it keeps in its cache.
+//
+// On Linux, Fadvise uses posix_fadvise(2).
+// On other systems, Fadvise does nothing.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Fadvise(off, length int64, advice FadviseAdvice) error {
+	if err := f.checkValid("fadvise"); err != nil {
+		return err
+	}
+	if off < 0 || length < 0 || advice < FadviseNormal || advice > FadviseNoReuse {
+		return &PathError{Op: "fadvise", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := fadvise(f, off, length, advice)
+	runtime.KeepAlive(f)
+	return f.wrapErr("fadvise", err)
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+var fadviseAdvice = [...]int{
+	FadviseNormal:     unix.POSIX_FADV_NORMAL,
+	FadviseRandom:     unix.POSIX_FADV_RANDOM,
+	FadviseSequential: unix.POSIX_FADV_SEQUENTIAL,
+	FadviseWillNeed:   unix.POSIX_FADV_WILLNEED,
+	FadviseDontNeed:   unix.POSIX_FADV_DONTNEED,
+	FadviseNoReuse:    unix.POSIX_FADV_NOREUSE,
+}
+
+func fadvise(f *File, off, length int64, advice FadviseAdvice) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = unix.Fadvise(int(fd), off, length, fadviseAdvice[advice])
+	})
+	if cerr != nil {
+		return cerr
+	}
+	if err == syscall.ENOSYS {
+		// The advice is only a hint.
+		return nil
+	}
+	return err
+}
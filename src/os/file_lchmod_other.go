@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package os
+
+import "errors"
+
+func lchmod(name string, mode FileMode) error {
+	return errors.ErrUnsupported
+}
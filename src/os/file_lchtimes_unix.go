@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"time"
+)
+
+func lchtimes(name string, atime, mtime time.Time) error {
+	utimes := chtimesUtimes(atime, mtime)
+	return ignoringEINTR(func() error {
+		return unix.Utimensat(unix.AT_FDCWD, name, &utimes, unix.AT_SYMLINK_NOFOLLOW)
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+	"time"
+)
+
+func lchtimes(name string, atime, mtime time.Time) error {
+	namep, err := syscall.UTF16PtrFromString(fixLongPath(name))
+	if err != nil {
+		return err
+	}
+	// FILE_FLAG_OPEN_REPARSE_POINT opens a symbolic link or
+	// junction itself, rather than the file it refers to.
+	h, err := syscall.CreateFile(namep, windows.FILE_WRITE_ATTRIBUTES,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE, nil,
+		syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS|syscall.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(h)
+	return fchtimes(nil, uintptr(h), atime, mtime)
+}
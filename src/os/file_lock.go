@@ -0,0 +1,177 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// Lock places an exclusive advisory lock on the file,
+// blocking until any conflicting lock is released.
+//
+// Locks are held by the open file, not by the process or goroutine:
+// two Files opened separately on the same path, even in the same process,
+// do not share a lock, and one will block the other.
+// A lock is released by [File.Unlock] or when the file is closed.
+//
+// On Unix systems, Lock uses flock(2). The lock is shared with any
+// duplicate of the file descriptor, including one inherited by a child
+// process through [os/exec.Cmd.ExtraFiles] or [ProcAttr.Files], and is
+// not released until every such descriptor is closed. Descriptors opened
+// by this package are close-on-exec, so a child process does not
+// otherwise inherit the lock. Calling Lock on a file which already holds
+// a shared lock converts it to an exclusive lock; the conversion is not
+// atomic.
+//
+// On Windows, Lock uses LockFileEx to lock the entire file.
+// Unlike on Unix systems, the lock is mandatory: while it is held,
+// reads and writes of the file through other handles fail.
+// Calling Lock on a file which already holds a lock blocks forever.
+//
+// On other systems, Lock returns an error wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Lock() error {
+	_, err := f.lock("lock", true, true)
+	return err
+}
+
+// RLock places a shared advisory lock on the file,
+// blocking until any exclusive lock is released.
+// Any number of Files may hold a shared lock on the same file at once.
+// See [File.Lock] for details.
+func (f *File) RLock() error {
+	_, err := f.lock("rlock", false, true)
+	return err
+}
+
+// TryLock attempts to place an exclusive advisory lock on the file
+// without blocking. It reports whether the lock was acquired;
+// if a conflicting lock is held, it returns false and a nil error.
+// See [File.Lock] for details.
+func (f *File) TryLock() (bool, error) {
+	return f.lock("lock", true, false)
+}
+
+// TryRLock attempts to place a shared advisory lock on the file
+// without blocking. It reports whether the lock was acquired;
+// if an exclusive lock is held, it returns false and a nil error.
+// See [File.Lock] for details.
+func (f *File) TryRLock() (bool, error) {
+	return f.lock("rlock", false, false)
+}
+
+// Unlock releases a lock placed on the file by [File.Lock], [File.RLock],
+// [File.TryLock], or [File.TryRLock].
+// If there is an error, it will be of type [*PathError].
+func (f *File) Unlock() error {
+	if err := f.checkValid("unlock"); err != nil {
+		return err
+	}
+	err := unlockFile(f)
+	runtime.KeepAlive(f)
+	return f.wrapErr("unlock", err)
+}
+
+func (f *File) lock(op string, exclusive, wait bool) (bool, error) {
+	if err := f.checkValid(op); err != nil {
+		return false, err
+	}
+	ok, err := lockFile(f, exclusive, wait)
+	runtime.KeepAlive(f)
+	if err != nil {
+		return false, f.wrapErr(op, err)
+	}
+	return ok, nil
+}
+
+// LockRange places an exclusive advisory lock on length bytes of the file
+// starting at offset off, blocking until any conflicting lock is released.
+// A length of zero locks from off to the end of the file, including any
+// bytes later added to it.
+//
+// As with [File.Lock], the lock is held by the open file, and is released
+// by [File.UnlockRange] or when the file is closed. Locks held by the same
+// File on different ranges do not conflict with each other.
+//
+// On Linux, LockRange uses open file description locks
+// (fcntl F_OFD_SETLKW). These are unlike traditional POSIX record locks,
+// which are held by the process and released when any descriptor
+// for the file is closed. Range locks and locks placed by [File.Lock]
+// are independent of each other.
+//
+// On Windows, LockRange uses LockFileEx, and the lock is mandatory.
+// A range must be unlocked with exactly the offset and length
+// with which it was locked.
+//
+// On other systems, and on Linux kernels older than 3.15,
+// locking the whole file with an offset and length of zero
+// is the same as calling [File.Lock], and locking any other range
+// returns an error wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) LockRange(off, length int64) error {
+	_, err := f.lockRange("lock", off, length, true, true)
+	return err
+}
+
+// RLockRange places a shared advisory lock on length bytes of the file
+// starting at offset off, blocking until any exclusive lock
+// on an overlapping range is released.
+// See [File.LockRange] for details.
+func (f *File) RLockRange(off, length int64) error {
+	_, err := f.lockRange("rlock", off, length, false, true)
+	return err
+}
+
+// TryLockRange attempts to place an exclusive advisory lock on length bytes
+// of the file starting at offset off without blocking. It reports whether
+// the lock was acquired; if a conflicting lock is held, it returns false
+// and a nil error.
+// See [File.LockRange] for details.
+func (f *File) TryLockRange(off, length int64) (bool, error) {
+	return f.lockRange("lock", off, length, true, false)
+}
+
+// TryRLockRange attempts to place a shared advisory lock on length bytes
+// of the file starting at offset off without blocking. It reports whether
+// the lock was acquired; if an exclusive lock is held on an overlapping
+// range, it returns false and a nil error.
+// See [File.LockRange] for details.
+func (f *File) TryRLockRange(off, length int64) (bool, error) {
+	return f.lockRange("rlock", off, length, false, false)
+}
+
+// UnlockRange releases a lock placed on length bytes of the file
+// starting at offset off by [File.LockRange], [File.RLockRange],
+// [File.TryLockRange], or [File.TryRLockRange].
+// If there is an error, it will be of type [*PathError].
+func (f *File) UnlockRange(off, length int64) error {
+	if err := f.checkValid("unlock"); err != nil {
+		return err
+	}
+	if off < 0 || length < 0 {
+		return &PathError{Op: "unlock", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := unlockFileRange(f, off, length)
+	runtime.KeepAlive(f)
+	return f.wrapErr("unlock", err)
+}
+
+func (f *File) lockRange(op string, off, length int64, exclusive, wait bool) (bool, error) {
+	if err := f.checkValid(op); err != nil {
+		return false, err
+	}
+	if off < 0 || length < 0 {
+		return false, &PathError{Op: op, Path: f.name, Err: syscall.EINVAL}
+	}
+	ok, err := lockFileRange(f, off, length, exclusive, wait)
+	runtime.KeepAlive(f)
+	if err != nil {
+		return false, f.wrapErr(op, err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"io"
+	"syscall"
+)
+
+func lockFileRange(f *File, off, length int64, exclusive, wait bool) (bool, error) {
+	typ := int16(syscall.F_RDLCK)
+	if exclusive {
+		typ = syscall.F_WRLCK
+	}
+	cmd := unix.F_OFD_SETLKW
+	if !wait {
+		cmd = unix.F_OFD_SETLK
+	}
+	err := fcntlLockRange(f, cmd, typ, off, length)
+	if err == syscall.EINVAL && off == 0 && length == 0 {
+		// Open file description locks are not supported by this kernel.
+		return lockFile(f, exclusive, wait)
+	}
+	if (err == syscall.EAGAIN || err == syscall.EACCES) && !wait {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFileRange(f *File, off, length int64) error {
+	err := fcntlLockRange(f, unix.F_OFD_SETLK, syscall.F_UNLCK, off, length)
+	if err == syscall.EINVAL && off == 0 && length == 0 {
+		return unlockFile(f)
+	}
+	return err
+}
+
+func fcntlLockRange(f *File, cmd int, typ int16, off, length int64) (err error) {
+	lk := syscall.Flock_t{
+		Type:   typ,
+		Whence: io.SeekStart,
+		Start:  off,
+		Len:    length,
+		// Pid must be zero for open file description locks.
+	}
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.FcntlFlock(fd, cmd, &lk)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || windows)
+
+package os
+
+import "errors"
+
+func lockFile(f *File, exclusive, wait bool) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func unlockFile(f *File) error {
+	return errors.ErrUnsupported
+}
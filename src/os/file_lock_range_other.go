@@ -0,0 +1,26 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !windows
+
+package os
+
+import "errors"
+
+// Without open file description locks, only a lock on the whole file
+// can be held by the open file rather than the process.
+
+func lockFileRange(f *File, off, length int64, exclusive, wait bool) (bool, error) {
+	if off != 0 || length != 0 {
+		return false, errors.ErrUnsupported
+	}
+	return lockFile(f, exclusive, wait)
+}
+
+func unlockFileRange(f *File, off, length int64) error {
+	if off != 0 || length != 0 {
+		return errors.ErrUnsupported
+	}
+	return unlockFile(f)
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+
+package os
+
+import "syscall"
+
+func lockFile(f *File, exclusive, wait bool) (locked bool, err error) {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.Flock(int(fd), how)
+		})
+	})
+	if cerr != nil {
+		return false, cerr
+	}
+	if err == syscall.EWOULDBLOCK && !wait {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFile(f *File) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return syscall.Flock(int(fd), syscall.LOCK_UN)
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
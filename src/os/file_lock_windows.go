@@ -0,0 +1,65 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+)
+
+// overlappedRange returns the OVERLAPPED structure holding the offset of
+// the range of length bytes at off, and the length to pass to LockFileEx.
+// A length of zero means the range from off to the largest possible offset.
+func overlappedRange(off, length int64) (ol *syscall.Overlapped, lenLow, lenHigh uint32) {
+	n := uint64(length)
+	if n == 0 {
+		n = ^uint64(0) - uint64(off)
+	}
+	ol = &syscall.Overlapped{
+		Offset:     uint32(off),
+		OffsetHigh: uint32(off >> 32),
+	}
+	return ol, uint32(n), uint32(n >> 32)
+}
+
+func lockFile(f *File, exclusive, wait bool) (bool, error) {
+	return lockFileRange(f, 0, 0, exclusive, wait)
+}
+
+func unlockFile(f *File) error {
+	return unlockFileRange(f, 0, 0)
+}
+
+func lockFileRange(f *File, off, length int64, exclusive, wait bool) (locked bool, err error) {
+	var flags uint32
+	if exclusive {
+		flags |= windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	if !wait {
+		flags |= windows.LOCKFILE_FAIL_IMMEDIATELY
+	}
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		ol, lenLow, lenHigh := overlappedRange(off, length)
+		err = windows.LockFileEx(syscall.Handle(fd), flags, 0, lenLow, lenHigh, ol)
+	})
+	if cerr != nil {
+		return false, cerr
+	}
+	if err == windows.ERROR_LOCK_VIOLATION && !wait {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFileRange(f *File, off, length int64) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		ol, lenLow, lenHigh := overlappedRange(off, length)
+		err = windows.UnlockFileEx(syscall.Handle(fd), 0, lenLow, lenHigh, ol)
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
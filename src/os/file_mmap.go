@@ -0,0 +1,55 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"runtime"
+	"sync"
+	"syscall"
+)
+
+// Mmap maps length bytes of the file, starting at offset off, into memory
+// for reading. It returns the mapped bytes and a function that releases
+// the mapping. The file must be open for reading, and off need not be
+// aligned to the page size.
+//
+// The returned slice must not be modified, and must not be used after
+// unmap has been called. The mapping remains valid after the file is
+// closed, so the file may be closed while mappings are still in use.
+// Calling unmap more than once is safe; later calls return the result
+// of the first one.
+//
+// The mapped range must lie within the file. If the file is truncated
+// while a mapping is live, accessing the part of the mapping past the
+// new end of the file may crash the program.
+//
+// On systems that do not support memory mapping, such as js, wasip1,
+// and plan9, Mmap returns an error wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Mmap(off, length int64) (data []byte, unmap func() error, err error) {
+	if err := f.checkValid("mmap"); err != nil {
+		return nil, nil, err
+	}
+	if off < 0 || length < 0 || int64(int(length)) != length {
+		return nil, nil, f.wrapErr("mmap", syscall.EINVAL)
+	}
+	if length == 0 {
+		return []byte{}, func() error { return nil }, nil
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if off > fi.Size() || length > fi.Size()-off {
+		return nil, nil, f.wrapErr("mmap", syscall.EINVAL)
+	}
+	data, release, err := mmapFile(f, off, int(length))
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, nil, f.wrapErr("mmap", err)
+	}
+	return data, sync.OnceValue(release), nil
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package os
+
+import "errors"
+
+func mmapFile(f *File, off int64, length int) ([]byte, func() error, error) {
+	return nil, nil, errors.ErrUnsupported
+}
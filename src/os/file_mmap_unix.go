@@ -0,0 +1,32 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import "syscall"
+
+// mmapFile maps length bytes of f starting at off, which need not be
+// page aligned. The returned function unmaps the whole mapping.
+func mmapFile(f *File, off int64, length int) ([]byte, func() error, error) {
+	pageSize := int64(syscall.Getpagesize())
+	start := off &^ (pageSize - 1)
+	delta := int(off - start)
+	var mem []byte
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		mem, err = syscall.Mmap(int(fd), start, delta+length, syscall.PROT_READ, syscall.MAP_SHARED)
+	})
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	unmap := func() error {
+		return NewSyscallError("munmap", syscall.Munmap(mem))
+	}
+	return mem[delta : delta+length : delta+length], unmap, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var allocationGranularity = sync.OnceValue(func() int64 {
+	var info windows.SystemInfo
+	windows.GetSystemInfo(&info)
+	return int64(info.AllocationGranularity)
+})
+
+// mmapFile maps length bytes of f starting at off, which need not be
+// aligned to the allocation granularity. The returned function unmaps
+// the view. The file mapping object itself is closed before returning,
+// as the view keeps it alive.
+func mmapFile(f *File, off int64, length int) ([]byte, func() error, error) {
+	start := off &^ (allocationGranularity() - 1)
+	delta := int(off - start)
+	var addr uintptr
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var h syscall.Handle
+		h, err = syscall.CreateFileMapping(syscall.Handle(fd), nil, syscall.PAGE_READONLY, 0, 0, nil)
+		if err != nil {
+			err = NewSyscallError("CreateFileMapping", err)
+			return
+		}
+		defer syscall.CloseHandle(h)
+		addr, err = syscall.MapViewOfFile(h, syscall.FILE_MAP_READ, uint32(start>>32), uint32(start), uintptr(delta+length))
+		if err != nil {
+			err = NewSyscallError("MapViewOfFile", err)
+		}
+	})
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	unmap := func() error {
+		return NewSyscallError("UnmapViewOfFile", syscall.UnmapViewOfFile(addr))
+	}
+	mem := unsafe.Slice((*byte)(unsafe.Pointer(addr)), delta+length)
+	return mem[delta:], unmap, nil
+}
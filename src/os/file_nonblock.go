@@ -0,0 +1,52 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// SetNonblock sets whether the file is in non-blocking mode.
+//
+// A File in non-blocking mode is registered with the runtime poller:
+// a Read or Write which cannot proceed parks the goroutine, rather than
+// blocking its thread, and deadlines are supported for the file.
+// Files opened with [Open] or [OpenFile] are usually in non-blocking mode
+// when the poller supports them, as for pipes, FIFOs, and terminals;
+// the poller does not support regular files or directories.
+//
+// SetNonblock(true) sets the O_NONBLOCK flag of the file and registers it
+// with the poller, if it is not already. It returns an error if the poller
+// does not support the file, leaving the file in blocking mode.
+// SetNonblock(false) clears the flag, after which I/O blocks the thread,
+// as after a call to [File.Fd].
+// Since the flag belongs to the open file description, changing it
+// also affects other descriptors referring to the file, such as
+// those created by [File.Dup] or inherited by child processes.
+//
+// On Windows, a file is in non-blocking mode if it was opened for
+// overlapped I/O, and SetNonblock returns an error wrapping
+// [errors.ErrUnsupported] when asked to change the mode.
+// On Plan 9, files are always in blocking mode.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) SetNonblock(nonblocking bool) error {
+	if err := f.checkValid("setnonblock"); err != nil {
+		return err
+	}
+	err := f.setNonblock(nonblocking)
+	runtime.KeepAlive(f)
+	return f.wrapErr("setnonblock", err)
+}
+
+// IsNonblock reports whether the file is in non-blocking mode,
+// and so registered with the runtime poller.
+// See [File.SetNonblock] for details.
+func (f *File) IsNonblock() bool {
+	if f == nil {
+		return false
+	}
+	nonblocking := f.isNonblock()
+	runtime.KeepAlive(f)
+	return nonblocking
+}
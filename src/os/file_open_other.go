@@ -0,0 +1,14 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+// These flags are zero where the system has no equivalent,
+// so that they have no effect when passed to OpenFile.
+const (
+	o_PATH    = 0
+	o_NOATIME = 0
+)
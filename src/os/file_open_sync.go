@@ -0,0 +1,14 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || linux || netbsd || openbsd || solaris
+
+package os
+
+import "syscall"
+
+const (
+	o_DSYNC = syscall.O_DSYNC
+	o_RSYNC = syscall.O_RSYNC
+)
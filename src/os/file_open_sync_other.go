@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !aix && !darwin && !linux && !netbsd && !openbsd && !solaris
+
+package os
+
+import "syscall"
+
+// O_DSYNC falls back to the stronger O_SYNC, which on Windows
+// opens the file with FILE_FLAG_WRITE_THROUGH.
+const (
+	o_DSYNC = syscall.O_SYNC
+	o_RSYNC = 0
+)
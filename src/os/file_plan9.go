@@ -5,6 +5,7 @@
 package os
 
 import (
+	"errors"
 	"internal/bytealg"
 	"internal/poll"
 	"internal/stringslite"
@@ -31,6 +32,7 @@ type file struct {
 	name       string
 	dirinfo    atomic.Pointer[dirInfo] // nil unless directory being read
 	appendMode bool                    // whether file is opened for appending
+	tmpName    string                  // name of an emulated O_TMPFILE file, removed on close
 }
 
 // fd is the Plan 9 implementation of Fd.
@@ -52,6 +54,32 @@ func newFileFromNewFile(fd uintptr, name string) *File {
 	return f
 }
 
+// setNonblock is the Plan 9 implementation of SetNonblock.
+func (f *File) setNonblock(nonblocking bool) error {
+	if nonblocking {
+		return errors.ErrUnsupported
+	}
+	return nil
+}
+
+// isNonblock reports false: files are always in blocking mode.
+func (f *File) isNonblock() bool {
+	return false
+}
+
+// dup is the Plan 9 implementation of Dup.
+func (f *File) dup() (*File, error) {
+	if err := f.incref("dup"); err != nil {
+		return nil, err
+	}
+	defer f.decref()
+	fd, _, err := poll.DupCloseOnExec(f.sysfd)
+	if err != nil {
+		return nil, err
+	}
+	return newFileFromNewFile(uintptr(fd), f.name), nil
+}
+
 // Auxiliary information if the File describes a directory
 type dirInfo struct {
 	mu   sync.Mutex
@@ -83,7 +111,7 @@ func syscallMode(i FileMode) (o uint32) {
 }
 
 // openFileNolog is the Plan 9 implementation of OpenFile.
-func openFileNolog(name string, flag int, perm FileMode) (*File, error) {
+func openFileNolog(name string, flag int, perm FileMode, _ *OpenFileOptions) (*File, error) {
 	var (
 		fd     int
 		e      error
@@ -135,7 +163,7 @@ func openFileNolog(name string, flag int, perm FileMode) (*File, error) {
 }
 
 func openDirNolog(name string) (*File, error) {
-	return openFileNolog(name, O_RDONLY, 0)
+	return openFileNolog(name, O_RDONLY, 0, nil)
 }
 
 // Close closes the File, rendering it unusable for I/O.
@@ -158,6 +186,9 @@ func (file *file) close() error {
 	// How do we do that on Plan 9?
 
 	err := file.decref()
+	if file.tmpName != "" {
+		Remove(file.tmpName)
+	}
 
 	// no need for a finalizer anymore
 	runtime.SetFinalizer(file, nil)
@@ -276,6 +307,49 @@ func (f *File) Sync() error {
 	return nil
 }
 
+// SyncData is the same as [File.Sync] on Plan 9.
+func (f *File) SyncData() error {
+	return f.Sync()
+}
+
+// Chtimes changes the access and modification times of the file,
+// like the package-level [Chtimes] function, but acts on the open file
+// rather than on a path.
+// A zero [time.Time] value will leave the corresponding file time unchanged.
+// If there is an error, it will be of type [*PathError].
+func (f *File) Chtimes(atime time.Time, mtime time.Time) error {
+	if f == nil {
+		return ErrInvalid
+	}
+	var d syscall.Dir
+
+	d.Null()
+	d.Atime = uint32(atime.Unix())
+	d.Mtime = uint32(mtime.Unix())
+	if atime.IsZero() {
+		d.Atime = 0xFFFFFFFF
+	}
+	if mtime.IsZero() {
+		d.Mtime = 0xFFFFFFFF
+	}
+
+	var buf [syscall.STATFIXLEN]byte
+	n, err := d.Marshal(buf[:])
+	if err != nil {
+		return &PathError{Op: "chtimes", Path: f.name, Err: err}
+	}
+
+	if err := f.incref("chtimes"); err != nil {
+		return err
+	}
+	defer f.decref()
+
+	if err = syscall.Fwstat(f.sysfd, buf[:n]); err != nil {
+		return &PathError{Op: "chtimes", Path: f.name, Err: err}
+	}
+	return nil
+}
+
 // read reads up to len(b) bytes from the File.
 // It returns the number of bytes read and an error, if any.
 func (f *File) read(b []byte) (n int, err error) {
@@ -469,6 +543,14 @@ func Chtimes(name string, atime time.Time, mtime time.Time) error {
 	return nil
 }
 
+// Lchtimes changes the access and modification times of the named file,
+// like [Chtimes]. Plan 9 has no symbolic links, so Lchtimes is the same
+// as Chtimes.
+// If there is an error, it will be of type [*PathError].
+func Lchtimes(name string, atime time.Time, mtime time.Time) error {
+	return Chtimes(name, atime, mtime)
+}
+
 // Pipe returns a connected pair of Files; reads from r return bytes
 // written to w. It returns the files and an error, if any.
 func Pipe() (r *File, w *File, err error) {
@@ -547,6 +629,7 @@ func (f *File) Chdir() error {
 	if e := syscall.Fchdir(f.sysfd); e != nil {
 		return &PathError{Op: "chdir", Path: f.name, Err: e}
 	}
+	chdirDone("")
 	return nil
 }
 
@@ -169,6 +169,25 @@ func (f *File) Sync() error {
 	return nil
 }
 
+// SyncData is like [File.Sync], but need not commit changes to the file's
+// metadata, such as its modification time, which are not needed to read
+// its contents. A change in the file's size is always committed.
+// Storage engines which sync a write-ahead log after every commit
+// may use SyncData as a cheaper barrier than Sync.
+//
+// On Linux, SyncData uses fdatasync(2). On Darwin, where fdatasync
+// does not flush the drive's cache, and on other systems,
+// including Windows, SyncData is the same as Sync.
+func (f *File) SyncData() error {
+	if err := f.checkValid("sync"); err != nil {
+		return err
+	}
+	if e := f.pfd.Fdatasync(); e != nil {
+		return f.wrapErr("sync", e)
+	}
+	return nil
+}
+
 // Chtimes changes the access and modification times of the named
 // file, similar to the Unix utime() or utimes() functions.
 // A zero [time.Time] value will leave the corresponding file time unchanged.
@@ -184,6 +203,51 @@ func Chtimes(name string, atime time.Time, mtime time.Time) error {
 	return nil
 }
 
+// Lchtimes changes the access and modification times of the named file,
+// like [Chtimes]. If the file is a symbolic link, it changes the times
+// of the link itself rather than of its target.
+// A zero [time.Time] value will leave the corresponding file time unchanged.
+//
+// On Unix systems, Lchtimes uses utimensat(2) with AT_SYMLINK_NOFOLLOW.
+// On Windows, it opens the reparse point itself and uses SetFileTime.
+// On other systems, Lchtimes returns an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func Lchtimes(name string, atime time.Time, mtime time.Time) error {
+	if e := lchtimes(name, atime, mtime); e != nil {
+		return &PathError{Op: "lchtimes", Path: name, Err: e}
+	}
+	return nil
+}
+
+// Chtimes changes the access and modification times of the file,
+// like the package-level [Chtimes] function, but acts on the open file
+// rather than on a path, which may have been renamed or replaced
+// since the file was opened.
+// A zero [time.Time] value will leave the corresponding file time unchanged.
+//
+// On Linux, Chtimes uses futimens. On BSD systems and Darwin,
+// it uses futimes, which has a resolution of one microsecond.
+// On Windows, it uses SetFileTime, and the file must have been opened
+// with write access. On other systems, Chtimes returns an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Chtimes(atime time.Time, mtime time.Time) error {
+	if err := f.checkValid("chtimes"); err != nil {
+		return err
+	}
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = fchtimes(f, fd, atime, mtime)
+	})
+	if cerr != nil {
+		err = cerr
+	}
+	return f.wrapErr("chtimes", err)
+}
+
 func chtimesUtimes(atime, mtime time.Time) [2]syscall.Timespec {
 	var utimes [2]syscall.Timespec
 	set := func(i int, t time.Time) {
@@ -200,6 +264,9 @@ func chtimesUtimes(atime, mtime time.Time) [2]syscall.Timespec {
 
 // Chdir changes the current working directory to the file,
 // which must be a directory.
+// On Unix and Windows, Chdir uses the open file rather than its name,
+// so it changes to the directory even if the directory has been renamed
+// since it was opened.
 // If there is an error, it will be of type [*PathError].
 func (f *File) Chdir() error {
 	if err := f.checkValid("chdir"); err != nil {
@@ -208,6 +275,7 @@ func (f *File) Chdir() error {
 	if e := f.pfd.Fchdir(); e != nil {
 		return f.wrapErr("chdir", e)
 	}
+	chdirDone("")
 	return nil
 }
 
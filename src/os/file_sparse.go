@@ -0,0 +1,69 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"iter"
+)
+
+// A SparseRegion is a range of a file reported by [File.SparseRegions].
+type SparseRegion struct {
+	Offset int64
+	Length int64
+	Hole   bool // the range is a hole, which reads as zeros
+}
+
+// SparseRegions returns an iterator over the ranges of the file,
+// in order of offset, which alternate between data and holes.
+// Together the ranges cover the file from offset zero to its size
+// when iteration starts.
+//
+// On Unix systems, SparseRegions uses lseek(2) with SEEK_DATA and
+// SEEK_HOLE, restoring the I/O offset before each range is yielded.
+// On Windows, it uses FSCTL_QUERY_ALLOCATED_RANGES.
+// Where holes cannot be detected, the whole file is reported as data.
+// A file system may report a hole as data, but never data as a hole.
+//
+// If an error occurs, the iterator yields it, wrapped in a [*PathError],
+// and stops.
+func (f *File) SparseRegions() iter.Seq2[SparseRegion, error] {
+	return func(yield func(SparseRegion, error) bool) {
+		fi, err := f.Stat()
+		if err != nil {
+			yield(SparseRegion{}, err)
+			return
+		}
+		size := fi.Size()
+		for pos := int64(0); pos < size; {
+			data, hole, err := sparseNext(f, pos)
+			if errors.Is(err, errors.ErrUnsupported) {
+				data, hole, err = pos, size, nil
+			}
+			if err != nil {
+				yield(SparseRegion{}, f.wrapErr("sparseregions", err))
+				return
+			}
+			if data < 0 || data > size {
+				data = size
+			}
+			if hole <= data || hole > size {
+				hole = size
+			}
+			if data > pos {
+				if !yield(SparseRegion{Offset: pos, Length: data - pos, Hole: true}, nil) {
+					return
+				}
+			}
+			if data == size {
+				return
+			}
+			if !yield(SparseRegion{Offset: data, Length: hole - data}, nil) {
+				return
+			}
+			pos = hole
+		}
+	}
+}
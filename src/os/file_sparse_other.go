@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package os
+
+import "errors"
+
+func sysSeekWhence(whence int) int {
+	return whence
+}
+
+func sparseNext(f *File, pos int64) (data, hole int64, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"syscall"
+)
+
+// sysSeekWhence returns the system's value for the whence argument of lseek.
+func sysSeekWhence(whence int) int {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "ios" {
+		// Darwin numbers SEEK_HOLE and SEEK_DATA the other way around.
+		switch whence {
+		case SeekData:
+			return 4
+		case SeekHole:
+			return 3
+		}
+	}
+	return whence
+}
+
+// sparseNext returns the range [data, hole) of the first data in the file
+// at or after pos. If there is no data after pos, data is -1.
+// It returns an error wrapping ErrUnsupported if holes cannot be detected.
+func sparseNext(f *File, pos int64) (data, hole int64, err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		sysfd := int(fd)
+		var cur int64
+		cur, err = syscall.Seek(sysfd, 0, io.SeekCurrent)
+		if err != nil {
+			return
+		}
+		defer syscall.Seek(sysfd, cur, io.SeekStart)
+		data, err = syscall.Seek(sysfd, pos, sysSeekWhence(SeekData))
+		if err == syscall.ENXIO {
+			data, err = -1, nil
+			return
+		}
+		if err != nil {
+			return
+		}
+		hole, err = syscall.Seek(sysfd, data, sysSeekWhence(SeekHole))
+	})
+	if cerr != nil {
+		return 0, 0, cerr
+	}
+	if err == syscall.EINVAL {
+		// SEEK_DATA is not supported.
+		err = errors.ErrUnsupported
+	}
+	return data, hole, err
+}
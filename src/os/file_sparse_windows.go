@@ -0,0 +1,92 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"io"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// sparseNext returns the range [data, hole) of the first data in the file
+// at or after pos. If there is no data after pos, data is -1.
+func sparseNext(f *File, pos int64) (data, hole int64, err error) {
+	data = -1
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		h := syscall.Handle(fd)
+		// Adjacent allocated ranges may be reported separately;
+		// query until the range after the data is not allocated.
+		for {
+			in := windows.FILE_ALLOCATED_RANGE_BUFFER{
+				FileOffset: pos,
+				Length:     1<<63 - 1 - pos,
+			}
+			var out windows.FILE_ALLOCATED_RANGE_BUFFER
+			var n uint32
+			e := syscall.DeviceIoControl(h, windows.FSCTL_QUERY_ALLOCATED_RANGES,
+				(*byte)(unsafe.Pointer(&in)), uint32(unsafe.Sizeof(in)),
+				(*byte)(unsafe.Pointer(&out)), uint32(unsafe.Sizeof(out)), &n, nil)
+			if e != nil && e != syscall.ERROR_MORE_DATA {
+				err = e
+				return
+			}
+			if n == 0 {
+				return
+			}
+			start := max(out.FileOffset, pos)
+			if data < 0 {
+				data = start
+			} else if start > hole {
+				return
+			}
+			hole = out.FileOffset + out.Length
+			pos = hole
+		}
+	})
+	if cerr != nil {
+		return 0, 0, cerr
+	}
+	return data, hole, err
+}
+
+// seekSparse implements Seek with a whence of SeekData or SeekHole.
+func (f *File) seekSparse(offset int64, whence int) (int64, error) {
+	var info windows.FILE_STANDARD_INFO
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = windows.GetFileInformationByHandleEx(syscall.Handle(fd), windows.FileStandardInfo, (*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	})
+	if cerr != nil {
+		return 0, cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	size := info.EndOfFile
+	if offset < 0 {
+		return 0, syscall.EINVAL
+	}
+	if offset >= size {
+		return 0, syscall.ENXIO
+	}
+	data, hole, err := sparseNext(f, offset)
+	if err != nil {
+		return 0, err
+	}
+	target := offset
+	switch {
+	case whence == SeekData && (data < 0 || data >= size):
+		return 0, syscall.ENXIO
+	case whence == SeekData:
+		target = data
+	case data == offset:
+		target = min(hole, size)
+	}
+	ret, err := f.pfd.Seek(target, io.SeekStart)
+	runtime.KeepAlive(f)
+	return ret, err
+}
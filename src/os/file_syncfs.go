@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// Syncfs commits the contents of the whole file system containing the
+// file to stable storage, so that a program which has written many files
+// to one file system can make them all durable at once, without syncing
+// every file system on the machine.
+//
+// On Linux, Syncfs uses syncfs(2). On other systems, Syncfs is the same
+// as [File.Sync], and commits only the file itself.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Syncfs() error {
+	if err := f.checkValid("syncfs"); err != nil {
+		return err
+	}
+	err := syncfs(f)
+	runtime.KeepAlive(f)
+	return f.wrapErr("syncfs", err)
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "internal/syscall/unix"
+
+func syncfs(f *File) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.Syncfs(int(fd))
+		})
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+func syncfs(f *File) error {
+	err := f.Sync()
+	if pe, ok := err.(*PathError); ok {
+		// Syncfs wraps the error itself.
+		return pe.Err
+	}
+	return err
+}
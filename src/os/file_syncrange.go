@@ -0,0 +1,58 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// SyncRangeFlags control the behavior of [File.SyncRange].
+type SyncRangeFlags int
+
+// The values are those of the Linux SYNC_FILE_RANGE flags.
+const (
+	// SyncRangeWaitBefore waits for writeback of any pages in the range
+	// already being written before starting further writeback.
+	SyncRangeWaitBefore SyncRangeFlags = 1 << iota
+
+	// SyncRangeWrite starts writeback of the dirty pages in the range
+	// which are not already being written.
+	SyncRangeWrite
+
+	// SyncRangeWaitAfter waits for writeback of the pages in the range
+	// to complete.
+	SyncRangeWaitAfter
+)
+
+// SyncRange starts or waits for writeback of n bytes of the file
+// starting at offset off, as directed by flags. A length of zero
+// means the range from off to the end of the file.
+//
+// SyncRange lets a program which writes a large file sequentially
+// begin writing completed regions to storage without waiting for them,
+// for example by calling SyncRange with SyncRangeWrite after each region
+// and with SyncRangeWaitBefore|SyncRangeWrite|SyncRangeWaitAfter on a
+// region written some time before.
+// SyncRange does not commit the file's metadata, nor flush the drive's
+// cache, and so does not guarantee that the data is durable;
+// use [File.Sync] or [File.SyncData] for that.
+//
+// On Linux, SyncRange uses sync_file_range(2). On other systems,
+// SyncRange with SyncRangeWaitAfter is the same as [File.SyncData],
+// which writes the whole file, and SyncRange without it does nothing.
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) SyncRange(off, n int64, flags SyncRangeFlags) error {
+	if err := f.checkValid("syncrange"); err != nil {
+		return err
+	}
+	if off < 0 || n < 0 || flags&^(SyncRangeWaitBefore|SyncRangeWrite|SyncRangeWaitAfter) != 0 {
+		return &PathError{Op: "syncrange", Path: f.name, Err: syscall.EINVAL}
+	}
+	err := syncRange(f, off, n, flags)
+	runtime.KeepAlive(f)
+	return f.wrapErr("syncrange", err)
+}
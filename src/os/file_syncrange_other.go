@@ -0,0 +1,15 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+func syncRange(f *File, off, n int64, flags SyncRangeFlags) error {
+	if flags&SyncRangeWaitAfter == 0 {
+		// Starting writeback is only a hint.
+		return nil
+	}
+	return f.SyncData()
+}
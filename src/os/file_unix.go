@@ -63,6 +63,11 @@ type file struct {
 	nonblock    bool                    // whether we set nonblocking mode
 	stdoutOrErr bool                    // whether this is stdout or stderr
 	appendMode  bool                    // whether file is opened for appending
+	tmpName     string                  // name of an emulated O_TMPFILE file, removed on close
+
+	// rootFDs, if non-nil, is decremented when the file is closed.
+	// See Root.ActiveFDs.
+	rootFDs atomic.Pointer[atomic.Int64]
 }
 
 // fd is the Unix implementation of Fd.
@@ -99,6 +104,34 @@ func newFileFromNewFile(fd uintptr, name string) *File {
 	return f
 }
 
+// dup is the Unix implementation of Dup.
+func (f *File) dup() (*File, error) {
+	fd, _, err := f.pfd.Dup()
+	if err != nil {
+		return nil, err
+	}
+	return newFileFromNewFile(uintptr(fd), f.name), nil
+}
+
+// setNonblock is the Unix implementation of SetNonblock.
+func (f *File) setNonblock(nonblocking bool) error {
+	if !nonblocking {
+		f.nonblock = false
+		return f.pfd.SetBlocking()
+	}
+	if err := f.pfd.SetNonblock(); err != nil {
+		return err
+	}
+	// Tell Fd to return a blocking descriptor.
+	f.nonblock = true
+	return nil
+}
+
+// isNonblock is the Unix implementation of IsNonblock.
+func (f *File) isNonblock() bool {
+	return f.pfd.IsNonblock()
+}
+
 // net_newUnixFile is a hidden entry point called by net.conn.File.
 // This is used so that a nonblocking network connection will become
 // blocking if code calls the Fd method. We don't want that for direct
@@ -242,7 +275,7 @@ const DevNull = "/dev/null"
 
 // openFileNolog is the Unix implementation of OpenFile.
 // Changes here should be reflected in openDirAt and openDirNolog, if relevant.
-func openFileNolog(name string, flag int, perm FileMode) (*File, error) {
+func openFileNolog(name string, flag int, perm FileMode, _ *OpenFileOptions) (*File, error) {
 	setSticky := false
 	if !supportsCreateWithStickyBit && flag&O_CREATE != 0 && perm&ModeSticky != 0 {
 		if _, err := Stat(name); IsNotExist(err) {
@@ -317,6 +350,12 @@ func (file *file) close() error {
 		}
 		err = &PathError{Op: "close", Path: file.name, Err: e}
 	}
+	if fds := file.rootFDs.Swap(nil); fds != nil {
+		fds.Add(-1)
+	}
+	if file.tmpName != "" {
+		Remove(file.tmpName)
+	}
 
 	// no need for a finalizer anymore
 	runtime.SetFinalizer(file, nil)
@@ -333,7 +372,7 @@ func (f *File) seek(offset int64, whence int) (ret int64, err error) {
 		// access this file as a directory again. See #35767 and #37161.
 		info.close()
 	}
-	ret, err = f.pfd.Seek(offset, whence)
+	ret, err = f.pfd.Seek(offset, sysSeekWhence(whence))
 	runtime.KeepAlive(f)
 	return ret, err
 }
@@ -0,0 +1,49 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// EnableVerity enables fs-verity on the file. Once enabled, the file
+// becomes read-only and its contents are verified against a Merkle tree
+// of SHA-256 hashes whenever they are read. Enabling fs-verity cannot
+// be undone. The file must be open for reading only, and no other
+// descriptors may have it open for writing.
+//
+// On Linux, EnableVerity uses the FS_IOC_ENABLE_VERITY ioctl. When the
+// file system does not support fs-verity, and on other systems,
+// EnableVerity returns an error wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) EnableVerity() error {
+	if err := f.checkValid("enableverity"); err != nil {
+		return err
+	}
+	err := f.enableVerity()
+	runtime.KeepAlive(f)
+	return f.wrapErr("enableverity", err)
+}
+
+// MeasureVerity returns the fs-verity digest of the file, which is
+// the SHA-256 hash of its fs-verity descriptor. The digest identifies
+// the complete contents of the file and can be compared against a
+// digest computed elsewhere by fs-verity tooling.
+//
+// MeasureVerity returns an error if fs-verity is not enabled on the file.
+// On systems other than Linux, it returns an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) MeasureVerity() ([]byte, error) {
+	if err := f.checkValid("measureverity"); err != nil {
+		return nil, err
+	}
+	digest, err := f.measureVerity()
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, f.wrapErr("measureverity", err)
+	}
+	return digest, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func (f *File) enableVerity() error {
+	// Merkle tree blocks the size of a page are supported
+	// by every kernel that implements fs-verity.
+	blockSize := uint32(syscall.Getpagesize())
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.IoctlEnableVerity(int(fd), unix.FS_VERITY_HASH_ALG_SHA256, blockSize)
+		})
+	})
+	if err == nil {
+		err = cerr
+	}
+	return verityError(err)
+}
+
+func (f *File) measureVerity() ([]byte, error) {
+	var digest []byte
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		_, digest, err = unix.IoctlMeasureVerity(int(fd))
+	})
+	if err == nil {
+		err = cerr
+	}
+	return digest, verityError(err)
+}
+
+// verityError maps the errors reported by file systems
+// without fs-verity support to errors.ErrUnsupported.
+func verityError(err error) error {
+	switch err {
+	case syscall.EOPNOTSUPP, syscall.ENOTTY:
+		return errors.ErrUnsupported
+	}
+	return err
+}
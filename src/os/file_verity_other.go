@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+import "errors"
+
+func (f *File) enableVerity() error {
+	return errors.ErrUnsupported
+}
+
+func (f *File) measureVerity() ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,21 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// preadv reads into bufs, which must not be empty, starting at offset off.
+func (f *File) preadv(bufs [][]byte, off int64) (int, error) {
+	n, err := f.pfd.Preadv(bufs, off)
+	runtime.KeepAlive(f)
+	return n, err
+}
+
+// pwritev writes bufs, which must not be empty, starting at offset off.
+func (f *File) pwritev(bufs [][]byte, off int64) (int, error) {
+	n, err := f.pfd.Pwritev(bufs, off)
+	runtime.KeepAlive(f)
+	return n, err
+}
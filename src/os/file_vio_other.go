@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+// preadv reads into bufs, which must not be empty, starting at offset off.
+// It reads only into the first buffer.
+func (f *File) preadv(bufs [][]byte, off int64) (int, error) {
+	return f.pread(bufs[0], off)
+}
+
+// pwritev writes bufs, which must not be empty, starting at offset off.
+// It writes only the first buffer.
+func (f *File) pwritev(bufs [][]byte, off int64) (int, error) {
+	return f.pwrite(bufs[0], off)
+}
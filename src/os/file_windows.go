@@ -9,6 +9,7 @@ import (
 	"internal/filepathlite"
 	"internal/godebug"
 	"internal/poll"
+	"internal/stringslite"
 	"internal/syscall/windows"
 	"runtime"
 	"sync"
@@ -29,6 +30,11 @@ type file struct {
 	name       string
 	dirinfo    atomic.Pointer[dirInfo] // nil unless directory being read
 	appendMode bool                    // whether file is opened for appending
+	tmpName    string                  // name of an emulated O_TMPFILE file, removed on close
+
+	// rootFDs, if non-nil, is decremented when the file is closed.
+	// See Root.ActiveFDs.
+	rootFDs atomic.Pointer[atomic.Int64]
 }
 
 // fd is the Windows implementation of Fd.
@@ -91,6 +97,40 @@ func newFileFromNewFile(fd uintptr, name string) *File {
 	return newFile(h, name, "file", nonBlocking)
 }
 
+// dup is the Windows implementation of Dup.
+func (f *File) dup() (*File, error) {
+	var (
+		h   syscall.Handle
+		err error
+	)
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		var nfd int
+		nfd, _, err = poll.DupCloseOnExec(int(fd))
+		h = syscall.Handle(nfd)
+	})
+	if cerr != nil {
+		return nil, cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newFileFromNewFile(uintptr(h), f.name), nil
+}
+
+// setNonblock is the Windows implementation of SetNonblock.
+// The mode of a handle is fixed when it is opened.
+func (f *File) setNonblock(nonblocking bool) error {
+	if nonblocking != f.pfd.IsNonblock() {
+		return errors.ErrUnsupported
+	}
+	return nil
+}
+
+// isNonblock is the Windows implementation of IsNonblock.
+func (f *File) isNonblock() bool {
+	return f.pfd.IsNonblock()
+}
+
 // net_newWindowsFile is a hidden entry point called by net.conn.File.
 // This is used so that the File.pfd.close method calls [syscall.Closesocket]
 // instead of [syscall.CloseHandle].
@@ -111,21 +151,29 @@ func epipecheck(file *File, e error) {
 const DevNull = "NUL"
 
 // openFileNolog is the Windows implementation of OpenFile.
-func openFileNolog(name string, flag int, perm FileMode) (*File, error) {
+func openFileNolog(name string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
 	if name == "" {
 		return nil, &PathError{Op: "open", Path: name, Err: syscall.ENOENT}
 	}
 	path := fixLongPath(name)
-	r, err := syscall.Open(path, flag|syscall.O_CLOEXEC, syscallMode(perm))
+	shareMode := uint32(syscall.FILE_SHARE_READ | syscall.FILE_SHARE_WRITE)
+	var fileFlags uint32
+	if opts != nil {
+		if opts.WindowsShareMode != 0 {
+			shareMode = opts.WindowsShareMode
+		}
+		fileFlags = opts.WindowsFileFlags
+	}
+	r, err := windows.OpenWithOptions(path, flag|syscall.O_CLOEXEC, syscallMode(perm), shareMode, fileFlags)
 	if err != nil {
 		return nil, &PathError{Op: "open", Path: name, Err: err}
 	}
-	// syscall.Open always returns a non-blocking handle.
-	return newFile(r, name, "file", false), nil
+	// The handle is blocking unless FILE_FLAG_OVERLAPPED was requested.
+	return newFile(r, name, "file", fileFlags&windows.FILE_FLAG_OVERLAPPED != 0), nil
 }
 
 func openDirNolog(name string) (*File, error) {
-	return openFileNolog(name, O_RDONLY, 0)
+	return openFileNolog(name, O_RDONLY, 0, nil)
 }
 
 func (file *file) close() error {
@@ -142,6 +190,12 @@ func (file *file) close() error {
 		}
 		err = &PathError{Op: "close", Path: file.name, Err: e}
 	}
+	if fds := file.rootFDs.Swap(nil); fds != nil {
+		fds.Add(-1)
+	}
+	if file.tmpName != "" {
+		Remove(file.tmpName)
+	}
 
 	// no need for a finalizer anymore
 	runtime.SetFinalizer(file, nil)
@@ -158,6 +212,9 @@ func (f *File) seek(offset int64, whence int) (ret int64, err error) {
 		// access this file as a directory again. See #35767 and #37161.
 		info.close()
 	}
+	if whence == SeekData || whence == SeekHole {
+		return f.seekSparse(offset, whence)
+	}
 	ret, err = f.pfd.Seek(offset, whence)
 	runtime.KeepAlive(f)
 	return ret, err
@@ -178,9 +235,40 @@ func Truncate(name string, size int64) error {
 	return nil
 }
 
+// winposixsemantics=1 makes Remove and Rename use POSIX semantics,
+// which permit deleting or replacing a file while it is open.
+// Root always uses them.
+var winposixsemantics = godebug.New("winposixsemantics")
+
+// posixUnsupported reports whether err, returned by removePOSIX or renamePOSIX,
+// indicates that the operation is not supported for the file or its file
+// system, rather than that it failed. Remove and Rename then fall back
+// to their previous implementation, which reports any error.
+func posixUnsupported(err error) bool {
+	switch err {
+	case windows.ERROR_NOT_SUPPORTED, windows.ERROR_INVALID_FUNCTION, windows.ERROR_INVALID_PARAMETER:
+		return true
+	case syscall.EINVAL:
+		// openParent cannot split the name into a directory
+		// and a final element.
+		return true
+	}
+	return false
+}
+
 // Remove removes the named file or directory.
 // If there is an error, it will be of type [*PathError].
 func Remove(name string) error {
+	if winposixsemantics.Value() == "1" {
+		winposixsemantics.IncNonDefault()
+		if err := removePOSIX(name); !posixUnsupported(err) {
+			if err != nil {
+				return &PathError{Op: "remove", Path: name, Err: err}
+			}
+			return nil
+		}
+	}
+
 	p, e := syscall.UTF16PtrFromString(fixLongPath(name))
 	if e != nil {
 		return &PathError{Op: "remove", Path: name, Err: e}
@@ -218,6 +306,16 @@ func Remove(name string) error {
 }
 
 func rename(oldname, newname string) error {
+	if winposixsemantics.Value() == "1" {
+		winposixsemantics.IncNonDefault()
+		if err := renamePOSIX(oldname, newname); !posixUnsupported(err) {
+			if err != nil {
+				return &LinkError{"rename", oldname, newname, err}
+			}
+			return nil
+		}
+	}
+
 	e := windows.Rename(fixLongPath(oldname), fixLongPath(newname))
 	if e != nil {
 		return &LinkError{"rename", oldname, newname, e}
@@ -225,6 +323,50 @@ func rename(oldname, newname string) error {
 	return nil
 }
 
+// removePOSIX removes name using FILE_DISPOSITION_POSIX_SEMANTICS,
+// which unlinks the file immediately even if other handles to it are open.
+func removePOSIX(name string) error {
+	dir, base, err := openParent(name)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(dir)
+	return windows.Deleteat(dir, base, 0)
+}
+
+// renamePOSIX renames oldname to newname using FILE_RENAME_POSIX_SEMANTICS,
+// which permits replacing newname even if other handles to it are open.
+func renamePOSIX(oldname, newname string) error {
+	olddir, oldbase, err := openParent(oldname)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(olddir)
+	newdir, newbase, err := openParent(newname)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(newdir)
+	return windows.Renameat(olddir, oldbase, newdir, newbase)
+}
+
+// openParent opens the directory containing name,
+// returning a handle to it and the final path element of name.
+func openParent(name string) (syscall.Handle, string, error) {
+	dir, base := filepathlite.Split(name)
+	if base == "" || base == "." || base == ".." {
+		return syscall.InvalidHandle, "", syscall.EINVAL
+	}
+	if dir == "" {
+		dir = "."
+	}
+	h, err := syscall.Open(fixLongPath(dir), syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return syscall.InvalidHandle, "", err
+	}
+	return h, base, nil
+}
+
 // Pipe returns a connected pair of Files; reads from r return bytes written to w.
 // It returns the files and an error, if any. The Windows handles underlying
 // the returned files are marked as inheritable by child processes.
@@ -346,6 +488,51 @@ func Symlink(oldname, newname string) error {
 	return nil
 }
 
+// CreateJunction creates link as a directory junction referencing
+// the directory target.
+//
+// A junction, or mount point, behaves much like a symbolic link to a
+// directory, but creating one does not require any special privileges.
+// Junction targets are always absolute: a relative target is resolved
+// relative to the current directory when the junction is created.
+// A junction may only reference a directory on a local volume.
+//
+// If there is an error, it will be of type [*LinkError].
+//
+// CreateJunction is only available on Windows.
+func CreateJunction(link, target string) error {
+	abs, err := syscall.FullPath(target)
+	if err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	h, base, err := openParent(link)
+	if err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	defer syscall.CloseHandle(h)
+	substitute, printName := junctionTargetNames(abs)
+	if err := windows.Junctionat(substitute, printName, h, base); err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	return nil
+}
+
+// junctionTargetNames returns the substitute (NT) and print (Win32)
+// forms of abs, an absolute path, as stored in a junction.
+// It is the inverse of normaliseLinkPath.
+func junctionTargetNames(abs string) (substitute, printName string) {
+	if s, ok := stringslite.CutPrefix(abs, `\\?\UNC\`); ok {
+		return `\??\UNC\` + s, `\\` + s
+	}
+	if s, ok := stringslite.CutPrefix(abs, `\\?\`); ok {
+		return `\??\` + s, s
+	}
+	if s, ok := stringslite.CutPrefix(abs, `\\`); ok {
+		return `\??\UNC\` + s, abs
+	}
+	return `\??\` + abs, abs
+}
+
 // openSymlink calls CreateFile Windows API with FILE_FLAG_OPEN_REPARSE_POINT
 // parameter, so that Windows does not follow symlink, if path is a symlink.
 // openSymlink returns opened file handle.
@@ -401,6 +588,12 @@ func normaliseLinkPath(path string) (string, error) {
 	}
 	defer syscall.CloseHandle(h)
 
+	return finalPathName(h)
+}
+
+// finalPathName returns the DOS path of the file opened as h,
+// as reported by GetFinalPathNameByHandle.
+func finalPathName(h syscall.Handle) (string, error) {
 	buf := make([]uint16, 100)
 	for {
 		n, err := windows.GetFinalPathNameByHandle(h, &buf[0], uint32(len(buf)), windows.VOLUME_NAME_DOS)
@@ -412,7 +605,7 @@ func normaliseLinkPath(path string) (string, error) {
 		}
 		buf = make([]uint16, n)
 	}
-	s = syscall.UTF16ToString(buf)
+	s := syscall.UTF16ToString(buf)
 	if len(s) > 4 && s[:4] == `\\?\` {
 		s = s[4:]
 		if len(s) > 3 && s[:3] == `UNC` {
@@ -434,11 +627,18 @@ func readReparseLink(path string) (string, error) {
 }
 
 func readReparseLinkHandle(h syscall.Handle) (string, error) {
+	link, _, err := readReparseLinkHandleTag(h)
+	return link, err
+}
+
+// readReparseLinkHandleTag is like readReparseLinkHandle,
+// but also returns the reparse tag of the link.
+func readReparseLinkHandleTag(h syscall.Handle) (link string, tag uint32, err error) {
 	rdbbuf := make([]byte, syscall.MAXIMUM_REPARSE_DATA_BUFFER_SIZE)
 	var bytesReturned uint32
-	err := syscall.DeviceIoControl(h, syscall.FSCTL_GET_REPARSE_POINT, nil, 0, &rdbbuf[0], uint32(len(rdbbuf)), &bytesReturned, nil)
+	err = syscall.DeviceIoControl(h, syscall.FSCTL_GET_REPARSE_POINT, nil, 0, &rdbbuf[0], uint32(len(rdbbuf)), &bytesReturned, nil)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
 	rdb := (*windows.REPARSE_DATA_BUFFER)(unsafe.Pointer(&rdbbuf[0]))
@@ -447,15 +647,17 @@ func readReparseLinkHandle(h syscall.Handle) (string, error) {
 		rb := (*windows.SymbolicLinkReparseBuffer)(unsafe.Pointer(&rdb.DUMMYUNIONNAME))
 		s := rb.Path()
 		if rb.Flags&windows.SYMLINK_FLAG_RELATIVE != 0 {
-			return s, nil
+			return s, rdb.ReparseTag, nil
 		}
-		return normaliseLinkPath(s)
+		link, err = normaliseLinkPath(s)
+		return link, rdb.ReparseTag, err
 	case windows.IO_REPARSE_TAG_MOUNT_POINT:
-		return normaliseLinkPath((*windows.MountPointReparseBuffer)(unsafe.Pointer(&rdb.DUMMYUNIONNAME)).Path())
+		link, err = normaliseLinkPath((*windows.MountPointReparseBuffer)(unsafe.Pointer(&rdb.DUMMYUNIONNAME)).Path())
+		return link, rdb.ReparseTag, err
 	default:
 		// the path is not a symlink or junction but another type of reparse
 		// point
-		return "", syscall.ENOENT
+		return "", rdb.ReparseTag, syscall.ENOENT
 	}
 }
 
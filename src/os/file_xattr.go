@@ -0,0 +1,72 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// Getxattr returns the value of the extended attribute name of the file.
+//
+// Extended attributes are name-value pairs associated with a file
+// in addition to its contents. On Linux, names have a namespace prefix,
+// such as "user." for attributes which any user with permission to read
+// or write the file may read or write.
+// If the file has no attribute name, the error wraps ENODATA
+// on Linux and ENOATTR on Darwin.
+//
+// Getxattr and the other extended attribute methods are implemented on
+// Linux and Darwin. On other systems, and for files on file systems which
+// do not support extended attributes, they return an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func (f *File) Getxattr(name string) ([]byte, error) {
+	if err := f.checkValid("getxattr"); err != nil {
+		return nil, err
+	}
+	val, err := getxattr(f, name)
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, f.wrapErr("getxattr", err)
+	}
+	return val, nil
+}
+
+// Setxattr sets the value of the extended attribute name of the file,
+// creating the attribute or replacing any existing value.
+// See [File.Getxattr] for details.
+func (f *File) Setxattr(name string, value []byte) error {
+	if err := f.checkValid("setxattr"); err != nil {
+		return err
+	}
+	err := setxattr(f, name, value)
+	runtime.KeepAlive(f)
+	return f.wrapErr("setxattr", err)
+}
+
+// Listxattr returns the names of the extended attributes of the file.
+// On Linux, it lists only the attributes the caller is permitted to read.
+// See [File.Getxattr] for details.
+func (f *File) Listxattr() ([]string, error) {
+	if err := f.checkValid("listxattr"); err != nil {
+		return nil, err
+	}
+	names, err := listxattr(f)
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, f.wrapErr("listxattr", err)
+	}
+	return names, nil
+}
+
+// Removexattr removes the extended attribute name of the file.
+// See [File.Getxattr] for details.
+func (f *File) Removexattr(name string) error {
+	if err := f.checkValid("removexattr"); err != nil {
+		return err
+	}
+	err := removexattr(f, name)
+	runtime.KeepAlive(f)
+	return f.wrapErr("removexattr", err)
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !linux
+
+package os
+
+import "errors"
+
+func getxattr(f *File, name string) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func setxattr(f *File, name string, value []byte) error {
+	return errors.ErrUnsupported
+}
+
+func listxattr(f *File) ([]string, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func removexattr(f *File, name string) error {
+	return errors.ErrUnsupported
+}
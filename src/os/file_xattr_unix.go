@@ -0,0 +1,90 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || linux
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func getxattr(f *File, name string) (val []byte, err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		for {
+			var n int
+			if n, err = unix.Fgetxattr(int(fd), name, nil); err != nil {
+				return
+			}
+			val = make([]byte, n)
+			n, err = unix.Fgetxattr(int(fd), name, val)
+			if err == syscall.ERANGE {
+				// The value grew since its size was read.
+				continue
+			}
+			val = val[:n]
+			return
+		}
+	})
+	if cerr != nil {
+		return nil, cerr
+	}
+	return val, err
+}
+
+func setxattr(f *File, name string, value []byte) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = unix.Fsetxattr(int(fd), name, value, 0)
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+func listxattr(f *File) (names []string, err error) {
+	var buf []byte
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		for {
+			var n int
+			if n, err = unix.Flistxattr(int(fd), nil); err != nil {
+				return
+			}
+			buf = make([]byte, n)
+			n, err = unix.Flistxattr(int(fd), buf)
+			if err == syscall.ERANGE {
+				// The list grew since its size was read.
+				continue
+			}
+			buf = buf[:n]
+			return
+		}
+	})
+	if cerr != nil {
+		return nil, cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The names are each terminated by a NUL byte.
+	names = []string{}
+	for start, i := 0, 0; i < len(buf); i++ {
+		if buf[i] == 0 {
+			names = append(names, string(buf[start:i]))
+			start = i + 1
+		}
+	}
+	return names, nil
+}
+
+func removexattr(f *File, name string) (err error) {
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = unix.Fremovexattr(int(fd), name)
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+// FileAttributes is a set of Windows file attributes.
+//
+// FileAttributes is only available on Windows.
+type FileAttributes uint32
+
+// File attributes which may be read and changed with [GetFileAttributes]
+// and [SetFileAttributes].
+const (
+	FileAttributeReadOnly          FileAttributes = syscall.FILE_ATTRIBUTE_READONLY
+	FileAttributeHidden            FileAttributes = syscall.FILE_ATTRIBUTE_HIDDEN
+	FileAttributeSystem            FileAttributes = syscall.FILE_ATTRIBUTE_SYSTEM
+	FileAttributeTemporary         FileAttributes = 0x100
+	FileAttributeNotContentIndexed FileAttributes = 0x2000
+
+	fileAttributesMask = FileAttributeReadOnly | FileAttributeHidden | FileAttributeSystem |
+		FileAttributeTemporary | FileAttributeNotContentIndexed
+)
+
+var errUnsupportedFileAttributes = errors.New("unsupported file attributes")
+
+// GetFileAttributes returns the attributes of the named file.
+// Only the attributes defined by the FileAttribute constants are reported.
+// If the file is a symbolic link, GetFileAttributes reports the attributes
+// of the link itself, as [Chmod] does on Windows.
+// If there is an error, it will be of type [*PathError].
+//
+// GetFileAttributes is only available on Windows.
+func GetFileAttributes(name string) (FileAttributes, error) {
+	p, err := syscall.UTF16PtrFromString(fixLongPath(name))
+	if err != nil {
+		return 0, &PathError{Op: "getfileattributes", Path: name, Err: err}
+	}
+	attrs, err := syscall.GetFileAttributes(p)
+	if err != nil {
+		return 0, &PathError{Op: "getfileattributes", Path: name, Err: err}
+	}
+	return FileAttributes(attrs) & fileAttributesMask, nil
+}
+
+// SetFileAttributes sets the attributes of the named file to attrs.
+// Attributes defined by the FileAttribute constants are set or cleared
+// to match attrs; all other attributes of the file are left unchanged.
+// If the file is a symbolic link, SetFileAttributes changes the attributes
+// of the link itself, as [Chmod] does on Windows.
+// If there is an error, it will be of type [*PathError].
+//
+// SetFileAttributes is only available on Windows.
+func SetFileAttributes(name string, attrs FileAttributes) error {
+	if attrs&^fileAttributesMask != 0 {
+		return &PathError{Op: "setfileattributes", Path: name, Err: errUnsupportedFileAttributes}
+	}
+	p, err := syscall.UTF16PtrFromString(fixLongPath(name))
+	if err != nil {
+		return &PathError{Op: "setfileattributes", Path: name, Err: err}
+	}
+	cur, err := syscall.GetFileAttributes(p)
+	if err != nil {
+		return &PathError{Op: "setfileattributes", Path: name, Err: err}
+	}
+	if next := updateFileAttributes(cur, attrs); next != cur {
+		if err := syscall.SetFileAttributes(p, next); err != nil {
+			return &PathError{Op: "setfileattributes", Path: name, Err: err}
+		}
+	}
+	return nil
+}
+
+// GetFileAttributes returns the attributes of the named file in the root.
+// See [GetFileAttributes] for more details.
+//
+// GetFileAttributes is only available on Windows.
+func (r *Root) GetFileAttributes(name string) (FileAttributes, error) {
+	attrs, err := doInRoot(r, name, nil, func(parent syscall.Handle, name string) (FileAttributes, error) {
+		h, err := windows.Openat(parent, name, syscall.O_CLOEXEC|windows.O_OPEN_REPARSE, 0)
+		if err != nil {
+			return 0, err
+		}
+		defer syscall.CloseHandle(h)
+		var d syscall.ByHandleFileInformation
+		if err := syscall.GetFileInformationByHandle(h, &d); err != nil {
+			return 0, err
+		}
+		return FileAttributes(d.FileAttributes) & fileAttributesMask, nil
+	})
+	if err != nil {
+		return 0, &PathError{Op: "getfileattributesat", Path: name, Err: err}
+	}
+	return attrs, nil
+}
+
+// SetFileAttributes sets the attributes of the named file in the root.
+// See [SetFileAttributes] for more details.
+//
+// SetFileAttributes is only available on Windows.
+func (r *Root) SetFileAttributes(name string, attrs FileAttributes) error {
+	if err := r.checkWritable("setfileattributesat", name); err != nil {
+		return err
+	}
+	if attrs&^fileAttributesMask != 0 {
+		return &PathError{Op: "setfileattributesat", Path: name, Err: errUnsupportedFileAttributes}
+	}
+	_, err := doInRoot(r, name, nil, func(parent syscall.Handle, name string) (struct{}, error) {
+		h, err := windows.Openat(parent, name, syscall.O_CLOEXEC|windows.O_OPEN_REPARSE|windows.O_WRITE_ATTRS, 0)
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer syscall.CloseHandle(h)
+		var d syscall.ByHandleFileInformation
+		if err := syscall.GetFileInformationByHandle(h, &d); err != nil {
+			return struct{}{}, err
+		}
+		next := updateFileAttributes(d.FileAttributes, attrs)
+		if next == d.FileAttributes {
+			return struct{}{}, nil
+		}
+		var fbi windows.FILE_BASIC_INFO
+		fbi.FileAttributes = next
+		return struct{}{}, windows.SetFileInformationByHandle(h, windows.FileBasicInfo, unsafe.Pointer(&fbi), uint32(unsafe.Sizeof(fbi)))
+	})
+	if err != nil {
+		return &PathError{Op: "setfileattributesat", Path: name, Err: err}
+	}
+	return nil
+}
+
+// updateFileAttributes returns cur with the attributes in
+// fileAttributesMask replaced by those in attrs.
+func updateFileAttributes(cur uint32, attrs FileAttributes) uint32 {
+	// FILE_ATTRIBUTE_NORMAL is only valid when used alone.
+	next := cur&^uint32(fileAttributesMask|syscall.FILE_ATTRIBUTE_NORMAL) | uint32(attrs)
+	if next == 0 {
+		next = syscall.FILE_ATTRIBUTE_NORMAL
+	}
+	return next
+}
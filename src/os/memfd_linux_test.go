@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestCreateMemFile(t *testing.T) {
+	f, err := os.CreateMemFile("test")
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("CreateMemFile: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("CreateMemFile: %v", err)
+	}
+	defer f.Close()
+	if !strings.HasPrefix(f.Name(), "memfd:") {
+		t.Errorf("Name() = %q, want a memfd: prefix", f.Name())
+	}
+
+	const data = "immutable"
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	seals := os.SealWrite | os.SealShrink | os.SealGrow | os.SealSeal
+	if err := f.AddSeals(seals); err != nil {
+		t.Fatalf("AddSeals: %v", err)
+	}
+	got, err := f.Seals()
+	if err != nil {
+		t.Fatalf("Seals: %v", err)
+	}
+	if got&seals != seals {
+		t.Errorf("Seals() = %#x, want %#x set", got, seals)
+	}
+
+	if _, err := f.WriteAt([]byte("x"), 0); !errors.Is(err, syscall.EPERM) {
+		t.Errorf("WriteAt on a sealed file: %v, want EPERM", err)
+	}
+	if err := f.Truncate(0); !errors.Is(err, syscall.EPERM) {
+		t.Errorf("Truncate on a sealed file: %v, want EPERM", err)
+	}
+	if err := f.AddSeals(os.SealFutureWrite); !errors.Is(err, syscall.EPERM) {
+		t.Errorf("AddSeals after SealSeal: %v, want EPERM", err)
+	}
+	buf, err := io.ReadAll(io.NewSectionReader(f, 0, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != data {
+		t.Errorf("contents = %q, want %q", buf, data)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix || aix
+
+package os
+
+import "errors"
+
+func mknod(name string, mode FileMode, dev uint64) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix && !aix
+
+package os
+
+import "syscall"
+
+func mknod(name string, mode FileMode, dev uint64) error {
+	var typ uint32
+	switch mode.Type() {
+	case 0:
+		typ = syscall.S_IFREG
+	case ModeDevice:
+		typ = syscall.S_IFBLK
+	case ModeDevice | ModeCharDevice:
+		typ = syscall.S_IFCHR
+	case ModeNamedPipe:
+		typ = syscall.S_IFIFO
+	case ModeSocket:
+		typ = syscall.S_IFSOCK
+	default:
+		return syscall.EINVAL
+	}
+	return ignoringEINTR(func() error {
+		return sysMknod(name, typ|syscallMode(mode), dev)
+	})
+}
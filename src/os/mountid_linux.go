@@ -0,0 +1,89 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+)
+
+// MountID returns the ID of the mount containing the file,
+// as reported by statx(2).
+//
+// When the kernel supports it (Linux 6.8 and later), MountID returns
+// the unique mount ID, which is never reused. Otherwise it returns the
+// mount ID shown in /proc/self/mountinfo, which may be reused once
+// the mount is unmounted. Kernels before Linux 5.8 report neither,
+// and MountID returns an error wrapping [errors.ErrUnsupported].
+//
+// MountID is only available on Linux.
+func (f *File) MountID() (uint64, error) {
+	if err := f.checkValid("mountid"); err != nil {
+		return 0, err
+	}
+	var id uint64
+	var err error
+	if cerr := f.pfd.RawControl(func(fd uintptr) {
+		id, err = fdMountID(int(fd))
+	}); cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, f.wrapErr("mountid", err)
+	}
+	return id, nil
+}
+
+// MountID returns the ID of the mount containing the root directory.
+// See [File.MountID] for more details.
+//
+// MountID is only available on Linux.
+func (r *Root) MountID() (uint64, error) {
+	if err := r.root.incref(); err != nil {
+		return 0, &PathError{Op: "mountid", Path: r.Name(), Err: err}
+	}
+	defer r.root.decref()
+	id, err := fdMountID(r.root.fd)
+	if err != nil {
+		return 0, &PathError{Op: "mountid", Path: r.Name(), Err: err}
+	}
+	return id, nil
+}
+
+// SameMount reports whether f, typically a file opened within the root,
+// is on the same mount as the root directory.
+//
+// SameMount lets callers detect after the fact that opening a file
+// crossed a mount point, such as a bind mount, on kernels where
+// [Root.OpenFileWithResolve] and [ResolveNoXdev] are unavailable.
+//
+// SameMount is only available on Linux.
+func (r *Root) SameMount(f *File) (bool, error) {
+	rid, err := r.MountID()
+	if err != nil {
+		return false, err
+	}
+	fid, err := f.MountID()
+	if err != nil {
+		return false, err
+	}
+	return rid == fid, nil
+}
+
+// fdMountID returns the mount ID of the open file fd.
+func fdMountID(fd int) (uint64, error) {
+	var stx unix.Statx_t
+	err := ignoringEINTR(func() error {
+		return unix.Statx(fd, "", unix.AT_EMPTY_PATH, unix.STATX_MNT_ID|unix.STATX_MNT_ID_UNIQUE, &stx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if stx.Mask&(unix.STATX_MNT_ID|unix.STATX_MNT_ID_UNIQUE) == 0 {
+		// Linux before 5.8.
+		return 0, errors.ErrUnsupported
+	}
+	return stx.MntID, nil
+}
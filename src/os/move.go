@@ -0,0 +1,144 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/filepathlite"
+	"io"
+)
+
+// Move moves oldpath to newpath, like [Rename], but also works when they
+// are on different file systems, where Rename fails. In that case, Move
+// copies oldpath, which may be a file, a symbolic link, or a directory
+// tree, to a temporary name in the directory of newpath, commits the copy
+// to stable storage, renames it to newpath, and then removes oldpath.
+// The copies keep the permission bits and modification times of the
+// originals, but not their owners or extended attributes.
+//
+// A copy appears at newpath complete or not at all, but if Move fails
+// after renaming it there, both oldpath and newpath may exist.
+// Directories may only contain regular files, symbolic links, and
+// directories.
+// If there is an error, it will be of type [*LinkError].
+func Move(oldpath, newpath string) error {
+	err := Rename(oldpath, newpath)
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+	if err := moveByCopy(oldpath, newpath); err != nil {
+		return &LinkError{"move", oldpath, newpath, err}
+	}
+	return nil
+}
+
+// moveByCopy implements Move when oldpath cannot be renamed to newpath.
+func moveByCopy(oldpath, newpath string) error {
+	fi, err := Lstat(oldpath)
+	if err != nil {
+		return err
+	}
+	// Copy oldpath into a temporary directory beside newpath, so that
+	// a single rename moves the complete copy into place.
+	dir := filepathlite.Dir(newpath)
+	tmpdir, err := MkdirTemp(dir, ".move-*")
+	if err != nil {
+		return err
+	}
+	defer RemoveAll(tmpdir)
+	tmp := joinPath(tmpdir, filepathlite.Base(newpath))
+	if err := moveCopy(oldpath, tmp, fi); err != nil {
+		return err
+	}
+	if err := Rename(tmp, newpath); err != nil {
+		return err
+	}
+	// Commit the new name. Some systems cannot sync a directory,
+	// so this is only best effort.
+	if d, err := Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return RemoveAll(oldpath)
+}
+
+// moveCopy copies src, with FileInfo fi, to the new name dst.
+func moveCopy(src, dst string, fi FileInfo) error {
+	switch mode := fi.Mode(); {
+	case mode.IsRegular():
+		return moveCopyFile(src, dst, fi)
+	case mode&ModeSymlink != 0:
+		target, err := Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := Symlink(target, dst); err != nil {
+			return err
+		}
+		if err := Lchtimes(dst, atime(fi), fi.ModTime()); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+		return nil
+	case mode.IsDir():
+		if err := Mkdir(dst, 0o700); err != nil {
+			return err
+		}
+		entries, err := ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				return err
+			}
+			if err := moveCopy(joinPath(src, e.Name()), joinPath(dst, e.Name()), info); err != nil {
+				return err
+			}
+		}
+		// Set the mode and times last, as creating the
+		// entries needs write permission and changes the times.
+		if err := Chmod(dst, mode); err != nil {
+			return err
+		}
+		return Chtimes(dst, atime(fi), fi.ModTime())
+	default:
+		return &PathError{Op: "move", Path: src, Err: errors.ErrUnsupported}
+	}
+}
+
+// moveCopyFile copies the regular file src, with FileInfo fi,
+// to the new file dst, and commits it to stable storage.
+func moveCopyFile(src, dst string, fi FileInfo) error {
+	sf, err := Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	df, err := OpenFile(dst, O_WRONLY|O_CREATE|O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer df.Close()
+	if df.Clone(sf) != nil {
+		if _, err := io.Copy(df, sf); err != nil {
+			return err
+		}
+	}
+	if err := df.Chmod(fi.Mode()); err != nil {
+		return err
+	}
+	err = df.Chtimes(atime(fi), fi.ModTime())
+	if errors.Is(err, errors.ErrUnsupported) {
+		err = Chtimes(dst, atime(fi), fi.ModTime())
+	}
+	if err != nil {
+		return err
+	}
+	if err := df.Sync(); err != nil {
+		return err
+	}
+	return df.Close()
+}
@@ -0,0 +1,12 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "errors"
+
+func isCrossDevice(err error) bool {
+	// Plan 9 cannot rename a file into another directory.
+	return errors.Is(err, ErrInvalid)
+}
@@ -6,6 +6,7 @@ package os_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"maps"
 	. "os"
 	"os/exec"
 	"path/filepath"
@@ -490,6 +492,101 @@ func TestFileReadDir(t *testing.T) {
 	t.Run("TempDir", testReadDir(t.TempDir(), nil))
 }
 
+func TestFileDirEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	const n = 1000 // more than one batch
+	for i := range n {
+		if err := WriteFile(filepath.Join(dir, strconv.Itoa(i)), nil, 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	seen := make(map[string]bool)
+	for d, err := range f.DirEntries() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[d.Name()] {
+			t.Errorf("DirEntries yielded %q twice", d.Name())
+		}
+		seen[d.Name()] = true
+	}
+	if len(seen) != n {
+		t.Errorf("DirEntries yielded %d entries, want %d", len(seen), n)
+	}
+
+	count := 0
+	for _, err := range ReadDirSeq(dir) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count++; count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Errorf("ReadDirSeq yielded %d entries before stopping, want 10", count)
+	}
+
+	for _, err := range ReadDirSeq(filepath.Join(dir, "missing")) {
+		if !IsNotExist(err) {
+			t.Errorf("ReadDirSeq of a missing directory yielded %v, want ErrNotExist", err)
+		}
+	}
+}
+
+func TestFileSetReadDirBufferSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	const n = 500
+	for i := range n {
+		if err := WriteFile(filepath.Join(dir, strconv.Itoa(i)), nil, 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, size := range []int{0, 1, 1 << 20} {
+		f, err := Open(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetReadDirBufferSize(size); err != nil {
+			t.Fatalf("SetReadDirBufferSize(%d): %v", size, err)
+		}
+		first, err := f.ReadDir(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Changing the size while entries are buffered loses none of them.
+		if err := f.SetReadDirBufferSize(2 * size); err != nil {
+			t.Fatalf("SetReadDirBufferSize(%d): %v", 2*size, err)
+		}
+		rest, err := f.ReadDir(-1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if got := len(first) + len(rest); got != n {
+			t.Errorf("with buffer size %d, read %d entries, want %d", size, got, n)
+		}
+	}
+
+	f, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.SetReadDirBufferSize(-1); err == nil {
+		t.Errorf("SetReadDirBufferSize(-1) succeeded")
+	}
+}
+
 func benchmarkReaddirname(path string, b *testing.B) {
 	var nentries int
 	for i := 0; i < b.N; i++ {
@@ -1081,6 +1178,145 @@ func TestRenameOverwriteDest(t *testing.T) {
 	}
 }
 
+func TestRenameNoReplace(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "renamefrom"), filepath.Join(dir, "renameto")
+	if err := WriteFile(from, []byte("from"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(to, []byte("to"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RenameNoReplace(from, to)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("RenameNoReplace is not supported: %v", err)
+	}
+	if !errors.Is(err, ErrExist) {
+		t.Errorf("RenameNoReplace to existing file = %v, want ErrExist", err)
+	}
+	if _, ok := err.(*LinkError); !ok {
+		t.Errorf("RenameNoReplace error is %T, want *LinkError", err)
+	}
+	if data, err := ReadFile(to); err != nil || string(data) != "to" {
+		t.Errorf("destination after failed RenameNoReplace = %q, %v; want %q", data, err, "to")
+	}
+
+	if err := Remove(to); err != nil {
+		t.Fatal(err)
+	}
+	if err := RenameNoReplace(from, to); err != nil {
+		t.Fatalf("RenameNoReplace(%q, %q) = %v", from, to, err)
+	}
+	if _, err := Lstat(from); !IsNotExist(err) {
+		t.Errorf("source after RenameNoReplace: %v, want not-exist error", err)
+	}
+	if data, err := ReadFile(to); err != nil || string(data) != "from" {
+		t.Errorf("destination after RenameNoReplace = %q, %v; want %q", data, err, "from")
+	}
+}
+
+func TestRenameExchange(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := WriteFile(a, []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Mkdir(b, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RenameExchange(a, b); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("RenameExchange is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("RenameExchange(%q, %q) = %v", a, b, err)
+	}
+	if fi, err := Lstat(a); err != nil || !fi.IsDir() {
+		t.Errorf("after RenameExchange, %q is not a directory: %v", a, err)
+	}
+	if data, err := ReadFile(b); err != nil || string(data) != "a" {
+		t.Errorf("after RenameExchange, %q = %q, %v; want %q", b, data, err, "a")
+	}
+
+	if err := RenameExchange(a, filepath.Join(dir, "missing")); !IsNotExist(err) {
+		t.Errorf("RenameExchange with missing file = %v, want not-exist error", err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from"), filepath.Join(dir, "to")
+	if err := WriteFile(from, []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Move(from, to); err != nil {
+		t.Fatalf("Move(%q, %q) = %v", from, to, err)
+	}
+	if _, err := Lstat(from); !IsNotExist(err) {
+		t.Errorf("source after Move: %v, want not-exist error", err)
+	}
+	if data, err := ReadFile(to); err != nil || string(data) != "data" {
+		t.Errorf("destination after Move = %q, %v; want %q", data, err, "data")
+	}
+	if err := Move(from, to); !IsNotExist(err) {
+		t.Errorf("Move of missing file = %v, want not-exist error", err)
+	}
+}
+
+func TestMoveByCopy(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from"), filepath.Join(dir, "to")
+	mtime := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	if err := MkdirAll(filepath.Join(from, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(from, "sub", "file")
+	if err := WriteFile(file, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Chtimes(file, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	if testenv.HasSymlink() {
+		if err := Symlink("sub/file", filepath.Join(from, "link")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := MoveByCopy(from, to); err != nil {
+		t.Fatalf("moveByCopy(%q, %q) = %v", from, to, err)
+	}
+	if _, err := Lstat(from); !IsNotExist(err) {
+		t.Errorf("source after moveByCopy: %v, want not-exist error", err)
+	}
+	fi, err := Stat(filepath.Join(to, "sub", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.ModTime().Equal(mtime) {
+		t.Errorf("ModTime of copied file = %v, want %v", fi.ModTime(), mtime)
+	}
+	if runtime.GOOS != "windows" && runtime.GOOS != "plan9" && fi.Mode().Perm() != 0o600 {
+		t.Errorf("mode of copied file = %v, want 0o600", fi.Mode())
+	}
+	if testenv.HasSymlink() {
+		if data, err := ReadFile(filepath.Join(to, "link")); err != nil || string(data) != "data" {
+			t.Errorf("reading copied link = %q, %v; want %q", data, err, "data")
+		}
+	}
+	// The temporary directory is removed.
+	entries, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "to" {
+		t.Errorf("entries after moveByCopy = %v, want only %q", entries, "to")
+	}
+}
+
 func TestRenameFailed(t *testing.T) {
 	t.Chdir(t.TempDir())
 	from, to := "renamefrom", "renameto"
@@ -1581,6 +1817,151 @@ func TestChtimesToUnixZero(t *testing.T) {
 	}
 }
 
+func TestLchtimes(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+	t.Parallel()
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "link")
+	if err := WriteFile(target, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+	targetSt, err := Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	if err := Lchtimes(link, time.Time{}, mtime); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Lchtimes is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Lchtimes(%q) = %v", link, err)
+	}
+
+	linkSt, err := Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !linkSt.ModTime().Equal(mtime) {
+		t.Errorf("link ModTime = %v, want %v", linkSt.ModTime(), mtime)
+	}
+	st, err := Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.ModTime().Equal(targetSt.ModTime()) {
+		t.Errorf("target ModTime changed from %v to %v", targetSt.ModTime(), st.ModTime())
+	}
+
+	if err := Lchtimes(filepath.Join(dir, "missing"), mtime, mtime); !IsNotExist(err) {
+		t.Errorf("Lchtimes of missing file = %v, want not-exist error", err)
+	}
+}
+
+func TestLchmod(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+	t.Parallel()
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "link")
+	if err := WriteFile(target, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Chmod(target, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Lchmod(link, 0o700); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Lchmod is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Lchmod(%q) = %v", link, err)
+	}
+	if fi, err := Lstat(link); err != nil {
+		t.Fatal(err)
+	} else if fi.Mode()&ModeSymlink == 0 || fi.Mode().Perm() != 0o700 {
+		t.Errorf("link mode = %v, want symlink with 0o700", fi.Mode())
+	}
+	if fi, err := Stat(target); err != nil {
+		t.Fatal(err)
+	} else if fi.Mode().Perm() != 0o644 {
+		t.Errorf("target mode = %v, want 0o644", fi.Mode())
+	}
+}
+
+func TestMkfifo(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "fifo")
+	if err := Mkfifo(name, 0o600); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Mkfifo is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Mkfifo(%q) = %v", name, err)
+	}
+	fi, err := Lstat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode()&ModeNamedPipe == 0 || fi.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want named pipe with 0o600", fi.Mode())
+	}
+	if err := Mkfifo(name, 0o600); !IsExist(err) {
+		t.Errorf("second Mkfifo(%q) = %v, want exist error", name, err)
+	}
+}
+
+func TestMkdev(t *testing.T) {
+	for _, tt := range []struct{ major, minor uint32 }{
+		{0, 0},
+		{8, 1},
+		{200, 0x10005},
+	} {
+		dev := Mkdev(tt.major, tt.minor)
+		if major, minor := Major(dev), Minor(dev); major != tt.major || minor != tt.minor {
+			t.Errorf("Major, Minor of Mkdev(%d, %d) = %d, %d", tt.major, tt.minor, major, minor)
+		}
+	}
+}
+
+func TestMknod(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	fifo := filepath.Join(dir, "fifo")
+	if err := Mknod(fifo, ModeNamedPipe|0o600, 0); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Mknod is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Mknod(%q) = %v", fifo, err)
+	}
+	if fi, err := Lstat(fifo); err != nil {
+		t.Fatal(err)
+	} else if fi.Mode() != ModeNamedPipe|0o600 {
+		t.Errorf("mode = %v, want %v", fi.Mode(), ModeNamedPipe|0o600)
+	}
+	if err := Mknod(filepath.Join(dir, "dir"), ModeDir|0o700, 0); err == nil {
+		t.Errorf("Mknod with ModeDir succeeded, want error")
+	}
+
+	if Getuid() != 0 {
+		return
+	}
+	// Create a device with the numbers of /dev/null on Linux.
+	null := filepath.Join(dir, "null")
+	if err := Mknod(null, ModeDevice|ModeCharDevice|0o666, Mkdev(1, 3)); err != nil {
+		t.Skipf("Mknod of a device = %v; may be forbidden in a container", err)
+	}
+	fi, err := Lstat(null)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Type() != ModeDevice|ModeCharDevice {
+		t.Errorf("mode = %v, want character device", fi.Mode())
+	}
+}
+
 func TestFileChdir(t *testing.T) {
 	wd, err := Getwd()
 	if err != nil {
@@ -1620,6 +2001,51 @@ func TestFileChdir(t *testing.T) {
 	}
 }
 
+func TestFileChdirRenamed(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9", "wasip1":
+		t.Skipf("File.Chdir uses the file's name on %s", runtime.GOOS)
+	}
+	t.Chdir(".") // Ensure wd is restored after the test.
+
+	dir := t.TempDir()
+	oldName := filepath.Join(dir, "old")
+	newName := filepath.Join(dir, "new")
+	if err := Mkdir(oldName, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	// On Windows, the directory can be renamed only if it was opened
+	// with FILE_SHARE_DELETE.
+	const shareAll = 0x1 | 0x2 | 0x4 // FILE_SHARE_READ | FILE_SHARE_WRITE | FILE_SHARE_DELETE
+	f, err := OpenFileWithOptions(oldName, O_RDONLY, 0, &OpenFileOptions{WindowsShareMode: shareAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := Rename(oldName, newName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Chdir(); err != nil {
+		t.Fatalf("Chdir to renamed directory: %v", err)
+	}
+	wd, err := Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wdInfo, err := Stat(wd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newInfo, err := Stat(newName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !SameFile(wdInfo, newInfo) {
+		t.Errorf("after Chdir to renamed directory, Getwd = %s, want %s", wd, newName)
+	}
+}
+
 func TestChdirAndGetwd(t *testing.T) {
 	t.Chdir(t.TempDir()) // Ensure wd is restored after the test.
 
@@ -1909,6 +2335,29 @@ func TestOpenNoName(t *testing.T) {
 	}
 }
 
+func TestOpenFileWithOptionsZero(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "file")
+	for _, opts := range []*OpenFileOptions{nil, {}} {
+		f, err := OpenFileWithOptions(name, O_RDWR|O_CREATE|O_APPEND, 0666, opts)
+		if err != nil {
+			t.Fatalf("OpenFileWithOptions(%v): %v", opts, err)
+		}
+		if _, err := f.WriteString("x"); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+	b, err := ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "xx"; got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+}
+
 func runBinHostname(t *testing.T) string {
 	// Run /bin/hostname and collect output.
 	r, w, err := Pipe()
@@ -2007,20 +2456,59 @@ func TestHostname(t *testing.T) {
 	}
 }
 
-func TestReadAt(t *testing.T) {
-	t.Parallel()
-
-	f := newFile(t)
-
-	const data = "hello, world\n"
-	io.WriteString(f, data)
-
-	b := make([]byte, 5)
-	n, err := f.ReadAt(b, 7)
-	if err != nil || n != len(b) {
-		t.Fatalf("ReadAt 7: %d, %v", n, err)
+func TestSethostname(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Sethostname is tested only on Linux, where setting the host name to its current value is harmless")
 	}
-	if string(b) != "world" {
+	hostname, err := Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Sethostname(hostname); err != nil && !IsPermission(err) {
+		t.Errorf("Sethostname(%q) = %v", hostname, err)
+	}
+
+	// A name longer than the kernel allows is rejected,
+	// and the host name is unchanged.
+	err = Sethostname(strings.Repeat("x", 1000))
+	if err == nil {
+		t.Fatalf("Sethostname with a long name succeeded")
+	}
+	if _, ok := err.(*SyscallError); !ok {
+		t.Errorf("Sethostname error is %T, want *SyscallError", err)
+	}
+	if got, err := Hostname(); err != nil || got != hostname {
+		t.Errorf("after failed Sethostname, Hostname() = %q, %v; want %q", got, err, hostname)
+	}
+}
+
+func TestHostnameFQDN(t *testing.T) {
+	t.Parallel()
+
+	name, err := HostnameFQDN()
+	if err != nil {
+		// Many hosts, such as CI containers, have no domain name.
+		t.Skipf("skipping: %v", err)
+	}
+	if !strings.Contains(name, ".") {
+		t.Errorf("HostnameFQDN() = %q; want a name containing a dot", name)
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	t.Parallel()
+
+	f := newFile(t)
+
+	const data = "hello, world\n"
+	io.WriteString(f, data)
+
+	b := make([]byte, 5)
+	n, err := f.ReadAt(b, 7)
+	if err != nil || n != len(b) {
+		t.Fatalf("ReadAt 7: %d, %v", n, err)
+	}
+	if string(b) != "world" {
 		t.Fatalf("ReadAt 7: have %q want %q", string(b), "world")
 	}
 }
@@ -2454,6 +2942,143 @@ func TestSameFile(t *testing.T) {
 	}
 }
 
+func TestSameFilesystem(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	if err := WriteFile(a, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ia, err := Stat(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isub, err := Stat(filepath.Join(dir, "sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !SameFilesystem(ia, isub) {
+		t.Errorf("SameFilesystem(%q, %q) = false; want true", a, "sub")
+	}
+	if SameFilesystem(ia, nil) {
+		t.Errorf("SameFilesystem with a nil FileInfo = true; want false")
+	}
+
+	same, err := SameFilesystemPath(a, dir)
+	if err != nil || !same {
+		t.Errorf("SameFilesystemPath(%q, %q) = %v, %v; want true, nil", a, dir, same, err)
+	}
+	if _, err := SameFilesystemPath(a, filepath.Join(dir, "missing")); !IsNotExist(err) {
+		t.Errorf("SameFilesystemPath with a missing file: error %v; want not exist", err)
+	}
+
+	if runtime.GOOS == "linux" {
+		// /proc is always a different file system.
+		if same, err := SameFilesystemPath(a, "/proc/self"); err == nil && same {
+			t.Errorf("SameFilesystemPath(%q, /proc/self) = true; want false", a)
+		}
+	}
+}
+
+func TestFileBirthTime(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "born")
+	before := time.Now().Add(-time.Minute)
+	if err := WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := FileBirthTime(fs.FileInfo(nil)); ok {
+		t.Errorf("FileBirthTime(nil) reported a birth time")
+	}
+	bt, ok := FileBirthTime(fi)
+	if !ok {
+		t.Skipf("birth time is not available on %s", runtime.GOOS)
+	}
+	if after := time.Now().Add(time.Minute); bt.Before(before) || bt.After(after) {
+		t.Errorf("FileBirthTime = %v, want between %v and %v", bt, before, after)
+	}
+}
+
+func TestStatfs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	info, err := Statfs(dir)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Statfs is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Statfs(%q) = %v", dir, err)
+	}
+	t.Logf("Statfs(%q) = %+v", dir, info)
+	if info.Total == 0 || info.BlockSize <= 0 || info.MaxNameLen <= 0 {
+		t.Errorf("Statfs(%q) = %+v, want nonzero Total, BlockSize and MaxNameLen", dir, info)
+	}
+	if info.Free > info.Total || info.Available > info.Total {
+		t.Errorf("Statfs(%q) = %+v, free space exceeds Total", dir, info)
+	}
+	if info.ReadOnly {
+		t.Errorf("Statfs(%q) reports a read-only file system", dir)
+	}
+
+	// A file reports the same file system as its directory.
+	name := filepath.Join(dir, "file")
+	if err := WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if finfo, err := Statfs(name); err != nil {
+		t.Errorf("Statfs(%q) = %v", name, err)
+	} else if finfo.Total != info.Total || finfo.BlockSize != info.BlockSize {
+		t.Errorf("Statfs(%q) = %+v, want same file system as %+v", name, finfo, info)
+	}
+
+	if _, err := Statfs(filepath.Join(dir, "missing")); !IsNotExist(err) {
+		t.Errorf("Statfs of missing file = %v, want not-exist error", err)
+	}
+}
+
+func TestAccess(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file")
+	if err := WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []AccessMode{0, AccessRead, AccessWrite, AccessRead | AccessWrite} {
+		if err := Access(name, mode); err != nil {
+			t.Errorf("Access(%q, %v) = %v", name, mode, err)
+		}
+	}
+	if err := Access(dir, AccessRead|AccessWrite|AccessExecute); err != nil {
+		t.Errorf("Access(%q, AccessRead|AccessWrite|AccessExecute) = %v", dir, err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := Access(name, AccessExecute); !IsPermission(err) {
+			t.Errorf("Access(%q, AccessExecute) = %v, want permission error", name, err)
+		}
+	}
+	if Getuid() != 0 {
+		if err := Chmod(name, 0o444); err != nil {
+			t.Fatal(err)
+		}
+		if err := Access(name, AccessWrite); !IsPermission(err) {
+			t.Errorf("Access(%q, AccessWrite) on read-only file = %v, want permission error", name, err)
+		}
+	}
+
+	if err := Access(filepath.Join(dir, "missing"), 0); !IsNotExist(err) {
+		t.Errorf("Access of missing file = %v, want not-exist error", err)
+	}
+	if err := Access(name, 8); err == nil {
+		t.Errorf("Access(%q, 8) succeeded, want error", name)
+	}
+}
+
 func testDevNullFileInfo(t *testing.T, statname, devNullName string, fi FileInfo) {
 	pre := fmt.Sprintf("%s(%q): ", statname, devNullName)
 	if fi.Size() != 0 {
@@ -2801,6 +3426,85 @@ func TestGetppid(t *testing.T) {
 	}
 }
 
+func TestGettid(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	tid := Gettid()
+	if tid == -1 {
+		t.Skipf("Gettid not supported on %s", runtime.GOOS)
+	}
+	if tid <= 0 {
+		t.Fatalf("Gettid() = %d; want positive ID", tid)
+	}
+	if tid2 := Gettid(); tid2 != tid {
+		t.Errorf("Gettid() = %d, then %d on the same thread", tid, tid2)
+	}
+
+	// A goroutine locked to another thread has a different ID.
+	c := make(chan int)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		c <- Gettid()
+		<-c
+	}()
+	other := <-c
+	c <- 0
+	if other == tid {
+		t.Errorf("Gettid() = %d on two different threads", tid)
+	}
+}
+
+func TestWaitContext(t *testing.T) {
+	if runtime.GOOS == "plan9" {
+		t.Skip("WaitContext is not supported on plan9")
+	}
+	testenv.MustHaveExec(t)
+	t.Parallel()
+
+	// Start a process that hangs until stdin is closed.
+	r, w, err := Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	p, err := StartProcess(testenv.Executable(t), []string{"os.test"}, &ProcAttr{
+		Env:   append(Environ(), "GO_OS_TEST_DRAIN_STDIN=1"),
+		Files: []*File{r, Stdout, Stderr},
+	})
+	r.Close()
+	if err != nil {
+		t.Fatalf("Failed to start test process: %v", err)
+	}
+	defer p.Kill()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.WaitContext(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("WaitContext with expired deadline: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	ctx, cancel = context.WithCancel(t.Context())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if _, err := p.WaitContext(ctx); err != context.Canceled {
+		t.Fatalf("WaitContext with canceled context: got %v, want %v", err, context.Canceled)
+	}
+
+	// Closing stdin lets the process exit.
+	w.Close()
+	ps, err := p.WaitContext(t.Context())
+	if err != nil {
+		t.Fatalf("WaitContext: %v", err)
+	}
+	if !ps.Success() {
+		t.Errorf("test process failed: %v", ps)
+	}
+	if ps.Pid() != p.Pid {
+		t.Errorf("ProcessState.Pid() = %d, want %d", ps.Pid(), p.Pid)
+	}
+}
+
 func TestKillFindProcess(t *testing.T) {
 	testKillProcess(t, func(p *Process) {
 		p2, err := FindProcess(p.Pid)
@@ -3098,6 +3802,183 @@ func TestUserConfigDirXDGConfigDirEnvVar(t *testing.T) {
 	}
 }
 
+func TestUserStateDir(t *testing.T) {
+	t.Parallel()
+
+	dir, err := UserStateDir()
+	if err != nil {
+		t.Skipf("skipping: %v", err)
+	}
+	if dir == "" {
+		t.Fatalf("UserStateDir returned %q; want non-empty path or error", dir)
+	}
+
+	fi, err := Stat(dir)
+	if err != nil {
+		if IsNotExist(err) {
+			t.Log(err)
+			return
+		}
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Fatalf("dir %s is not directory; type = %v", dir, fi.Mode())
+	}
+}
+
+func TestUserStateDirXDGStateDirEnvVar(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		t.Skip("$XDG_STATE_HOME is effective only on Unix systems")
+	}
+
+	wd, err := Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_STATE_HOME", wd)
+
+	dir, err := UserStateDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != wd {
+		t.Fatalf("UserStateDir returned %q; want the value of $XDG_STATE_HOME %q", dir, wd)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "some-dir")
+	_, err = UserStateDir()
+	if err == nil {
+		t.Fatal("UserStateDir succeeded though $XDG_STATE_HOME contains a relative path")
+	}
+}
+
+func TestUserRuntimeDir(t *testing.T) {
+	t.Parallel()
+
+	dir, err := UserRuntimeDir()
+	if err != nil {
+		t.Skipf("skipping: %v", err)
+	}
+	if dir == "" {
+		t.Fatalf("UserRuntimeDir returned %q; want non-empty path or error", dir)
+	}
+
+	fi, err := Stat(dir)
+	if err != nil {
+		if IsNotExist(err) {
+			t.Log(err)
+			return
+		}
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Fatalf("dir %s is not directory; type = %v", dir, fi.Mode())
+	}
+}
+
+func TestUserRuntimeDirXDGRuntimeDirEnvVar(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		t.Skip("$XDG_RUNTIME_DIR is effective only on Unix systems")
+	}
+
+	wd, err := Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", wd)
+
+	dir, err := UserRuntimeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != wd {
+		t.Fatalf("UserRuntimeDir returned %q; want the value of $XDG_RUNTIME_DIR %q", dir, wd)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "some-dir")
+	if _, err := UserRuntimeDir(); err == nil {
+		t.Fatal("UserRuntimeDir succeeded though $XDG_RUNTIME_DIR contains a relative path")
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if _, err := UserRuntimeDir(); err == nil {
+		t.Fatal("UserRuntimeDir succeeded though $XDG_RUNTIME_DIR is empty")
+	}
+}
+
+func TestUserDir(t *testing.T) {
+	t.Parallel()
+
+	for kind := UserDirDesktop; kind <= UserDirPublic; kind++ {
+		dir, err := UserDir(kind)
+		if errors.Is(err, errors.ErrUnsupported) {
+			t.Logf("UserDir(%d): %v", kind, err)
+			continue
+		}
+		if err != nil {
+			t.Skipf("skipping: %v", err)
+		}
+		if !filepath.IsAbs(dir) {
+			t.Errorf("UserDir(%d) = %q; want absolute path", kind, dir)
+		}
+	}
+
+	if _, err := UserDir(UserDirPublic + 1); err == nil {
+		t.Error("UserDir succeeded with an invalid kind")
+	}
+}
+
+func TestUserDirXDGUserDirs(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		t.Skip("user-dirs.dirs is effective only on Unix systems")
+	}
+
+	home := t.TempDir()
+	config := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", config)
+
+	for kind, want := range map[UserDirKind]string{
+		UserDirDownloads: home + "/Downloads",
+		UserDirPublic:    home + "/Public",
+	} {
+		if dir, err := UserDir(kind); err != nil || dir != want {
+			t.Errorf("without user-dirs.dirs: UserDir(%d) = %q, %v; want %q", kind, dir, err, want)
+		}
+	}
+
+	dirs := `# This file is written by xdg-user-dirs-update
+XDG_DESKTOP_DIR="$HOME/Bureau"
+XDG_DOWNLOAD_DIR="$HOME/T\"l\\chargements/"
+XDG_DOCUMENTS_DIR="/srv/docs"
+XDG_MUSIC_DIR="$HOME"
+XDG_PICTURES_DIR=relative
+XDG_VIDEOS_DIR="$HOME/old"
+XDG_VIDEOS_DIR="$HOME/Vidéos"
+XDG_TEMPLATES_DIR="$HOMEtemplates"
+`
+	if err := WriteFile(filepath.Join(config, "user-dirs.dirs"), []byte(dirs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for kind, want := range map[UserDirKind]string{
+		UserDirDesktop:   home + "/Bureau",
+		UserDirDownloads: home + `/T"l\chargements`,
+		UserDirDocuments: "/srv/docs",
+		UserDirMusic:     home,
+		UserDirPictures:  home + "/Pictures",
+		UserDirVideos:    home + "/Vidéos",
+		UserDirTemplates: home + "/Templates",
+		UserDirPublic:    home + "/Public",
+	} {
+		if dir, err := UserDir(kind); err != nil || dir != want {
+			t.Errorf("UserDir(%d) = %q, %v; want %q", kind, dir, err, want)
+		}
+	}
+}
+
 func TestUserHomeDir(t *testing.T) {
 	t.Parallel()
 
@@ -3305,19 +4186,60 @@ func TestRootDirFS(t *testing.T) {
 	testDirFS(t, r.FS())
 }
 
-func testDirFS(t *testing.T, fsys fs.FS) {
-	forceMFTUpdateOnWindows(t, "./testdata/dirfs")
+func TestDirFSRooted(t *testing.T) {
+	t.Parallel()
+	testDirFS(t, DirFSRooted("./testdata/dirfs"))
 
-	if err := fstest.TestFS(fsys, "a", "b", "dir/x"); err != nil {
-		t.Fatal(err)
+	if _, err := DirFSRooted("").Open("."); err == nil {
+		t.Errorf(`DirFSRooted("").Open(".") succeeded`)
 	}
+}
 
-	rdfs, ok := fsys.(fs.ReadDirFS)
-	if !ok {
-		t.Error("expected DirFS result to implement fs.ReadDirFS")
+func TestDirFSRootedEscape(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := Mkdir(filepath.Join(dir, "tree"), 0o777); err != nil {
+		t.Fatal(err)
 	}
-	if _, err := rdfs.ReadDir("nonexistent"); err == nil {
-		t.Error("fs.ReadDir of nonexistent directory succeeded")
+	if err := WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := Symlink(filepath.Join("..", "secret"), filepath.Join(dir, "tree", "link")); err != nil {
+		t.Fatal(err)
+	}
+	forceMFTUpdateOnWindows(t, dir)
+
+	fsys := DirFSRooted(filepath.Join(dir, "tree"))
+	if f, err := fsys.Open("link"); err == nil {
+		f.Close()
+		t.Errorf("Open of a link out of the tree succeeded")
+	}
+	if _, err := fs.ReadFile(fsys, "link"); err == nil {
+		t.Errorf("ReadFile of a link out of the tree succeeded")
+	}
+	if _, err := fs.Stat(fsys, "link"); err == nil {
+		t.Errorf("Stat of a link out of the tree succeeded")
+	}
+	if target, err := fs.ReadLink(fsys, "link"); err != nil || target != filepath.Join("..", "secret") {
+		t.Errorf("ReadLink(link) = %q, %v; want %q", target, err, filepath.Join("..", "secret"))
+	}
+}
+
+func testDirFS(t *testing.T, fsys fs.FS) {
+	forceMFTUpdateOnWindows(t, "./testdata/dirfs")
+
+	if err := fstest.TestFS(fsys, "a", "b", "dir/x"); err != nil {
+		t.Fatal(err)
+	}
+
+	rdfs, ok := fsys.(fs.ReadDirFS)
+	if !ok {
+		t.Error("expected DirFS result to implement fs.ReadDirFS")
+	}
+	if _, err := rdfs.ReadDir("nonexistent"); err == nil {
+		t.Error("fs.ReadDir of nonexistent directory succeeded")
 	}
 
 	// Test that the error message does not contain a backslash,
@@ -3758,6 +4680,341 @@ func verifyCopyFS(t *testing.T, originFS, copiedFS fs.FS) error {
 	})
 }
 
+func TestCopyFileFunc(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	if err := WriteFile(src, []byte("contents"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	for _, opts := range []*CopyFileOptions{nil, {DataOnly: true}} {
+		dst := filepath.Join(dir, fmt.Sprintf("dst-%v", opts != nil))
+		if err := CopyFile(src, dst, opts); err != nil {
+			t.Fatalf("CopyFile(%v): %v", opts, err)
+		}
+		got, err := ReadFile(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "contents" {
+			t.Errorf("CopyFile(%v): dst contains %q, want %q", opts, got, "contents")
+		}
+		if runtime.GOOS != "windows" && runtime.GOOS != "plan9" && runtime.GOOS != "js" && runtime.GOOS != "wasip1" {
+			fi, err := Stat(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := fi.Mode().Perm(), FileMode(0o640); got&^0o022 != want&^0o022 {
+				t.Errorf("CopyFile(%v): dst mode %v, want %v", opts, got, want)
+			}
+		}
+
+		// Copying over an existing file fails.
+		if err := CopyFile(src, dst, opts); !errors.Is(err, fs.ErrExist) {
+			t.Errorf("CopyFile(%v) to existing file: %v, want ErrExist", opts, err)
+		}
+	}
+
+	// Copying a directory fails.
+	if err := CopyFile(dir, filepath.Join(dir, "dir"), nil); err == nil {
+		t.Errorf("CopyFile of directory succeeded, want error")
+	}
+}
+
+func TestCopyFilePreserve(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	// A read-only source checks that metadata can be set
+	// on a clone which has its mode.
+	for _, perm := range []FileMode{0o644, 0o444} {
+		src := filepath.Join(dir, fmt.Sprintf("src-%o", perm))
+		if err := WriteFile(src, []byte("contents"), perm); err != nil {
+			t.Fatal(err)
+		}
+		if err := Chtimes(src, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		for _, opts := range []*CopyFileOptions{
+			{PreserveTimes: true, PreserveXattrs: true},
+			{DataOnly: true, PreserveTimes: true},
+		} {
+			dst := filepath.Join(dir, fmt.Sprintf("dst-%o-%v", perm, opts.DataOnly))
+			if err := CopyFile(src, dst, opts); err != nil {
+				t.Fatalf("CopyFile(%v, %+v): %v", perm, opts, err)
+			}
+			fi, err := Stat(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runtime.GOOS != "js" && runtime.GOOS != "wasip1" && !fi.ModTime().Equal(mtime) {
+				t.Errorf("CopyFile(%v, %+v): dst modification time %v, want %v", perm, opts, fi.ModTime(), mtime)
+			}
+			if got := fi.Mode().Perm() & 0o200; got != perm&0o200 {
+				t.Errorf("CopyFile(%v, %+v): dst mode %v, want %v", perm, opts, fi.Mode().Perm(), perm)
+			}
+		}
+	}
+}
+
+func TestCopyFileFailureRemovesDst(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test requires /proc/self/mem")
+	}
+	t.Parallel()
+
+	// /proc/self/mem is a regular file which cannot be read
+	// at offset 0, so the copy fails after dst is created.
+	dst := filepath.Join(t.TempDir(), "dst")
+	for range 2 {
+		err := CopyFile("/proc/self/mem", dst, &CopyFileOptions{DataOnly: true})
+		if err == nil {
+			t.Fatal("CopyFile of /proc/self/mem succeeded, want error")
+		}
+		if errors.Is(err, fs.ErrExist) {
+			t.Fatalf("CopyFile after failed copy: %v", err)
+		}
+		if _, err := Lstat(dst); !IsNotExist(err) {
+			t.Fatalf("Lstat(dst) after failed copy: %v, want not-exist error", err)
+		}
+	}
+}
+
+func TestCopyFSSymlinkPolicy(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+
+	src := t.TempDir()
+	if err := Mkdir(filepath.Join(src, "d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"f", filepath.Join("d", "g")} {
+		if err := WriteFile(filepath.Join(src, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Symlink("f", filepath.Join(src, "flink")); err != nil {
+		t.Fatal(err)
+	}
+	if err := Symlink("d", filepath.Join(src, "dlink")); err != nil {
+		t.Fatal(err)
+	}
+	forceMFTUpdateOnWindows(t, src)
+
+	for _, test := range []struct {
+		policy SymlinkPolicy
+		flink  FileMode // type of flink in the copy, or ^0 if absent
+		dlink  FileMode
+	}{
+		{CopySymlinks, ModeSymlink, ModeSymlink},
+		{FollowSymlinks, 0, ModeDir},
+		{SkipSymlinks, ^FileMode(0), ^FileMode(0)},
+	} {
+		dst := t.TempDir()
+		if err := CopyFSWithOptions(dst, DirFS(src), &CopyFSOptions{Symlinks: test.policy}); err != nil {
+			t.Errorf("CopyFSWithOptions(policy %v): %v", test.policy, err)
+			continue
+		}
+		for _, link := range []struct {
+			name string
+			typ  FileMode
+		}{
+			{"flink", test.flink},
+			{"dlink", test.dlink},
+		} {
+			fi, err := Lstat(filepath.Join(dst, link.name))
+			if link.typ == ^FileMode(0) {
+				if !IsNotExist(err) {
+					t.Errorf("policy %v: Lstat(%s) = %v, want not-exist error", test.policy, link.name, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("policy %v: %v", test.policy, err)
+				continue
+			}
+			if got := fi.Mode().Type(); got != link.typ {
+				t.Errorf("policy %v: %s has type %v, want %v", test.policy, link.name, got, link.typ)
+			}
+		}
+		if test.policy == FollowSymlinks {
+			if b, err := ReadFile(filepath.Join(dst, "dlink", "g")); err != nil || string(b) != filepath.Join("d", "g") {
+				t.Errorf("policy %v: dlink/g = %q, %v; want %q", test.policy, b, err, filepath.Join("d", "g"))
+			}
+		}
+	}
+
+	// Following a cycle of links stops with an error.
+	if err := Symlink(".", filepath.Join(src, "d", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyFSWithOptions(t.TempDir(), DirFS(src), &CopyFSOptions{Symlinks: FollowSymlinks}); err == nil {
+		t.Errorf("CopyFSWithOptions with FollowSymlinks copied a cycle of links without error")
+	}
+}
+
+func TestCopyFSWithOptions(t *testing.T) {
+	src := t.TempDir()
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	files := map[string]string{
+		"a":                             "a",
+		filepath.Join("d", "b"):         "bb",
+		filepath.Join("d", "e", "c"):    "ccc",
+		filepath.Join("d", "e", "none"): "",
+	}
+	if err := MkdirAll(filepath.Join(src, "d", "e"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		if err := WriteFile(filepath.Join(src, name), []byte(data), 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"a", "d", filepath.Join("d", "e")} {
+		if err := Chtimes(filepath.Join(src, name), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, concurrency := range []int{0, 4} {
+		dst := t.TempDir()
+		progress := map[string]int64{}
+		opts := &CopyFSOptions{
+			PreserveMode:  true,
+			PreserveTimes: true,
+			Concurrency:   concurrency,
+			Progress: func(name string, n int64) {
+				progress[name] = n
+			},
+		}
+		if err := CopyFSWithOptions(dst, DirFS(src), opts); err != nil {
+			t.Fatalf("CopyFSWithOptions(concurrency %d): %v", concurrency, err)
+		}
+
+		want := map[string]int64{}
+		for name, data := range files {
+			want[filepath.ToSlash(name)] = int64(len(data))
+		}
+		if !maps.Equal(progress, want) {
+			t.Errorf("concurrency %d: Progress calls %v, want %v", concurrency, progress, want)
+		}
+
+		for _, name := range []string{"a", "d", filepath.Join("d", "e")} {
+			sfi, err := Stat(filepath.Join(src, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dfi, err := Stat(filepath.Join(dst, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := dfi.Mode().Perm(), sfi.Mode().Perm(); got != want {
+				t.Errorf("concurrency %d: %s has mode %v, want %v", concurrency, name, got, want)
+			}
+			if runtime.GOOS != "js" && runtime.GOOS != "wasip1" && !dfi.ModTime().Equal(mtime) {
+				t.Errorf("concurrency %d: %s has modification time %v, want %v", concurrency, name, dfi.ModTime(), mtime)
+			}
+		}
+	}
+
+	// An error stops the copy, even when copying concurrently.
+	dst := t.TempDir()
+	if err := WriteFile(filepath.Join(dst, "a"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyFSWithOptions(dst, DirFS(src), &CopyFSOptions{Concurrency: 4}); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("CopyFSWithOptions over an existing file = %v, want error matching fs.ErrExist", err)
+	}
+}
+
+func TestCopyFSRoot(t *testing.T) {
+	src := t.TempDir()
+	data := bytes.Repeat([]byte("0123456789abcdef"), 64<<10)
+	if err := WriteFile(filepath.Join(src, "big"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(filepath.Join(src, "readonly"), []byte("ro"), 0o444); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := Chtimes(filepath.Join(src, "big"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := OpenRoot(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	for _, fsys := range []fs.FS{DirFS(src), root.FS()} {
+		dst := t.TempDir()
+		if err := CopyFS(dst, fsys); err != nil {
+			t.Fatalf("CopyFS(%T): %v", fsys, err)
+		}
+		if got, err := ReadFile(filepath.Join(dst, "big")); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("CopyFS(%T): big has %d bytes, %v; want %d bytes", fsys, len(got), err, len(data))
+		}
+		// CopyFS does not preserve modification times.
+		if fi, err := Stat(filepath.Join(dst, "big")); err != nil {
+			t.Fatal(err)
+		} else if fi.ModTime().Equal(mtime) {
+			t.Errorf("CopyFS(%T): copy of big has the modification time of the source", fsys)
+		}
+		// The copy of a read-only file is writable.
+		fi, err := Stat(filepath.Join(dst, "readonly"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode()&0o200 == 0 {
+			t.Errorf("CopyFS(%T): copy of read-only file has mode %v, want owner write permission", fsys, fi.Mode())
+		}
+	}
+}
+
+// failingReadFS is a file system whose regular files cannot be read.
+type failingReadFS struct {
+	fs.FS
+}
+
+func (fsys failingReadFS) Open(name string) (fs.File, error) {
+	f, err := fsys.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+		return failingReadFile{f}, nil
+	}
+	return f, nil
+}
+
+type failingReadFile struct {
+	fs.File
+}
+
+func (failingReadFile) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestCopyFSFailureRemovesFile(t *testing.T) {
+	t.Parallel()
+
+	fsys := failingReadFS{fstest.MapFS{"f": {Data: []byte("data")}}}
+	dst := t.TempDir()
+	for range 2 {
+		err := CopyFS(dst, fsys)
+		if err == nil {
+			t.Fatal("CopyFS succeeded, want error")
+		}
+		if errors.Is(err, fs.ErrExist) {
+			t.Fatalf("CopyFS after failed copy: %v", err)
+		}
+		if _, err := Lstat(filepath.Join(dst, "f")); !IsNotExist(err) {
+			t.Fatalf("Lstat after failed copy: %v, want not-exist error", err)
+		}
+	}
+}
+
 func TestCopyFSWithSymlinks(t *testing.T) {
 	// Test it with absolute and relative symlinks that point inside and outside the tree.
 	testenv.MustHaveSymlink(t)
@@ -4054,3 +5311,879 @@ func TestReadFileContents(t *testing.T) {
 		})
 	}
 }
+
+func TestFileLock(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "lock")
+	open := func() *File {
+		t.Helper()
+		f, err := OpenFile(name, O_RDWR|O_CREATE, 0o666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	f1, f2, f3 := open(), open(), open()
+
+	if err := f1.Lock(); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("file locking is not supported on %v", runtime.GOOS)
+	} else if err != nil {
+		t.Fatalf("f1.Lock() = %v", err)
+	}
+	tryLock := func(f *File, shared bool, want bool) {
+		t.Helper()
+		op, try := "TryLock", f.TryLock
+		if shared {
+			op, try = "TryRLock", f.TryRLock
+		}
+		got, err := try()
+		if err != nil {
+			t.Fatalf("%v() = %v", op, err)
+		}
+		if got != want {
+			t.Fatalf("%v() = %v, want %v", op, got, want)
+		}
+	}
+	tryLock(f2, false, false)
+	tryLock(f2, true, false)
+	if err := f1.Unlock(); err != nil {
+		t.Fatalf("f1.Unlock() = %v", err)
+	}
+
+	// Shared locks may be held together, but exclude an exclusive lock.
+	if err := f1.RLock(); err != nil {
+		t.Fatalf("f1.RLock() = %v", err)
+	}
+	tryLock(f2, true, true)
+	tryLock(f3, false, false)
+	if err := f1.Unlock(); err != nil {
+		t.Fatalf("f1.Unlock() = %v", err)
+	}
+	if err := f2.Unlock(); err != nil {
+		t.Fatalf("f2.Unlock() = %v", err)
+	}
+
+	// A blocked Lock proceeds once the lock is released by Close.
+	tryLock(f3, false, true)
+	done := make(chan error)
+	go func() {
+		done <- f1.Lock()
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("f1.Lock() = %v while f3 holds the lock, want block", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	f3.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("f1.Lock() = %v", err)
+	}
+
+	if err := f3.Lock(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Lock of closed file = %v, want ErrClosed", err)
+	}
+}
+
+func TestFileLockRange(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "lock")
+	open := func() *File {
+		t.Helper()
+		f, err := OpenFile(name, O_RDWR|O_CREATE, 0o666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	f1, f2 := open(), open()
+
+	if err := f1.LockRange(0, 10); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("byte-range locking is not supported on %v", runtime.GOOS)
+	} else if err != nil {
+		t.Fatalf("f1.LockRange(0, 10) = %v", err)
+	}
+	tryLock := func(f *File, shared bool, off, length int64, want bool) {
+		t.Helper()
+		op, try := "TryLockRange", f.TryLockRange
+		if shared {
+			op, try = "TryRLockRange", f.TryRLockRange
+		}
+		got, err := try(off, length)
+		if err != nil {
+			t.Fatalf("%v(%v, %v) = %v", op, off, length, err)
+		}
+		if got != want {
+			t.Fatalf("%v(%v, %v) = %v, want %v", op, off, length, got, want)
+		}
+	}
+	tryLock(f2, false, 5, 10, false)
+	tryLock(f2, true, 0, 5, false)
+	tryLock(f2, false, 10, 10, true)
+
+	// Closing another descriptor for the file does not release
+	// the locks, as it would for POSIX record locks.
+	open().Close()
+	tryLock(f2, false, 0, 0, false)
+
+	if err := f1.UnlockRange(0, 10); err != nil {
+		t.Fatalf("f1.UnlockRange(0, 10) = %v", err)
+	}
+	tryLock(f2, false, 0, 10, true)
+
+	if err := f1.LockRange(-1, 10); err == nil {
+		t.Errorf("f1.LockRange(-1, 10) succeeded, want error")
+	}
+}
+
+func TestFilePreallocate(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "prealloc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	checkSize := func(want int64) {
+		t.Helper()
+		fi, err := f.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() != want {
+			t.Fatalf("size = %v, want %v", fi.Size(), want)
+		}
+	}
+	if err := f.Preallocate(0, 1<<16); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Preallocate is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Preallocate(0, 1<<16) = %v", err)
+	}
+	checkSize(1 << 16)
+
+	// Preallocating within the file does not change its size.
+	if err := f.Preallocate(100, 100); err != nil {
+		t.Fatalf("Preallocate(100, 100) = %v", err)
+	}
+	checkSize(1 << 16)
+
+	// The offset is unchanged, and the contents are preserved.
+	if _, err := f.WriteString(" world"); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 16)
+	if _, err := f.ReadAt(b, 0); err != nil {
+		t.Fatal(err)
+	}
+	if want := "hello world\x00\x00\x00\x00\x00"; string(b) != want {
+		t.Errorf("contents = %q, want %q", b, want)
+	}
+
+	if err := f.Preallocate(0, 0); err == nil {
+		t.Errorf("Preallocate(0, 0) succeeded, want error")
+	}
+}
+
+func TestFilePunchHole(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "hole"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	const size = 1 << 20
+	data := bytes.Repeat([]byte{'x'}, size)
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.PunchHole(1<<16, 1<<16); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("PunchHole is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("PunchHole(1<<16, 1<<16) = %v", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Size() != size {
+		t.Errorf("size after PunchHole = %v, want %v", fi.Size(), size)
+	}
+	got := make([]byte, size)
+	if _, err := f.ReadAt(got, 0); err != nil {
+		t.Fatal(err)
+	}
+	clear(data[1<<16 : 2<<16])
+	if !bytes.Equal(got, data) {
+		t.Errorf("contents after PunchHole do not match")
+	}
+}
+
+func TestFileZeroRange(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "zero"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	const size = 1 << 20
+	data := bytes.Repeat([]byte{'x'}, size)
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// Zero a range within the file, and a range that extends it.
+	if err := f.ZeroRange(100, 1<<17); err != nil {
+		t.Fatalf("ZeroRange(100, 1<<17) = %v", err)
+	}
+	if err := f.ZeroRange(size-10, 20); err != nil {
+		t.Fatalf("ZeroRange(size-10, 20) = %v", err)
+	}
+	clear(data[100 : 100+1<<17])
+	clear(data[size-10:])
+	data = append(data, make([]byte, 10)...)
+
+	got, err := ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("contents after ZeroRange do not match")
+	}
+	if off, err := f.Seek(0, io.SeekCurrent); err != nil || off != size {
+		t.Errorf("offset after ZeroRange = %v, %v; want %v", off, err, size)
+	}
+
+	if err := f.ZeroRange(0, 0); err == nil {
+		t.Errorf("ZeroRange(0, 0) succeeded, want error")
+	}
+}
+
+func TestFileSparseRegions(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "sparse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	const size = 8 << 20
+	chunk := bytes.Repeat([]byte{'x'}, 1<<16)
+	if _, err := f.WriteAt(chunk, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt(chunk, size-int64(len(chunk))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(100, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	var pos int64
+	var holes int
+	lastHole := true
+	for r, err := range f.SparseRegions() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Offset != pos || r.Length <= 0 {
+			t.Fatalf("region %+v does not start at %v", r, pos)
+		}
+		if r.Hole == lastHole {
+			t.Fatalf("region %+v has the same kind as the previous region", r)
+		}
+		if r.Hole {
+			holes++
+		}
+		pos, lastHole = r.Offset+r.Length, r.Hole
+	}
+	if pos != size {
+		t.Errorf("regions end at %v, want %v", pos, size)
+	}
+	if lastHole {
+		t.Errorf("last region is a hole, want data")
+	}
+
+	// Iterating does not change the I/O offset.
+	if off, err := f.Seek(0, io.SeekCurrent); err != nil || off != 100 {
+		t.Errorf("offset after SparseRegions = %v, %v; want 100", off, err)
+	}
+
+	if holes == 0 {
+		t.Skip("file system does not report holes")
+	}
+	if off, err := f.Seek(0, SeekHole); err != nil || off < int64(len(chunk)) || off >= size {
+		t.Errorf("Seek(0, SeekHole) = %v, %v; want offset in [%v, %v)", off, err, len(chunk), size)
+	}
+	if off, err := f.Seek(size/2, SeekData); err != nil || off <= size/2 || off > size-int64(len(chunk)) {
+		t.Errorf("Seek(size/2, SeekData) = %v, %v; want offset in (%v, %v]", off, err, size/2, size-len(chunk))
+	}
+}
+
+func TestFileSyncData(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "syncdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SyncData(); err != nil {
+		t.Errorf("SyncData() = %v", err)
+	}
+	f.Close()
+	if err := f.SyncData(); !errors.Is(err, ErrClosed) {
+		t.Errorf("SyncData of closed file = %v, want ErrClosed", err)
+	}
+}
+
+func TestFileSyncRange(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "syncrange"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(make([]byte, 1<<16)); err != nil {
+		t.Fatal(err)
+	}
+	for _, flags := range []SyncRangeFlags{
+		SyncRangeWrite,
+		SyncRangeWaitBefore | SyncRangeWrite | SyncRangeWaitAfter,
+	} {
+		if err := f.SyncRange(0, 1<<15, flags); err != nil {
+			t.Errorf("SyncRange(0, 1<<15, %v) = %v", flags, err)
+		}
+		if err := f.SyncRange(1<<15, 0, flags); err != nil {
+			t.Errorf("SyncRange(1<<15, 0, %v) = %v", flags, err)
+		}
+	}
+	if err := f.SyncRange(-1, 0, SyncRangeWrite); err == nil {
+		t.Errorf("SyncRange(-1, 0, SyncRangeWrite) succeeded, want error")
+	}
+}
+
+func TestFileSyncfs(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "syncfs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Syncfs(); err != nil {
+		t.Errorf("Syncfs() = %v", err)
+	}
+	f.Close()
+	if err := f.Syncfs(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Syncfs() after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestSyncfs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := WriteFile(filepath.Join(dir, "syncfs"), []byte("hello"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	err := Syncfs(dir)
+	if errors.Is(err, errors.ErrUnsupported) || (runtime.GOOS == "windows" && IsPermission(err)) {
+		t.Skipf("Syncfs: %v", err)
+	}
+	if err != nil {
+		t.Errorf("Syncfs(%q) = %v", dir, err)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	if err := Syncfs(missing); !IsNotExist(err) {
+		t.Errorf("Syncfs(%q) = %v, want not-exist error", missing, err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping flushing all file systems in short mode")
+	}
+	err := Sync()
+	if errors.Is(err, errors.ErrUnsupported) || (runtime.GOOS == "windows" && IsPermission(err)) {
+		t.Skipf("Sync: %v", err)
+	}
+	if err != nil {
+		t.Errorf("Sync() = %v", err)
+	}
+}
+
+func TestFileFadvise(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "fadvise"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(make([]byte, 1<<16)); err != nil {
+		t.Fatal(err)
+	}
+	for _, advice := range []FadviseAdvice{
+		FadviseSequential,
+		FadviseRandom,
+		FadviseWillNeed,
+		FadviseDontNeed,
+		FadviseNoReuse,
+		FadviseNormal,
+	} {
+		if err := f.Fadvise(0, 0, advice); err != nil {
+			t.Errorf("Fadvise(0, 0, %v) = %v", advice, err)
+		}
+	}
+	if err := f.Fadvise(0, 0, FadviseNoReuse+1); err == nil {
+		t.Errorf("Fadvise with invalid advice succeeded, want error")
+	}
+}
+
+func TestFileXattr(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "xattr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	const name = "user.go.test"
+	if err := f.Setxattr(name, []byte("value")); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("extended attributes are not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Setxattr(%q) = %v", name, err)
+	}
+	if got, err := f.Getxattr(name); err != nil || string(got) != "value" {
+		t.Errorf("Getxattr(%q) = %q, %v; want %q", name, got, err, "value")
+	}
+	if err := f.Setxattr(name, nil); err != nil {
+		t.Fatalf("Setxattr(%q, nil) = %v", name, err)
+	}
+	if got, err := f.Getxattr(name); err != nil || len(got) != 0 {
+		t.Errorf("Getxattr(%q) = %q, %v; want empty", name, got, err)
+	}
+	names, err := f.Listxattr()
+	if err != nil {
+		t.Fatalf("Listxattr() = %v", err)
+	}
+	if !slices.Contains(names, name) {
+		t.Errorf("Listxattr() = %q, want it to contain %q", names, name)
+	}
+	if err := f.Removexattr(name); err != nil {
+		t.Fatalf("Removexattr(%q) = %v", name, err)
+	}
+	var pe *PathError
+	if _, err := f.Getxattr(name); !errors.As(err, &pe) {
+		t.Errorf("Getxattr(%q) after Removexattr = %v, want PathError", name, err)
+	}
+}
+
+func TestFileChtimes(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "chtimes")
+	f, err := Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	mt := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+	if err := f.Chtimes(at, mt); errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("File.Chtimes is not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("Chtimes = %v", err)
+	}
+	fi, err := Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.ModTime().Equal(mt) {
+		t.Errorf("modification time = %v, want %v", fi.ModTime(), mt)
+	}
+
+	// A zero time leaves the modification time unchanged.
+	at2 := at.Add(time.Hour)
+	if err := f.Chtimes(at2, time.Time{}); err != nil {
+		t.Fatalf("Chtimes with zero mtime = %v", err)
+	}
+	if fi, err = Stat(name); err != nil {
+		t.Fatal(err)
+	}
+	if !fi.ModTime().Equal(mt) {
+		t.Errorf("modification time after Chtimes with zero mtime = %v, want %v", fi.ModTime(), mt)
+	}
+
+	f.Close()
+	if err := f.Chtimes(at, mt); !errors.Is(err, ErrClosed) {
+		t.Errorf("Chtimes of closed file = %v, want ErrClosed", err)
+	}
+}
+
+func TestFileAttrs(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "attrs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	attrs, err := f.GetAttrs()
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("file attributes are not supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("GetAttrs() = %v", err)
+	}
+	if attrs != 0 {
+		t.Errorf("GetAttrs() of new file = %v, want 0", attrs)
+	}
+
+	// Any user may mark their own file not to be dumped.
+	if err := f.SetAttrs(AttrNoDump); err != nil {
+		t.Fatalf("SetAttrs(AttrNoDump) = %v", err)
+	}
+	if attrs, err := f.GetAttrs(); err != nil || attrs != AttrNoDump {
+		t.Errorf("GetAttrs() = %v, %v; want AttrNoDump", attrs, err)
+	}
+	if err := f.SetAttrs(0); err != nil {
+		t.Fatalf("SetAttrs(0) = %v", err)
+	}
+	if attrs, err := f.GetAttrs(); err != nil || attrs != 0 {
+		t.Errorf("GetAttrs() = %v, %v; want 0", attrs, err)
+	}
+}
+
+func TestFileReadWriteVAt(t *testing.T) {
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "vat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	bufs := [][]byte{[]byte("hello"), nil, []byte(", "), []byte("world")}
+	n, err := f.WriteVAt(bufs, 3)
+	if err != nil || n != 12 {
+		t.Fatalf("WriteVAt = %v, %v; want 12, nil", n, err)
+	}
+	if string(bufs[0]) != "hello" || len(bufs) != 4 {
+		t.Errorf("WriteVAt modified its argument: %q", bufs)
+	}
+	got, err := ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\x00\x00\x00hello, world"; string(got) != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+
+	a, b, c := make([]byte, 3), make([]byte, 0), make([]byte, 4)
+	n, err = f.ReadVAt([][]byte{a, b, c}, 5)
+	if err != nil || n != 7 {
+		t.Fatalf("ReadVAt = %v, %v; want 7, nil", n, err)
+	}
+	if string(a) != "llo" || string(c) != ", wo" {
+		t.Errorf("ReadVAt read %q, %q; want %q, %q", a, c, "llo", ", wo")
+	}
+
+	// Reading past the end of the file returns io.EOF.
+	d := make([]byte, 10)
+	n, err = f.ReadVAt([][]byte{a, d}, 10)
+	if err != io.EOF || n != 5 {
+		t.Errorf("ReadVAt at end of file = %v, %v; want 5, io.EOF", n, err)
+	}
+	if string(a) != "wor" || string(d[:2]) != "ld" {
+		t.Errorf("ReadVAt read %q, %q; want %q, %q", a, d[:2], "wor", "ld")
+	}
+}
+
+func TestFileCopyRange(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src, err := Create(filepath.Join(dir, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	dst, err := Create(filepath.Join(dir, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	data := make([]byte, 100<<10)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	if _, err := src.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	n, err := src.CopyRange(dst, 10, 5, int64(len(data)-20))
+	if err != nil || n != int64(len(data)-20) {
+		t.Fatalf("CopyRange = %v, %v; want %v, nil", n, err, len(data)-20)
+	}
+	got, err := ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(make([]byte, 5), data[10:len(data)-10]...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("destination contents differ from the copied range")
+	}
+	if off, err := dst.Seek(0, io.SeekCurrent); err != nil || off != 0 {
+		t.Errorf("destination offset after CopyRange = %v, %v; want 0", off, err)
+	}
+
+	// Copying past the end of the source returns io.EOF.
+	n, err = src.CopyRange(dst, int64(len(data)-10), 0, 20)
+	if err != io.EOF || n != 10 {
+		t.Errorf("CopyRange past end of file = %v, %v; want 10, io.EOF", n, err)
+	}
+	if _, err := src.CopyRange(dst, -1, 0, 1); err == nil {
+		t.Errorf("CopyRange with negative offset succeeded, want error")
+	}
+}
+
+func TestFileClone(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src, err := Create(filepath.Join(dir, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	dst, err := Create(filepath.Join(dir, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	const data = "hello, world"
+	if _, err := src.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	err = dst.Clone(src)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Clone: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	got, err := ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != data {
+		t.Errorf("cloned file contents = %q, want %q", got, data)
+	}
+}
+
+func TestOpenFileTmpFile(t *testing.T) {
+	testenv.MustHaveLink(t)
+	t.Parallel()
+	dir := t.TempDir()
+
+	f, err := OpenFileWithOptions(dir, O_RDWR, 0o644, &OpenFileOptions{TmpFile: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("contents"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.LinkInto(dir, "file"); err != nil {
+		t.Fatalf("LinkInto: %v", err)
+	}
+	if err := f.LinkInto(dir, "file"); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("LinkInto existing file: %v, want ErrExist", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadFile(filepath.Join(dir, "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "contents" {
+		t.Errorf("linked file contains %q, want %q", got, "contents")
+	}
+
+	// A file which is closed without being linked disappears.
+	f, err = OpenFileWithOptions(dir, O_WRONLY, 0o644, &OpenFileOptions{TmpFile: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	entries, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "file" {
+		t.Errorf("directory contains %v, want only file", entries)
+	}
+}
+
+func TestFileDup(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "wasip1":
+		t.Skipf("dup not supported on %s", runtime.GOOS)
+	}
+	t.Parallel()
+	f, err := Create(filepath.Join(t.TempDir(), "dup"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d, err := f.Dup()
+	if err != nil {
+		t.Fatalf("Dup: %v", err)
+	}
+	if d.Fd() == f.Fd() {
+		t.Errorf("Dup returned the same descriptor %v", d.Fd())
+	}
+	if d.Name() != f.Name() {
+		t.Errorf("Dup name = %q, want %q", d.Name(), f.Name())
+	}
+
+	// The files share an offset, and closing one does not close the other.
+	if _, err := d.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(", world"); err != nil {
+		t.Fatalf("Write after closing duplicate: %v", err)
+	}
+	got, err := ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "hello, world"; string(got) != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+
+	if _, err := d.Dup(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Dup of closed file = %v, want ErrClosed", err)
+	}
+}
+
+func TestFileMmap(t *testing.T) {
+	t.Parallel()
+	f := newFile(t)
+	data := bytes.Repeat([]byte("0123456789"), 10000)
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	const off, length = 70001, 1234
+	mem, unmap, err := f.Mmap(off, length)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Mmap: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Mmap: %v", err)
+	}
+	// The mapping outlives the file.
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(mem, data[off:off+length]) {
+		t.Errorf("mapped data does not match file contents")
+	}
+	if err := unmap(); err != nil {
+		t.Errorf("unmap: %v", err)
+	}
+	if err := unmap(); err != nil {
+		t.Errorf("second unmap: %v", err)
+	}
+}
+
+func TestFileMmapOutOfRange(t *testing.T) {
+	t.Parallel()
+	f := newFile(t)
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ off, length int64 }{
+		{-1, 1},
+		{0, -1},
+		{0, 6},
+		{5, 1},
+	} {
+		if _, _, err := f.Mmap(tt.off, tt.length); err == nil {
+			t.Errorf("Mmap(%v, %v) succeeded, want error", tt.off, tt.length)
+		}
+	}
+}
+
+func TestFileVerity(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "file")
+	if err := WriteFile(name, []byte("hello, world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.MeasureVerity(); err == nil {
+		t.Errorf("MeasureVerity succeeded before EnableVerity")
+	}
+	err = f.EnableVerity()
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("EnableVerity: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("EnableVerity: %v", err)
+	}
+	digest, err := f.MeasureVerity()
+	if err != nil {
+		t.Fatalf("MeasureVerity: %v", err)
+	}
+	if len(digest) != 32 {
+		t.Errorf("MeasureVerity returned a %d-byte digest, want 32", len(digest))
+	}
+	if _, err := OpenFile(name, O_WRONLY, 0); err == nil {
+		t.Errorf("opening a verity file for writing succeeded")
+	}
+}
+
+func TestOpenFileSyncFlags(t *testing.T) {
+	if runtime.GOOS == "js" {
+		t.Skip("O_SYNC is not supported on js")
+	}
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "file")
+	for _, flag := range []int{O_DSYNC, O_DSYNC | O_RSYNC, O_SYNC | O_RSYNC} {
+		f, err := OpenFile(name, O_RDWR|O_CREATE|O_TRUNC|flag, 0o644)
+		if err != nil {
+			t.Fatalf("OpenFile with flag %#x: %v", flag, err)
+		}
+		if _, err := f.WriteString("data"); err != nil {
+			t.Errorf("Write with flag %#x: %v", flag, err)
+		}
+		if _, err := f.ReadAt(make([]byte, 4), 0); err != nil {
+			t.Errorf("ReadAt with flag %#x: %v", flag, err)
+		}
+		f.Close()
+	}
+
+	// The test owns the file, so O_NOATIME is permitted.
+	f, err := OpenFile(name, O_RDONLY|O_NOATIME, 0)
+	if err != nil {
+		t.Fatalf("OpenFile with O_NOATIME: %v", err)
+	}
+	defer f.Close()
+	if _, err := io.ReadAll(f); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -7,6 +7,7 @@
 package os_test
 
 import (
+	"errors"
 	"internal/testenv"
 	"io"
 	. "os"
@@ -424,3 +425,113 @@ func TestIssue60181(t *testing.T) {
 		t.Errorf("files not concatenated: got %q, want %q", got, want)
 	}
 }
+
+func TestFileSetNonblock(t *testing.T) {
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
+		t.Skipf("syscall.Pipe is not available on %s.", runtime.GOOS)
+	}
+	t.Parallel()
+
+	p := make([]int, 2)
+	if err := syscall.Pipe(p); err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer syscall.Close(p[1])
+	// A blocking descriptor passed to NewFile is not in the poller.
+	r := NewFile(uintptr(p[0]), "pipe")
+	defer r.Close()
+	if r.IsNonblock() {
+		t.Fatalf("IsNonblock of blocking pipe = true, want false")
+	}
+
+	if err := r.SetNonblock(true); err != nil {
+		t.Fatalf("SetNonblock(true): %v", err)
+	}
+	if !r.IsNonblock() {
+		t.Errorf("IsNonblock after SetNonblock(true) = false, want true")
+	}
+	// Deadlines now use the poller.
+	r.SetReadDeadline(time.Now().Add(time.Millisecond))
+	if _, err := r.Read(make([]byte, 1)); !isDeadlineExceeded(err) {
+		t.Errorf("Read with deadline: %v, want timeout", err)
+	}
+	r.SetReadDeadline(time.Time{})
+
+	if err := r.SetNonblock(false); err != nil {
+		t.Fatalf("SetNonblock(false): %v", err)
+	}
+	if r.IsNonblock() {
+		t.Errorf("IsNonblock after SetNonblock(false) = true, want false")
+	}
+	syscall.Write(p[1], []byte("a"))
+	if _, err := r.Read(make([]byte, 1)); err != nil {
+		t.Errorf("blocking Read: %v", err)
+	}
+}
+
+func TestOpenFilePath(t *testing.T) {
+	if O_PATH == 0 {
+		t.Skip("O_PATH not supported")
+	}
+	t.Parallel()
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file")
+	if err := WriteFile(name, []byte("secret"), 0o200); err != nil {
+		t.Fatal(err)
+	}
+
+	// O_PATH does not need read permission.
+	f, err := OpenFile(name, O_PATH, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if fi.Size() != 6 || fi.Mode().Perm() != 0o200 {
+		t.Errorf("Stat = size %d, mode %v; want size 6, mode %v", fi.Size(), fi.Mode(), FileMode(0o200))
+	}
+	if _, err := f.Read(make([]byte, 1)); !errors.Is(err, syscall.EBADF) {
+		t.Errorf("Read: %v, want EBADF", err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, syscall.EBADF) {
+		t.Errorf("Write: %v, want EBADF", err)
+	}
+
+	root, err := OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	d, err := root.OpenFile(".", O_PATH, 0)
+	if err != nil {
+		t.Fatalf("Root.OpenFile(., O_PATH): %v", err)
+	}
+	defer d.Close()
+	if fi, err := d.Stat(); err != nil || !fi.IsDir() {
+		t.Errorf("Stat of O_PATH directory = %v, %v; want a directory", fi, err)
+	}
+}
+
+func TestHostsCanonicalName(t *testing.T) {
+	const hosts = `# comment
+127.0.0.1	localhost
+127.0.1.1	build1.example.com	build1 # the host
+10.0.0.2 other
+10.0.0.3	alias	db.example.org
+`
+	for _, test := range []struct{ name, want string }{
+		{"build1", "build1.example.com"},
+		{"BUILD1", "build1.example.com"},
+		{"alias", "db.example.org"},
+		{"other", ""},
+		{"localhost", ""},
+		{"missing", ""},
+	} {
+		if got := HostsCanonicalName(hosts, test.name); got != test.want {
+			t.Errorf("HostsCanonicalName(%q) = %q; want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -33,6 +33,7 @@ import (
 
 var winsymlink = godebug.New("winsymlink")
 var winreadlinkvolume = godebug.New("winreadlinkvolume")
+var winposixsemantics = godebug.New("winposixsemantics")
 
 // For TestRawConnReadWrite.
 type syscallDescriptor = syscall.Handle
@@ -1604,6 +1605,80 @@ func TestReadWriteFileOverlapped(t *testing.T) {
 	}
 }
 
+func TestReadWriteAtFileOverlappedConcurrent(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "test.txt")
+	f := newFileOverlapped(t, name, true)
+
+	const chunk = 4096
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			want := bytes.Repeat([]byte{byte('a' + i)}, chunk)
+			off := int64(i * chunk)
+			if n, err := f.WriteAt(want, off); err != nil || n != chunk {
+				t.Errorf("WriteAt(%d) = %d, %v", off, n, err)
+				return
+			}
+			got := make([]byte, chunk)
+			if n, err := f.ReadAt(got, off); err != nil || n != chunk {
+				t.Errorf("ReadAt(%d) = %d, %v", off, n, err)
+				return
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("ReadAt(%d) returned wrong data", off)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Positioned IO must not move the file offset.
+	if off, err := f.Seek(0, io.SeekCurrent); err != nil || off != 0 {
+		t.Fatalf("Seek(0, io.SeekCurrent) = %d, %v; want 0, nil", off, err)
+	}
+}
+
+func TestReadWriteAtFileOverlappedDeadline(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "test.txt")
+	f := newFileOverlapped(t, name, true)
+	if _, err := f.WriteAt([]byte("hello"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.SetDeadline(time.Now().Add(-time.Second)); err != nil {
+		if errors.Is(err, os.ErrNoDeadline) {
+			t.Skip("file does not support deadlines")
+		}
+		t.Fatal(err)
+	}
+	b := make([]byte, 5)
+	if _, err := f.ReadAt(b, 0); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("ReadAt after deadline: got %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	if _, err := f.WriteAt(b, 0); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("WriteAt after deadline: got %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	if err := f.SetDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.ReadAt(b, 0); err != nil || string(b[:n]) != "hello" {
+		t.Errorf("ReadAt after clearing deadline = %q, %v; want %q, nil", b[:n], err, "hello")
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ReadAt(b, 0); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("ReadAt after Close: got %v, want %v", err, os.ErrClosed)
+	}
+}
+
 func TestStdinOverlappedPipe(t *testing.T) {
 	// Test that we can read from a named pipe open with FILE_FLAG_OVERLAPPED.
 	// See https://go.dev/issue/15388.
@@ -2143,3 +2218,156 @@ func TestSplitPath(t *testing.T) {
 		}
 	}
 }
+
+func TestOpenFileWithOptionsShareMode(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "file")
+	f, err := os.OpenFileWithOptions(name, os.O_RDWR|os.O_CREATE, 0666, &os.OpenFileOptions{
+		WindowsShareMode: windows.FILE_SHARE_READ,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// Readers are permitted, writers are not.
+	r, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("Open for reading: %v", err)
+	}
+	r.Close()
+	if w, err := os.OpenFile(name, os.O_WRONLY, 0); !errors.Is(err, windows.ERROR_SHARING_VIOLATION) {
+		if err == nil {
+			w.Close()
+		}
+		t.Errorf("OpenFile for writing: %v, want ERROR_SHARING_VIOLATION", err)
+	}
+}
+
+func TestOpenFileWithOptionsDeleteOnClose(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "file")
+	f, err := os.OpenFileWithOptions(name, os.O_RDWR|os.O_CREATE, 0666, &os.OpenFileOptions{
+		WindowsShareMode: windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE,
+		WindowsFileFlags: windows.FILE_FLAG_DELETE_ON_CLOSE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name); err != nil {
+		t.Fatalf("Stat before Close: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat after Close: %v, want ErrNotExist", err)
+	}
+}
+
+func TestOpenFileWithOptionsOverlapped(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "file")
+	f, err := os.OpenFileWithOptions(name, os.O_RDWR|os.O_CREATE, 0666, &os.OpenFileOptions{
+		WindowsFileFlags: windows.FILE_FLAG_OVERLAPPED,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	nonblock, err := windows.IsNonblock(syscall.Handle(f.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nonblock {
+		t.Errorf("file opened with FILE_FLAG_OVERLAPPED is blocking")
+	}
+	want := []byte("hello")
+	if _, err := f.Write(want); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(want))
+	if _, err := f.ReadAt(got, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ReadAt = %q, want %q", got, want)
+	}
+}
+
+func TestRemoveRenameOpenFilePOSIXSemantics(t *testing.T) {
+	if winposixsemantics.Value() != "1" {
+		t.Skip("requires GODEBUG=winposixsemantics=1")
+	}
+	dir := t.TempDir()
+	testRemoveRenameOpenFile(t, dir, func(src, dst string) error {
+		return os.Rename(filepath.Join(dir, src), filepath.Join(dir, dst))
+	}, func(name string) error {
+		return os.Remove(filepath.Join(dir, name))
+	})
+}
+
+func TestRootRemoveRenameOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	testRemoveRenameOpenFile(t, dir, root.Rename, root.Remove)
+}
+
+// testRemoveRenameOpenFile checks that rename and remove, which operate
+// on names in dir, can replace and remove a file which is still open.
+func testRemoveRenameOpenFile(t *testing.T, dir string, rename func(src, dst string) error, remove func(name string) error) {
+	openShared := func(name string) *os.File {
+		t.Helper()
+		f, err := os.OpenFileWithOptions(name, os.O_RDONLY, 0, &os.OpenFileOptions{
+			WindowsShareMode: windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+	if err := os.WriteFile(src, []byte("new"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("old"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	f := openShared(dst)
+	defer f.Close()
+	if err := rename("src", "dst"); err != nil {
+		if errors.Is(err, windows.ERROR_SHARING_VIOLATION) || errors.Is(err, syscall.ERROR_ACCESS_DENIED) {
+			t.Skipf("filesystem does not support POSIX semantics: %v", err)
+		}
+		t.Fatalf("Rename over open file: %v", err)
+	}
+	if got, err := os.ReadFile(dst); err != nil || string(got) != "new" {
+		t.Errorf("ReadFile(dst) = %q, %v; want %q", got, err, "new")
+	}
+
+	g := openShared(dst)
+	defer g.Close()
+	if err := remove("dst"); err != nil {
+		t.Fatalf("Remove of open file: %v", err)
+	}
+	// With POSIX semantics, the name is unlinked immediately,
+	// even though a handle to the file is still open.
+	if _, err := os.Lstat(dst); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Lstat after Remove: %v, want ErrNotExist", err)
+	}
+	if err := os.WriteFile(dst, nil, 0666); err != nil {
+		t.Errorf("recreating removed file: %v", err)
+	}
+}
@@ -5,6 +5,7 @@
 package os
 
 import (
+	"context"
 	"internal/filepathlite"
 	"syscall"
 )
@@ -71,7 +72,63 @@ func MkdirAll(path string, perm FileMode) error {
 // returns nil (no error).
 // If there is an error, it will be of type [*PathError].
 func RemoveAll(path string) error {
-	return removeAll(path)
+	return removeAll(&removeAllState{}, path)
+}
+
+// RemoveAllOptions configures [RemoveAllContext].
+type RemoveAllOptions struct {
+	// Progress, if not nil, is called after each file or directory
+	// is removed, with the number of entries removed so far,
+	// including this one, and the path of the entry.
+	Progress func(removed int64, path string)
+}
+
+// RemoveAllContext is like [RemoveAll], but stops and returns
+// an error wrapping ctx.Err() if ctx is done before the removal is complete.
+// ctx is checked before each batch of directory entries is read and before
+// each entry is removed, so some entries may already have been removed
+// when RemoveAllContext returns.
+//
+// A nil opts is equivalent to the zero [RemoveAllOptions].
+// If there is an error, it will be of type [*PathError].
+func RemoveAllContext(ctx context.Context, path string, opts *RemoveAllOptions) error {
+	if err := ctx.Err(); err != nil {
+		return &PathError{Op: "RemoveAll", Path: path, Err: err}
+	}
+	st := &removeAllState{ctx: ctx}
+	if opts != nil {
+		st.progress = opts.Progress
+	}
+	err := removeAll(st, path)
+	if err != nil {
+		if _, ok := err.(*PathError); !ok {
+			err = &PathError{Op: "RemoveAll", Path: path, Err: err}
+		}
+	}
+	return err
+}
+
+// removeAllState holds the options and progress of a RemoveAll call.
+type removeAllState struct {
+	ctx      context.Context                  // if non-nil, checked before reading each batch of entries
+	progress func(removed int64, path string) // if non-nil, called after each removal
+	removed  int64
+}
+
+// ctxErr returns the error of st.ctx, if it is done.
+func (st *removeAllState) ctxErr() error {
+	if st.ctx == nil {
+		return nil
+	}
+	return st.ctx.Err()
+}
+
+// done records the removal of path.
+func (st *removeAllState) done(path string) {
+	if st.progress != nil {
+		st.removed++
+		st.progress(st.removed, path)
+	}
 }
 
 // endsWithDot reports whether the final component of path is ".".
@@ -16,7 +16,10 @@
 package os
 
 import (
+	"context"
 	"errors"
+	"internal/itoa"
+	"internal/stringslite"
 	"internal/syscall/unix"
 	"runtime"
 	"sync"
@@ -123,6 +126,38 @@ func (p *Process) pidfdWait() (*ProcessState, error) {
 	}, nil
 }
 
+// pidfdWaitContext is like pidfdWait, but returns early if ctx is done.
+func (p *Process) pidfdWaitContext(ctx context.Context) (*ProcessState, error) {
+	handle, status := p.handleTransientAcquire()
+	switch status {
+	case statusDone:
+		return nil, NewSyscallError("wait", syscall.ECHILD)
+	case statusReleased:
+		return nil, syscall.EINVAL
+	}
+	// Wait on a duplicate of the pidfd, so that the handle
+	// may be closed while we are waiting.
+	fd, err := unix.Fcntl(int(handle), syscall.F_DUPFD_CLOEXEC, 0)
+	p.handleTransientRelease()
+	if err != nil {
+		return nil, NewSyscallError("fcntl", err)
+	}
+
+	// A pidfd becomes readable when the process exits.
+	err = waitFDReady(ctx, fd, func() bool {
+		var info unix.SiginfoChild
+		err := ignoringEINTR(func() error {
+			return unix.Waitid(unix.P_PIDFD, fd, &info, syscall.WEXITED|syscall.WNOHANG|syscall.WNOWAIT, nil)
+		})
+		// On error, let pidfdWait report it.
+		return err != nil || info.Pid != 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.pidfdWait()
+}
+
 // pidfdSendSignal sends a signal to the process.
 func (p *Process) pidfdSendSignal(s syscall.Signal) error {
 	handle, status := p.handleTransientAcquire()
@@ -137,6 +172,94 @@ func (p *Process) pidfdSendSignal(s syscall.Signal) error {
 	return convertESRCH(unix.PidFDSendSignal(handle, s))
 }
 
+// pidfd returns a duplicate of the pidfd of the process.
+func (p *Process) pidfd() (uintptr, error) {
+	if p.handle == nil {
+		if p.pidStatus() == statusDone {
+			return 0, ErrProcessDone
+		}
+		return 0, NewSyscallError("pidfd", errors.ErrUnsupported)
+	}
+	handle, status := p.handleTransientAcquire()
+	switch status {
+	case statusDone:
+		return 0, ErrProcessDone
+	case statusReleased:
+		return 0, errors.New("os: process already released")
+	}
+	defer p.handleTransientRelease()
+
+	fd, err := unix.Fcntl(int(handle), syscall.F_DUPFD_CLOEXEC, 0)
+	if err != nil {
+		return 0, NewSyscallError("fcntl", err)
+	}
+	return uintptr(fd), nil
+}
+
+// newProcessFromPidfd returns a Process that owns the pidfd fd.
+func newProcessFromPidfd(fd uintptr) (*Process, error) {
+	if !pidfdWorks() {
+		return nil, NewSyscallError("pidfd", errors.ErrUnsupported)
+	}
+	pid, err := pidfdPid(fd)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case pid < 0:
+		// The process has exited and has been reaped.
+		return nil, ErrProcessDone
+	case pid == 0:
+		// The process is in a PID namespace which is not visible
+		// from ours. A Process with a Pid of 0 would be misused
+		// to refer to the caller's process group.
+		return nil, NewSyscallError("pidfd", syscall.ESRCH)
+	}
+	return newHandleProcess(pid, fd), nil
+}
+
+// pidfdPid returns the PID of the process referred to by the pidfd fd,
+// as reported by the "Pid:" field of /proc/self/fdinfo/<fd>.
+// The PID is -1 if the process has been reaped, and 0 if it is
+// not visible in the current PID namespace.
+func pidfdPid(fd uintptr) (int, error) {
+	name := "/proc/self/fdinfo/" + itoa.Uitoa(uint(fd))
+	data, err := ReadFile(name)
+	if err != nil {
+		return 0, err
+	}
+	rest := string(data)
+	for rest != "" {
+		var line string
+		line, rest, _ = stringslite.Cut(rest, "\n")
+		val, ok := stringslite.CutPrefix(line, "Pid:")
+		if !ok {
+			continue
+		}
+		f := lineFields(val)
+		if len(f) != 1 {
+			break
+		}
+		s, neg := stringslite.CutPrefix(f[0], "-")
+		if s == "" || len(s) > 9 {
+			break
+		}
+		n := 0
+		for i := 0; i < len(s); i++ {
+			if s[i] < '0' || s[i] > '9' {
+				return 0, &PathError{Op: "pidfd", Path: name, Err: syscall.EINVAL}
+			}
+			n = n*10 + int(s[i]-'0')
+		}
+		if neg {
+			n = -n
+		}
+		return n, nil
+	}
+	// No valid Pid field: fd is not a pidfd.
+	return 0, &PathError{Op: "pidfd", Path: name, Err: syscall.EINVAL}
+}
+
 // pidfdWorks returns whether we can use pidfd on this system.
 func pidfdWorks() bool {
 	return checkPidfdOnce() == nil
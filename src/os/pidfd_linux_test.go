@@ -145,3 +145,70 @@ func TestPidfdLeak(t *testing.T) {
 		t.Errorf("got descriptor %d, want %d", got[count-1], want[count-1])
 	}
 }
+
+func TestProcessPidFD(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	if err := os.CheckPidfdOnce(); err != nil {
+		t.Skipf("skipping: pidfd not available: %v", err)
+	}
+
+	p, err := os.StartProcess(testenv.GoToolPath(t), []string{"go"}, &os.ProcAttr{})
+	if err != nil {
+		t.Fatalf("starting test process: %v", err)
+	}
+	defer p.Release()
+
+	fd, err := p.PidFD()
+	if err != nil {
+		t.Fatalf("PidFD: %v", err)
+	}
+
+	// The new Process owns fd from here on.
+	p2, err := os.NewProcessFromPidFD(fd)
+	if err != nil {
+		syscall.Close(int(fd))
+		t.Fatalf("NewProcessFromPidFD: %v", err)
+	}
+	if p2.Pid != p.Pid {
+		t.Errorf("NewProcessFromPidFD: got pid %d, want %d", p2.Pid, p.Pid)
+	}
+	if _, err := p2.Wait(); err != nil {
+		t.Fatalf("Wait: got %v, want <nil>", err)
+	}
+	if _, err := p2.PidFD(); err != os.ErrProcessDone {
+		t.Errorf("PidFD after Wait: got %v, want %v", err, os.ErrProcessDone)
+	}
+
+	// The original Process still has its own pidfd, which now
+	// refers to a reaped process.
+	fd, err = p.PidFD()
+	if err != nil {
+		t.Fatalf("PidFD: %v", err)
+	}
+	defer syscall.Close(int(fd))
+	if _, err := os.NewProcessFromPidFD(fd); err != os.ErrProcessDone {
+		t.Errorf("NewProcessFromPidFD of reaped process: got %v, want %v", err, os.ErrProcessDone)
+	}
+}
+
+func TestNewProcessFromPidFDNotPidfd(t *testing.T) {
+	if err := os.CheckPidfdOnce(); err != nil {
+		t.Skipf("skipping: pidfd not available: %v", err)
+	}
+
+	f, err := os.Open(testenv.Executable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if p, err := os.NewProcessFromPidFD(f.Fd()); err == nil {
+		t.Errorf("NewProcessFromPidFD of regular file: got %v, want error", p)
+	}
+	// The descriptor is still owned by f.
+	if _, err := f.Stat(); err != nil {
+		t.Errorf("Stat after failed NewProcessFromPidFD: %v", err)
+	}
+}
@@ -6,7 +6,11 @@
 
 package os
 
-import "syscall"
+import (
+	"context"
+	"errors"
+	"syscall"
+)
 
 func ensurePidfd(sysAttr *syscall.SysProcAttr) (*syscall.SysProcAttr, bool) {
 	return sysAttr, false
@@ -24,6 +28,18 @@ func (_ *Process) pidfdWait() (*ProcessState, error) {
 	panic("unreachable")
 }
 
+func (_ *Process) pidfdWaitContext(_ context.Context) (*ProcessState, error) {
+	panic("unreachable")
+}
+
 func (_ *Process) pidfdSendSignal(_ syscall.Signal) error {
 	panic("unreachable")
 }
+
+func (_ *Process) pidfd() (uintptr, error) {
+	return 0, NewSyscallError("pidfd", errors.ErrUnsupported)
+}
+
+func newProcessFromPidfd(_ uintptr) (*Process, error) {
+	return nil, NewSyscallError("pidfd", errors.ErrUnsupported)
+}
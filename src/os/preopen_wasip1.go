@@ -0,0 +1,42 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build wasip1
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func preopens() ([]*Root, error) {
+	var roots []*Root
+	for i := 0; ; i++ {
+		fd, name, ok := unix.Preopen(i)
+		if !ok {
+			break
+		}
+		// Open a new descriptor for the directory, so that closing
+		// the Root does not close the preopened descriptor.
+		// The syscall package uses the preopened descriptors
+		// to resolve all other file names.
+		dirfd, err := unix.Openat(fd, ".", syscall.O_DIRECTORY, 0)
+		if err != nil {
+			for _, r := range roots {
+				r.Close()
+			}
+			return nil, &PathError{Op: "openat", Path: name, Err: err}
+		}
+		r, err := newRoot(dirfd, name)
+		if err != nil {
+			for _, r := range roots {
+				r.Close()
+			}
+			return nil, err
+		}
+		roots = append(roots, r)
+	}
+	return roots, nil
+}
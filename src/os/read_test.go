@@ -40,6 +40,46 @@ func TestReadFile(t *testing.T) {
 	checkNamedSize(t, filename, int64(len(contents)))
 }
 
+func TestReadFileInto(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "file")
+	if err := WriteFile(name, []byte("hello, world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A buffer which is too small is replaced.
+	small := make([]byte, 0, 4)
+	data, err := ReadFileInto(name, small)
+	if err != nil {
+		t.Fatalf("ReadFileInto: %v", err)
+	}
+	if string(data) != "hello, world" {
+		t.Errorf("ReadFileInto = %q, want %q", data, "hello, world")
+	}
+
+	// A buffer which is large enough is reused, and its old contents
+	// are overwritten.
+	if err := WriteFile(name, []byte("bye"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	buf := data
+	data, err = ReadFileInto(name, buf)
+	if err != nil {
+		t.Fatalf("ReadFileInto: %v", err)
+	}
+	if string(data) != "bye" {
+		t.Errorf("ReadFileInto = %q, want %q", data, "bye")
+	}
+	if &data[0] != &buf[0] {
+		t.Errorf("ReadFileInto did not reuse a buffer with enough capacity")
+	}
+
+	if _, err := ReadFileInto(filepath.Join(t.TempDir(), "missing"), buf); !IsNotExist(err) {
+		t.Errorf("ReadFileInto of missing file = %v, want not-exist error", err)
+	}
+}
+
 func TestWriteFile(t *testing.T) {
 	t.Parallel()
 
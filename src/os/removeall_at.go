@@ -12,7 +12,7 @@ import (
 	"syscall"
 )
 
-func removeAll(path string) error {
+func removeAll(st *removeAllState, path string) error {
 	if path == "" {
 		// fail silently to retain compatibility with previous behavior
 		// of RemoveAll. See issue 28830.
@@ -27,7 +27,11 @@ func removeAll(path string) error {
 
 	// Simple case: if Remove works, we're done.
 	err := Remove(path)
-	if err == nil || IsNotExist(err) {
+	if err == nil {
+		st.done(path)
+		return nil
+	}
+	if IsNotExist(err) {
 		return nil
 	}
 
@@ -53,7 +57,7 @@ func removeAll(path string) error {
 	}
 	defer parent.Close()
 
-	if err := removeAllFrom(sysfdType(parent.Fd()), base); err != nil {
+	if err := removeAllFrom(st, sysfdType(parent.Fd()), base, path); err != nil {
 		if pathErr, ok := err.(*PathError); ok {
 			pathErr.Path = parentDir + string(PathSeparator) + pathErr.Path
 			err = pathErr
@@ -63,10 +67,19 @@ func removeAll(path string) error {
 	return nil
 }
 
-func removeAllFrom(parentFd sysfdType, base string) error {
+// removeAllFrom removes base and its contents from the directory parentFd.
+// path is the name of base reported to st.progress.
+// If st.ctx is non-nil, removeAllFrom stops with st.ctx.Err() when it is done,
+// checking before reading each batch of directory entries and before
+// removing each entry.
+func removeAllFrom(st *removeAllState, parentFd sysfdType, base, path string) error {
 	// Simple case: if Unlink (aka remove) works, we're done.
 	err := removefileat(parentFd, base)
-	if err == nil || IsNotExist(err) {
+	if err == nil {
+		st.done(path)
+		return nil
+	}
+	if IsNotExist(err) {
 		return nil
 	}
 
@@ -104,6 +117,10 @@ func removeAllFrom(parentFd sysfdType, base string) error {
 		for {
 			numErr := 0
 
+			if err := st.ctxErr(); err != nil {
+				file.Close()
+				return err
+			}
 			names, readErr := file.Readdirnames(reqSize)
 			// Errors other than EOF should stop us from continuing.
 			if readErr != nil && readErr != io.EOF {
@@ -116,7 +133,15 @@ func removeAllFrom(parentFd sysfdType, base string) error {
 
 			respSize = len(names)
 			for _, name := range names {
-				err := removeAllFrom(sysfdType(file.Fd()), name)
+				if err := st.ctxErr(); err != nil {
+					file.Close()
+					return err
+				}
+				var namePath string
+				if st.progress != nil {
+					namePath = path + string(PathSeparator) + name
+				}
+				err := removeAllFrom(st, sysfdType(file.Fd()), name, namePath)
 				if err != nil {
 					if pathErr, ok := err.(*PathError); ok {
 						pathErr.Path = base + string(PathSeparator) + pathErr.Path
@@ -150,7 +175,11 @@ func removeAllFrom(parentFd sysfdType, base string) error {
 
 	// Remove the directory itself.
 	unlinkError := removedirat(parentFd, base)
-	if unlinkError == nil || IsNotExist(unlinkError) {
+	if unlinkError == nil {
+		st.done(path)
+		return nil
+	}
+	if IsNotExist(unlinkError) {
 		return nil
 	}
 
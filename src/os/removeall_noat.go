@@ -12,7 +12,7 @@ import (
 	"syscall"
 )
 
-func removeAll(path string) error {
+func removeAll(st *removeAllState, path string) error {
 	if path == "" {
 		// fail silently to retain compatibility with previous behavior
 		// of RemoveAll. See issue 28830.
@@ -28,7 +28,11 @@ func removeAll(path string) error {
 
 	// Simple case: if Remove works, we're done.
 	err := Remove(path)
-	if err == nil || IsNotExist(err) {
+	if err == nil {
+		st.done(path)
+		return nil
+	}
+	if IsNotExist(err) {
 		return nil
 	}
 
@@ -63,10 +67,18 @@ func removeAll(path string) error {
 
 		for {
 			numErr := 0
+			if err := st.ctxErr(); err != nil {
+				fd.Close()
+				return err
+			}
 			names, readErr = fd.Readdirnames(reqSize)
 
 			for _, name := range names {
-				err1 := RemoveAll(path + string(PathSeparator) + name)
+				if err := st.ctxErr(); err != nil {
+					fd.Close()
+					return err
+				}
+				err1 := removeAll(st, path+string(PathSeparator)+name)
 				if err == nil {
 					err = err1
 				}
@@ -106,7 +118,11 @@ func removeAll(path string) error {
 		// succeeds, we are done.
 		if len(names) < reqSize {
 			err1 := Remove(path)
-			if err1 == nil || IsNotExist(err1) {
+			if err1 == nil {
+				st.done(path)
+				return nil
+			}
+			if IsNotExist(err1) {
 				return nil
 			}
 
@@ -125,7 +141,11 @@ func removeAll(path string) error {
 
 	// Remove directory.
 	err1 := Remove(path)
-	if err1 == nil || IsNotExist(err1) {
+	if err1 == nil {
+		st.done(path)
+		return nil
+	}
+	if IsNotExist(err1) {
 		return nil
 	}
 	if runtime.GOOS == "windows" && IsPermission(err1) {
@@ -135,6 +155,9 @@ func removeAll(path string) error {
 			}
 		}
 	}
+	if err1 == nil {
+		st.done(path)
+	}
 	if err == nil {
 		err = err1
 	}
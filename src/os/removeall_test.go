@@ -6,11 +6,14 @@ package os_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"internal/testenv"
 	. "os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -492,6 +495,79 @@ func TestRemoveAllTrailingSlash(t *testing.T) {
 	}
 }
 
+func TestRemoveAllContext(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "dir")
+	names := []string{"a/file1", "a/file2", "file3"}
+	create := func() {
+		for _, name := range names {
+			name = filepath.Join(dir, filepath.FromSlash(name))
+			if err := MkdirAll(filepath.Dir(name), 0o777); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteFile(name, nil, 0o666); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	create()
+
+	// A canceled context stops the removal before anything is removed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := RemoveAllContext(ctx, dir, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RemoveAllContext with canceled context = %v, want context.Canceled", err)
+	}
+	if _, ok := err.(*PathError); !ok {
+		t.Errorf("RemoveAllContext with canceled context returned %T, want *PathError", err)
+	}
+	if _, err := Stat(dir); err != nil {
+		t.Fatalf("RemoveAllContext with canceled context removed %s: %v", dir, err)
+	}
+
+	// Canceling the context stops the removal partway through.
+	ctx, cancel = context.WithCancel(context.Background())
+	err = RemoveAllContext(ctx, dir, &RemoveAllOptions{
+		Progress: func(int64, string) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RemoveAllContext canceled partway = %v, want context.Canceled", err)
+	}
+	if _, err := Stat(dir); err != nil {
+		t.Fatalf("RemoveAllContext canceled partway removed %s: %v", dir, err)
+	}
+	create()
+
+	var removed []string
+	var count int64
+	opts := &RemoveAllOptions{
+		Progress: func(n int64, path string) {
+			count = n
+			removed = append(removed, path)
+		},
+	}
+	if err := RemoveAllContext(context.Background(), dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Lstat(dir); !IsNotExist(err) {
+		t.Errorf("after RemoveAllContext, %s still exists", dir)
+	}
+	want := []string{dir, filepath.Join(dir, "a")}
+	for _, name := range names {
+		want = append(want, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+	slices.Sort(removed)
+	slices.Sort(want)
+	if !slices.Equal(removed, want) {
+		t.Errorf("Progress reported removing %q, want %q", removed, want)
+	}
+	if count != int64(len(want)) {
+		t.Errorf("Progress reported %d entries removed, want %d", count, len(want))
+	}
+}
+
 func BenchmarkRemoveAll(b *testing.B) {
 	tmpDir := filepath.Join(b.TempDir(), "target")
 	b.ReportAllocs()
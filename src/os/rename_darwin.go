@@ -0,0 +1,33 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func renameNoReplace(oldname, newname string) error {
+	err := ignoringEINTR(func() error {
+		return unix.Renameatx(unix.AT_FDCWD, oldname, unix.AT_FDCWD, newname, unix.RENAME_EXCL)
+	})
+	// Some file systems, such as those mounted over the network,
+	// do not support the flags.
+	if err == syscall.ENOTSUP {
+		return renameByLink(oldname, newname)
+	}
+	return err
+}
+
+func renameExchange(oldname, newname string) error {
+	err := ignoringEINTR(func() error {
+		return unix.Renameatx(unix.AT_FDCWD, oldname, unix.AT_FDCWD, newname, unix.RENAME_SWAP)
+	})
+	if err == syscall.ENOTSUP {
+		return errors.ErrUnsupported
+	}
+	return err
+}
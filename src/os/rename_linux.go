@@ -0,0 +1,33 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+func renameNoReplace(oldname, newname string) error {
+	err := ignoringEINTR(func() error {
+		return unix.Renameat2(unix.AT_FDCWD, oldname, unix.AT_FDCWD, newname, unix.RENAME_NOREPLACE)
+	})
+	// Kernels before 3.15 do not implement renameat2, and some file
+	// systems do not support its flags.
+	if err == syscall.ENOSYS || err == syscall.EINVAL {
+		return renameByLink(oldname, newname)
+	}
+	return err
+}
+
+func renameExchange(oldname, newname string) error {
+	err := ignoringEINTR(func() error {
+		return unix.Renameat2(unix.AT_FDCWD, oldname, unix.AT_FDCWD, newname, unix.RENAME_EXCHANGE)
+	})
+	if err == syscall.ENOSYS || err == syscall.EINVAL {
+		return errors.ErrUnsupported
+	}
+	return err
+}
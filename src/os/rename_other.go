@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package os
+
+import "errors"
+
+func renameNoReplace(oldname, newname string) error {
+	return errors.ErrUnsupported
+}
+
+func renameExchange(oldname, newname string) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+// renameByLink renames oldname to newname without replacing newname,
+// by linking oldname at newname, which fails if newname exists, and
+// then removing oldname.
+func renameByLink(oldname, newname string) error {
+	if err := ignoringEINTR(func() error {
+		return unix.Linkat(unix.AT_FDCWD, oldname, unix.AT_FDCWD, newname, 0)
+	}); err != nil {
+		return err
+	}
+	if err := ignoringEINTR(func() error {
+		return syscall.Unlink(oldname)
+	}); err != nil {
+		syscall.Unlink(newname)
+		return err
+	}
+	return nil
+}
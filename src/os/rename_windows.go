@@ -0,0 +1,29 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/windows"
+	"syscall"
+)
+
+func renameNoReplace(oldname, newname string) error {
+	from, err := syscall.UTF16PtrFromString(fixLongPath(oldname))
+	if err != nil {
+		return err
+	}
+	to, err := syscall.UTF16PtrFromString(fixLongPath(newname))
+	if err != nil {
+		return err
+	}
+	// Without MOVEFILE_REPLACE_EXISTING, MoveFileEx fails
+	// if newname exists.
+	return windows.MoveFileEx(from, to, 0)
+}
+
+func renameExchange(oldname, newname string) error {
+	return errors.ErrUnsupported
+}
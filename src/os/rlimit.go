@@ -0,0 +1,73 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// An RlimitResource identifies a system resource whose use by the
+// current process can be limited, as reported by [Getrlimit].
+type RlimitResource int
+
+const (
+	RlimitNofile RlimitResource = iota // number of open files
+	RlimitAS                           // size of the address space, in bytes
+	RlimitCore                         // size of a core file, in bytes
+	RlimitCPU                          // CPU time, in seconds
+	RlimitData                         // size of the data segment, in bytes
+	RlimitFsize                        // size of a created file, in bytes
+	RlimitStack                        // size of the main thread's stack, in bytes
+)
+
+// RlimitInfinity is the value of a limit that does not restrict the resource.
+const RlimitInfinity = ^uint64(0)
+
+// An Rlimit holds the limits of a resource.
+// The soft limit is the one that is enforced. An unprivileged process
+// may set the soft limit to any value up to the hard limit, and may lower
+// the hard limit, but not raise it.
+type Rlimit struct {
+	Cur uint64 // soft limit
+	Max uint64 // hard limit
+}
+
+// Getrlimit returns the limits of the resource for the current process.
+//
+// On Windows, only [RlimitNofile] is supported, and it is reported as
+// [RlimitInfinity], as Windows does not limit the number of open handles of
+// a process. On systems without resource limits, and for resources that
+// the system does not limit, Getrlimit returns an error wrapping
+// [errors.ErrUnsupported]. Any error will be of type [*SyscallError].
+func Getrlimit(resource RlimitResource) (Rlimit, error) {
+	return getrlimit(resource)
+}
+
+// Setrlimit sets the limits of the resource for the current process.
+// Limits are inherited by child processes.
+//
+// As with [syscall.Setrlimit], once [RlimitNofile] is set, child processes
+// inherit the new limit rather than the limit the program was started with.
+// On Windows, setting [RlimitNofile] has no effect.
+// Otherwise, Setrlimit is supported as described for [Getrlimit].
+func Setrlimit(resource RlimitResource, lim Rlimit) error {
+	return setrlimit(resource, lim)
+}
+
+// RaiseOpenFileLimit raises the soft limit on the number of open files
+// of the current process as far as the system allows, normally to the
+// hard limit, and returns the resulting limits.
+//
+// Go programs already raise the limit when they start, to a value that is
+// usually close to the hard limit, so RaiseOpenFileLimit is mostly useful
+// after the limit has been lowered or the hard limit has been raised.
+// Like [Setrlimit], it changes the limit inherited by child processes.
+func RaiseOpenFileLimit() (Rlimit, error) {
+	lim, err := getrlimit(RlimitNofile)
+	if err != nil || lim.Cur >= lim.Max {
+		return lim, err
+	}
+	lim.Cur = lim.Max
+	// Some systems, such as macOS, do not accept every value up to the
+	// hard limit. If so, the limit set at startup is left in place.
+	setrlimit(RlimitNofile, lim)
+	return getrlimit(RlimitNofile)
+}
@@ -0,0 +1,11 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix && !openbsd
+
+package os
+
+import "syscall"
+
+const rlimitAS = syscall.RLIMIT_AS
@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package os
+
+import "errors"
+
+func getrlimit(resource RlimitResource) (Rlimit, error) {
+	return Rlimit{}, NewSyscallError("getrlimit", errors.ErrUnsupported)
+}
+
+func setrlimit(resource RlimitResource, lim Rlimit) error {
+	return NewSyscallError("setrlimit", errors.ErrUnsupported)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"errors"
+	. "os"
+	"testing"
+)
+
+func TestRlimit(t *testing.T) {
+	lim, err := Getrlimit(RlimitNofile)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lim.Cur == 0 || lim.Cur > lim.Max {
+		t.Fatalf("Getrlimit(RlimitNofile) = %+v; want 0 < Cur <= Max", lim)
+	}
+
+	raised, err := RaiseOpenFileLimit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raised.Cur < lim.Cur || raised.Max != lim.Max {
+		t.Errorf("RaiseOpenFileLimit() = %+v; want at least %+v", raised, lim)
+	}
+
+	// Setting the current core file limit again must succeed.
+	core, err := Getrlimit(RlimitCore)
+	if errors.Is(err, errors.ErrUnsupported) {
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Setrlimit(RlimitCore, core); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Getrlimit(RlimitCore); err != nil || got != core {
+		t.Errorf("after Setrlimit(RlimitCore, %+v): Getrlimit = %+v, %v", core, got, err)
+	}
+
+	if _, err := Getrlimit(RlimitStack + 1); err == nil {
+		t.Errorf("Getrlimit succeeded with an invalid resource")
+	}
+}
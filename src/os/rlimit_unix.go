@@ -0,0 +1,88 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package os
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+)
+
+func rlimitResource(resource RlimitResource) (int, error) {
+	switch resource {
+	case RlimitNofile:
+		return syscall.RLIMIT_NOFILE, nil
+	case RlimitAS:
+		if rlimitAS < 0 {
+			return 0, errors.ErrUnsupported
+		}
+		return rlimitAS, nil
+	case RlimitCore:
+		return syscall.RLIMIT_CORE, nil
+	case RlimitCPU:
+		return syscall.RLIMIT_CPU, nil
+	case RlimitData:
+		return syscall.RLIMIT_DATA, nil
+	case RlimitFsize:
+		return syscall.RLIMIT_FSIZE, nil
+	case RlimitStack:
+		return syscall.RLIMIT_STACK, nil
+	}
+	return 0, syscall.EINVAL
+}
+
+// Depending on the system, syscall.Rlimit holds int64 or uint64 limits,
+// and RLIM_INFINITY is either the largest int64 or a value close to the
+// largest uint64.
+const maxRlimit = 1<<63 - 1
+
+func rlimitFromSys[T int64 | uint64](v T) uint64 {
+	if uint64(v) >= maxRlimit {
+		return RlimitInfinity
+	}
+	return uint64(v)
+}
+
+func rlimitToSys[T int64 | uint64](p *T, v uint64) {
+	if v >= maxRlimit {
+		switch runtime.GOOS {
+		case "linux", "android":
+			v = ^uint64(0)
+		case "solaris", "illumos":
+			v = ^uint64(2) // RLIM_INFINITY is -3
+		default:
+			v = maxRlimit
+		}
+	}
+	*p = T(v)
+}
+
+func getrlimit(resource RlimitResource) (Rlimit, error) {
+	r, err := rlimitResource(resource)
+	if err != nil {
+		return Rlimit{}, NewSyscallError("getrlimit", err)
+	}
+	var sl syscall.Rlimit
+	if err := syscall.Getrlimit(r, &sl); err != nil {
+		return Rlimit{}, NewSyscallError("getrlimit", err)
+	}
+	return Rlimit{Cur: rlimitFromSys(sl.Cur), Max: rlimitFromSys(sl.Max)}, nil
+}
+
+func setrlimit(resource RlimitResource, lim Rlimit) error {
+	r, err := rlimitResource(resource)
+	if err != nil {
+		return NewSyscallError("setrlimit", err)
+	}
+	var sl syscall.Rlimit
+	rlimitToSys(&sl.Cur, lim.Cur)
+	rlimitToSys(&sl.Max, lim.Max)
+	if err := syscall.Setrlimit(r, &sl); err != nil {
+		return NewSyscallError("setrlimit", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"syscall"
+)
+
+func getrlimit(resource RlimitResource) (Rlimit, error) {
+	switch resource {
+	case RlimitNofile:
+		return Rlimit{Cur: RlimitInfinity, Max: RlimitInfinity}, nil
+	case RlimitAS, RlimitCore, RlimitCPU, RlimitData, RlimitFsize, RlimitStack:
+		return Rlimit{}, NewSyscallError("getrlimit", errors.ErrUnsupported)
+	}
+	return Rlimit{}, NewSyscallError("getrlimit", syscall.EINVAL)
+}
+
+func setrlimit(resource RlimitResource, lim Rlimit) error {
+	if _, err := getrlimit(resource); err != nil {
+		return NewSyscallError("setrlimit", underlyingError(err))
+	}
+	return nil
+}
@@ -5,13 +5,16 @@
 package os
 
 import (
+	"context"
 	"errors"
 	"internal/bytealg"
+	"internal/itoa"
 	"internal/stringslite"
 	"internal/testlog"
 	"io/fs"
 	"runtime"
 	"slices"
+	"sync/atomic"
 	"time"
 )
 
@@ -35,7 +38,7 @@ func OpenInRoot(dir, name string) (*File, error) {
 //
 // Methods on Root can only access files and directories beneath a root directory.
 // If any component of a file name passed to a method of Root references a location
-// outside the root, the method returns an error.
+// outside the root, the method returns an error wrapping [ErrPathEscapes].
 // File names may reference the directory itself (.).
 //
 // Methods on Root will follow symbolic links, but symbolic links may not
@@ -55,6 +58,11 @@ func OpenInRoot(dir, name string) (*File, error) {
 //
 //   - When GOOS=windows, file names may not reference Windows reserved device names
 //     such as NUL and COM1.
+//   - When GOOS=windows, directory junctions (mount points) are reported by
+//     [Root.Lstat] in the same way as by [Lstat], and [Root.Readlink] returns
+//     their target. Since junction targets are always absolute, Root follows
+//     a junction only when its target names a location within the root,
+//     resolving the target like a relative symbolic link.
 //   - On Unix, [Root.Chmod], [Root.Chown], and [Root.Chtimes] are vulnerable to a race condition.
 //     If the target of the operation is changed from a regular file to a symlink
 //     while the operation is in progress, the operation may be performed on the link
@@ -67,6 +75,234 @@ func OpenInRoot(dir, name string) (*File, error) {
 //   - WASI preview 1 (GOOS=wasip1) does not support [Root.Chmod].
 type Root struct {
 	root *root
+	opts RootOptions
+
+	// ctx, if non-nil, is checked between the steps of each operation.
+	// See Root.withContext.
+	ctx context.Context
+}
+
+// ErrPathEscapes is returned, wrapped in a [*PathError] or [*LinkError],
+// by methods on [Root] when a file name references a location outside the root.
+// When the location of the escape is known, the wrapped error
+// is a [*PathEscapeError] describing it.
+var ErrPathEscapes = errors.New("path escapes from parent")
+
+// ErrRootGone is returned, wrapped in a [*PathError] or [*LinkError],
+// by methods on [Root] when the root directory itself has been removed,
+// for example because it was deleted or its file system was unmounted.
+// Reopening the root, if possible, may allow the operation to succeed.
+var ErrRootGone = errors.New("root directory has been removed")
+
+// ErrResolutionLimit is returned, wrapped in a [*PathError] or [*LinkError],
+// by methods on [Root] when resolving a file name takes too many steps,
+// typically because of a large number of ".." components.
+// The wrapped error is a [*ResolutionLimitError].
+var ErrResolutionLimit = errors.New("path resolution limit exceeded")
+
+// ResolutionLimitError records the work done resolving a file name
+// in a [Root] before the resolution limit was exceeded.
+type ResolutionLimitError struct {
+	Steps    int // number of path components resolved
+	Restarts int // number of restarts from the root caused by ".." components
+}
+
+func (e *ResolutionLimitError) Error() string {
+	return ErrResolutionLimit.Error() + " after " + itoa.Itoa(e.Steps) + " steps and " +
+		itoa.Itoa(e.Restarts) + " restarts"
+}
+
+// Unwrap returns ErrResolutionLimit.
+func (e *ResolutionLimitError) Unwrap() error { return ErrResolutionLimit }
+
+// PathEscapeError describes the path component which caused
+// a file name to reference a location outside a [Root].
+type PathEscapeError struct {
+	// Prefix is the part of the path which was resolved
+	// before the escape, relative to the root.
+	// It is empty if the escape occurred in the root directory itself.
+	Prefix string
+
+	// Component is the component of the path which escaped the root:
+	// either ".." or the name of a symbolic link.
+	Component string
+
+	// Target is the target of the symbolic link named by Component,
+	// or empty if Component is not a symbolic link.
+	Target string
+}
+
+func (e *PathEscapeError) Error() string {
+	s := ErrPathEscapes.Error() + ": " + e.Component
+	if e.Prefix != "" {
+		s += " in " + e.Prefix
+	}
+	if e.Target != "" {
+		s += " (symlink to " + e.Target + ")"
+	}
+	return s
+}
+
+// Unwrap returns ErrPathEscapes.
+func (e *PathEscapeError) Unwrap() error { return ErrPathEscapes }
+
+// RootPathError records how far resolution of a file name in a [Root]
+// progressed before an error occurred.
+// Methods on a Root opened with [RootOptions.TraceErrors] wrap the error
+// in a [*PathError] or [*LinkError] in a RootPathError when the failure
+// occurs after traversing one or more directories or following one or
+// more symbolic links.
+// Use [errors.As] to retrieve it.
+type RootPathError struct {
+	// Traversed is the list of directories successfully opened
+	// before the failure, in order, after replacing any symbolic links.
+	Traversed []string
+
+	// Component is the path component being resolved when the error occurred.
+	Component string
+
+	// Symlinks is the chain of symbolic links followed, in order.
+	Symlinks []RootSymlink
+
+	Err error
+}
+
+// RootSymlink is a symbolic link followed while resolving a file name in a [Root].
+type RootSymlink struct {
+	Path   string // path of the link, relative to the root
+	Target string // target of the link
+}
+
+// Error returns the message of the underlying error,
+// so that wrapping an error in a RootPathError does not change its text.
+func (e *RootPathError) Error() string { return e.Err.Error() }
+
+func (e *RootPathError) Unwrap() error { return e.Err }
+
+// joinParts is strings.Join(parts, string(PathSeparator)).
+func joinParts(parts []string) string {
+	var s string
+	for j, part := range parts {
+		if j > 0 {
+			s += string(PathSeparator)
+		}
+		s += part
+	}
+	return s
+}
+
+// pathEscapeError returns a *PathEscapeError for the component
+// parts[i], which has the symlink target link if non-empty.
+func pathEscapeError(parts []string, i int, link string) error {
+	return &PathEscapeError{Prefix: joinParts(parts[:i]), Component: parts[i], Target: link}
+}
+
+// RootOptions configures a [Root].
+//
+// A Root opened with [Root.OpenRoot] has the same options as its parent.
+type RootOptions struct {
+	// ReadOnly prevents modifying files through the Root.
+	// Methods which would modify the filesystem return an error
+	// wrapping [ErrPermission], as do attempts to open a file for writing.
+	//
+	// On WASI preview 1 (GOOS=wasip1), the rights of the directory's
+	// descriptor are also restricted, so that runtimes which enforce
+	// WASI rights will prevent modification.
+	ReadOnly bool
+
+	// WindowsBackupSemantics enables the SeBackupPrivilege and
+	// SeRestorePrivilege privileges while opening the root directory
+	// and while performing each operation on the Root.
+	// A process holding these privileges, such as a backup agent,
+	// may then access files and directories through the Root
+	// regardless of their access control lists.
+	// The privileges are enabled only on the thread performing
+	// the operation, and only for its duration.
+	// Opening the Root fails if the process holds neither privilege.
+	//
+	// It is ignored on other systems.
+	WindowsBackupSemantics bool
+
+	// WindowsChmodACL causes Root.Chmod to replace the access control
+	// list of the file as [ChmodACL] does, rather than only setting or
+	// clearing the read-only attribute.
+	//
+	// It is ignored on other systems.
+	WindowsChmodACL bool
+
+	// Trace, if non-nil, is called for each step taken while
+	// resolving a file name in the Root: each directory opened,
+	// each symbolic link followed, each restart of resolution
+	// from the root directory, and the operation performed on
+	// the final path component.
+	// Trace may be called concurrently by operations running
+	// in different goroutines.
+	//
+	// Trace is called synchronously, before the next step is taken.
+	// Tests of code built on Root may use it to modify the file system
+	// between steps, for example to replace a directory which has
+	// already been opened with a symbolic link, and so exercise
+	// races which are otherwise difficult to reproduce.
+	//
+	// When Trace is set, the Root does not use system calls which
+	// resolve an entire file name at once, so that every step is reported.
+	// Trace is not called on GOOS=js or GOOS=plan9.
+	//
+	// Setting GODEBUG=osrootdebug=1 logs the same steps
+	// for every Root to standard error.
+	Trace func(ResolveEvent)
+
+	// PlainOps causes errors returned by methods on the Root to report
+	// the Op used by the equivalent function in this package,
+	// such as "open" or "mkdir", rather than the name of the
+	// underlying system call, such as "openat" or "mkdirat".
+	// The name of the system call is kept in a [*SyscallError]
+	// wrapping the underlying error.
+	// This allows code which examines the Op of a [*PathError] to move
+	// from functions such as [Open] to methods on a Root.
+	PlainOps bool
+
+	// TrackFDs enables the accounting reported by [Root.ActiveFDs]
+	// of descriptors opened through the Root.
+	TrackFDs bool
+
+	// TraceErrors causes methods on the Root to record how far
+	// resolution of a file name progressed before an error occurred.
+	// The Err field of the [*PathError] or [*LinkError] returned
+	// is then a [*RootPathError] wrapping the underlying error,
+	// rather than the underlying error itself, so code which compares
+	// that field with an error such as [syscall.ENOENT] should use
+	// [errors.Is] instead.
+	// TraceErrors has no effect on GOOS=js or GOOS=plan9.
+	TraceErrors bool
+}
+
+// A ResolveEventKind is the kind of a [ResolveEvent].
+type ResolveEventKind int
+
+const (
+	ResolveOpen    ResolveEventKind = iota // a directory was opened
+	ResolveSymlink                         // a symbolic link was followed
+	ResolveRestart                         // resolution restarted from the root directory
+	ResolveFinal                           // the operation was performed on the final path component
+)
+
+// A ResolveEvent describes a step taken while resolving a file name
+// in a [Root]. See [RootOptions.Trace].
+type ResolveEvent struct {
+	Kind ResolveEventKind
+
+	// Path is the path of the component opened, followed, or operated on,
+	// relative to the root.
+	// For ResolveRestart events, it is the path which remains to be
+	// resolved from the root directory.
+	Path string
+
+	// Target is the target of the symbolic link, for ResolveSymlink events.
+	Target string
+
+	// Err is the error, if any, from opening or operating on the component.
+	Err error
 }
 
 const (
@@ -80,8 +316,51 @@ const (
 // It follows symbolic links in the directory name.
 // If there is an error, it will be of type [*PathError].
 func OpenRoot(name string) (*Root, error) {
+	return OpenRootWithOptions(name, nil)
+}
+
+// OpenRootWithOptions opens the named directory with the given options.
+// A nil opts is equivalent to the zero RootOptions.
+// If there is an error, it will be of type [*PathError].
+func OpenRootWithOptions(name string, opts *RootOptions) (*Root, error) {
 	testlog.Open(name)
-	return openRootNolog(name)
+	r, err := openRootNolog(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if err := restrictRoot(r); err != nil {
+		r.Close()
+		return nil, &PathError{Op: "open", Path: name, Err: err}
+	}
+	return r, nil
+}
+
+// TempRoot opens the default directory for temporary files, as returned
+// by [TempDir], as a [Root].
+//
+// The temporary directory is usually shared with other users, which makes
+// it a common target of symbolic link attacks. Creating and opening
+// temporary files through the Root, such as with [Root.CreateTemp] and
+// [Root.MkdirTemp], ensures that no operation escapes the directory.
+// If there is an error, it will be of type [*PathError].
+func TempRoot() (*Root, error) {
+	return OpenRoot(TempDir())
+}
+
+// Preopens returns a Root for each directory preopened by the host.
+//
+// On WASI preview 1 (GOOS=wasip1), a program may only access files within
+// directories which the WASI runtime makes available to it.
+// Preopens returns these directories, in the order provided by the runtime.
+// The name of each Root is the name assigned to the directory by the runtime.
+// Closing a Root returned by Preopens does not affect other file operations.
+//
+// On other platforms, Preopens returns [errors.ErrUnsupported].
+func Preopens() ([]*Root, error) {
+	return preopens()
 }
 
 // Name returns the name of the directory presented to OpenRoot.
@@ -91,6 +370,91 @@ func (r *Root) Name() string {
 	return r.root.Name()
 }
 
+// RootStats holds counters describing the file names resolved by a [Root].
+type RootStats struct {
+	Operations uint64 // number of file names resolved
+	Components uint64 // number of path components resolved
+	Restarts   uint64 // number of restarts from the root caused by ".." components
+	Symlinks   uint64 // number of symbolic links followed
+	Escapes    uint64 // number of file names rejected for escaping the root
+}
+
+// Stats returns a snapshot of the counters for the file names resolved by r
+// since it was opened.
+// Operations which access more than one file name, such as [Root.Rename],
+// count each name separately.
+// Components is not updated when the system resolves an entire
+// file name in a single call.
+func (r *Root) Stats() RootStats {
+	return r.root.stats.snapshot()
+}
+
+// rootStats is the implementation of Root.Stats.
+type rootStats struct {
+	operations atomic.Uint64
+	components atomic.Uint64
+	restarts   atomic.Uint64
+	symlinks   atomic.Uint64
+	escapes    atomic.Uint64
+}
+
+// record records the resolution of one file name,
+// which completed with err.
+func (s *rootStats) record(components, restarts, symlinks int, err error) {
+	s.operations.Add(1)
+	if components > 0 {
+		s.components.Add(uint64(components))
+	}
+	if restarts > 0 {
+		s.restarts.Add(uint64(restarts))
+	}
+	if symlinks > 0 {
+		s.symlinks.Add(uint64(symlinks))
+	}
+	if err != nil && errors.Is(err, ErrPathEscapes) {
+		s.escapes.Add(1)
+	}
+}
+
+func (s *rootStats) snapshot() RootStats {
+	return RootStats{
+		Operations: s.operations.Load(),
+		Components: s.components.Load(),
+		Restarts:   s.restarts.Load(),
+		Symlinks:   s.symlinks.Load(),
+		Escapes:    s.escapes.Load(),
+	}
+}
+
+// CanonicalName returns an absolute path naming the root directory.
+//
+// On Linux and Windows, CanonicalName derives the path from the open
+// directory itself, so it contains no symbolic links and reflects any
+// renaming of the directory since the Root was opened.
+// On other systems, CanonicalName returns [Root.Name] made absolute
+// relative to the current directory.
+// If there is an error, it will be of type [*PathError].
+func (r *Root) CanonicalName() (string, error) {
+	name, err := rootCanonicalName(r)
+	if err != nil {
+		return "", &PathError{Op: "canonicalname", Path: r.Name(), Err: err}
+	}
+	return name, nil
+}
+
+// ActiveFDs returns the number of file descriptors or handles
+// currently held open by r.
+// This includes the root directory itself, directories held open
+// by operations in progress, and files opened by [Root.OpenFile]
+// and related methods which have not yet been closed.
+//
+// Only the root directory itself is counted unless the Root was opened
+// with [RootOptions.TrackFDs] set.
+// On GOOS=js and GOOS=plan9, ActiveFDs returns 0.
+func (r *Root) ActiveFDs() int {
+	return rootActiveFDs(r)
+}
+
 // Close closes the Root.
 // After Close is called, methods on Root return errors.
 func (r *Root) Close() error {
@@ -115,29 +479,81 @@ func (r *Root) Create(name string) (*File, error) {
 // If perm contains bits other than the nine least-significant bits (0o777),
 // OpenFile returns an error.
 func (r *Root) OpenFile(name string, flag int, perm FileMode) (*File, error) {
+	return r.OpenFileWithOptions(name, flag, perm, nil)
+}
+
+// OpenFileWithOptions is like [Root.OpenFile], but accepts additional
+// platform-specific options. A nil opts is equivalent to the zero
+// [OpenFileOptions].
+func (r *Root) OpenFileWithOptions(name string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
 	if perm&0o777 != perm {
-		return nil, &PathError{Op: "openat", Path: name, Err: errors.New("unsupported file mode")}
+		return nil, r.plainOp(&PathError{Op: "openat", Path: name, Err: errors.New("unsupported file mode")})
+	}
+	if opts != nil && opts.TmpFile {
+		return nil, r.plainOp(&PathError{Op: "openat", Path: name, Err: errors.ErrUnsupported})
+	}
+	if flag&(O_WRONLY|O_RDWR|O_APPEND|O_CREATE|O_TRUNC) != 0 {
+		if err := r.checkWritable("openat", name); err != nil {
+			return nil, err
+		}
 	}
 	r.logOpen(name)
-	rf, err := rootOpenFileNolog(r, name, flag, perm)
+	rf, err := rootOpenFileNolog(r, name, flag, perm, opts)
 	if err != nil {
-		return nil, err
+		return nil, r.plainOp(err)
+	}
+	if r.opts.TrackFDs {
+		rootTrackFile(r, rf)
 	}
 	rf.appendMode = flag&O_APPEND != 0
 	return rf, nil
 }
 
+// CaseSensitive reports whether names of files in the root directory
+// are case-sensitive.
+//
+// On Windows, this reports the per-directory case sensitivity flag,
+// which may be set on NTFS directories shared with Linux.
+// On macOS, it reports whether the filesystem containing the root
+// is case-sensitive. On other systems, CaseSensitive reports true.
+//
+// Case sensitivity may vary between directories within a root.
+// Name lookups within a Root are performed by the operating system,
+// which always applies the sensitivity of the directory being searched.
+func (r *Root) CaseSensitive() (bool, error) {
+	sensitive, err := rootCaseSensitive(r)
+	if err != nil {
+		return false, &PathError{Op: "caseSensitive", Path: r.Name(), Err: err}
+	}
+	return sensitive, nil
+}
+
 // OpenRoot opens the named directory in the root.
 // If there is an error, it will be of type [*PathError].
 func (r *Root) OpenRoot(name string) (*Root, error) {
 	r.logOpen(name)
-	return openRootInRoot(r, name)
+	nr, err := openRootInRoot(r, name)
+	if err != nil {
+		return nil, r.plainOp(err)
+	}
+	nr.opts = r.opts
+	if err := restrictRoot(nr); err != nil {
+		nr.Close()
+		return nil, &PathError{Op: "openat", Path: name, Err: err}
+	}
+	return nr, nil
 }
 
 // Chmod changes the mode of the named file in the root to mode.
 // See [Chmod] for more details.
 func (r *Root) Chmod(name string, mode FileMode) error {
-	return rootChmod(r, name, mode)
+	if err := r.checkWritable("chmodat", name); err != nil {
+		return err
+	}
+	if r.opts.WindowsChmodACL {
+		return r.plainOp(rootChmodACL(r, name, mode))
+	}
+	return r.plainOp(rootChmod(r, name, mode))
 }
 
 // Mkdir creates a new directory in the root
@@ -148,9 +564,12 @@ func (r *Root) Chmod(name string, mode FileMode) error {
 // Mkdir returns an error.
 func (r *Root) Mkdir(name string, perm FileMode) error {
 	if perm&0o777 != perm {
-		return &PathError{Op: "mkdirat", Path: name, Err: errors.New("unsupported file mode")}
+		return r.plainOp(&PathError{Op: "mkdirat", Path: name, Err: errors.New("unsupported file mode")})
+	}
+	if err := r.checkWritable("mkdirat", name); err != nil {
+		return err
 	}
-	return rootMkdir(r, name, perm)
+	return r.plainOp(rootMkdir(r, name, perm))
 }
 
 // MkdirAll creates a new directory in the root, along with any necessary parents.
@@ -160,38 +579,94 @@ func (r *Root) Mkdir(name string, perm FileMode) error {
 // MkdirAll returns an error.
 func (r *Root) MkdirAll(name string, perm FileMode) error {
 	if perm&0o777 != perm {
-		return &PathError{Op: "mkdirat", Path: name, Err: errors.New("unsupported file mode")}
+		return r.plainOp(&PathError{Op: "mkdirat", Path: name, Err: errors.New("unsupported file mode")})
 	}
-	return rootMkdirAll(r, name, perm)
+	if err := r.checkWritable("mkdirat", name); err != nil {
+		return err
+	}
+	return r.plainOp(rootMkdirAll(r, name, perm))
 }
 
 // Chown changes the numeric uid and gid of the named file in the root.
 // See [Chown] for more details.
 func (r *Root) Chown(name string, uid, gid int) error {
-	return rootChown(r, name, uid, gid)
+	if err := r.checkWritable("chownat", name); err != nil {
+		return err
+	}
+	return r.plainOp(rootChown(r, name, uid, gid))
 }
 
 // Lchown changes the numeric uid and gid of the named file in the root.
 // See [Lchown] for more details.
 func (r *Root) Lchown(name string, uid, gid int) error {
-	return rootLchown(r, name, uid, gid)
+	if err := r.checkWritable("lchownat", name); err != nil {
+		return err
+	}
+	return r.plainOp(rootLchown(r, name, uid, gid))
 }
 
 // Chtimes changes the access and modification times of the named file in the root.
 // See [Chtimes] for more details.
 func (r *Root) Chtimes(name string, atime time.Time, mtime time.Time) error {
-	return rootChtimes(r, name, atime, mtime)
+	if err := r.checkWritable("chtimesat", name); err != nil {
+		return err
+	}
+	return r.plainOp(rootChtimes(r, name, atime, mtime))
+}
+
+// OpenContext is like [Root.Open], but stops resolving name and returns
+// an error wrapping ctx.Err() if ctx is done before the file is opened.
+func (r *Root) OpenContext(ctx context.Context, name string) (*File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, r.plainOp(&PathError{Op: "openat", Path: name, Err: err})
+	}
+	return r.withContext(ctx).Open(name)
+}
+
+// StatContext is like [Root.Stat], but stops resolving name and returns
+// an error wrapping ctx.Err() if ctx is done before the file is found.
+func (r *Root) StatContext(ctx context.Context, name string) (FileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, r.plainOp(&PathError{Op: "statat", Path: name, Err: err})
+	}
+	return r.withContext(ctx).Stat(name)
+}
+
+// RemoveAllContext is like [Root.RemoveAll], but stops and returns
+// an error wrapping ctx.Err() if ctx is done before the removal is complete.
+// Directories are read in batches, and ctx is checked before each batch,
+// so some entries may already have been removed when RemoveAllContext returns.
+func (r *Root) RemoveAllContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return &PathError{Op: "RemoveAll", Path: name, Err: err}
+	}
+	return r.withContext(ctx).RemoveAll(name)
+}
+
+// withContext returns a copy of r which checks ctx between the steps of
+// each operation. The copy shares r's underlying directory and must not
+// be closed.
+func (r *Root) withContext(ctx context.Context) *Root {
+	rc := *r
+	rc.ctx = ctx
+	return &rc
 }
 
 // Remove removes the named file or (empty) directory in the root.
 // See [Remove] for more details.
 func (r *Root) Remove(name string) error {
-	return rootRemove(r, name)
+	if err := r.checkWritable("removeat", name); err != nil {
+		return err
+	}
+	return r.plainOp(rootRemove(r, name))
 }
 
 // RemoveAll removes the named file or directory and any children that it contains.
 // See [RemoveAll] for more details.
 func (r *Root) RemoveAll(name string) error {
+	if err := r.checkWritable("RemoveAll", name); err != nil {
+		return err
+	}
 	return rootRemoveAll(r, name)
 }
 
@@ -199,7 +674,8 @@ func (r *Root) RemoveAll(name string) error {
 // See [Stat] for more details.
 func (r *Root) Stat(name string) (FileInfo, error) {
 	r.logStat(name)
-	return rootStat(r, name, false)
+	fi, err := rootStat(r, name, false)
+	return fi, r.plainOp(err)
 }
 
 // Lstat returns a [FileInfo] describing the named file in the root.
@@ -208,20 +684,42 @@ func (r *Root) Stat(name string) (FileInfo, error) {
 // See [Lstat] for more details.
 func (r *Root) Lstat(name string) (FileInfo, error) {
 	r.logStat(name)
-	return rootStat(r, name, true)
+	fi, err := rootStat(r, name, true)
+	if pe, ok := r.plainOp(err).(*PathError); ok && pe.Op == "stat" {
+		pe.Op = "lstat"
+	}
+	return fi, err
 }
 
 // Readlink returns the destination of the named symbolic link in the root.
 // See [Readlink] for more details.
 func (r *Root) Readlink(name string) (string, error) {
-	return rootReadlink(r, name)
+	target, err := rootReadlink(r, name)
+	return target, r.plainOp(err)
 }
 
 // Rename renames (moves) oldname to newname.
 // Both paths are relative to the root.
 // See [Rename] for more details.
 func (r *Root) Rename(oldname, newname string) error {
-	return rootRename(r, oldname, newname)
+	if r.opts.ReadOnly {
+		return r.plainOp(&LinkError{"renameat", oldname, newname, ErrPermission})
+	}
+	return r.plainOp(rootRename(r, oldname, newname))
+}
+
+// CopyFile copies the regular file src to a new file dst.
+// Both paths are relative to the root.
+// See [CopyFile] for more details.
+func (r *Root) CopyFile(src, dst string, opts *CopyFileOptions) error {
+	if err := r.checkWritable("copyfile", dst); err != nil {
+		return err
+	}
+	return copyFile(src, dst, opts, r.Open,
+		func(sf *File, dst string) (bool, error) {
+			return rootCloneFile(r, sf, dst)
+		},
+		r.OpenFile, r.Chmod, r.Remove)
 }
 
 // Link creates newname as a hard link to the oldname file.
@@ -233,7 +731,10 @@ func (r *Root) Rename(oldname, newname string) error {
 //
 // When GOOS=js, Link returns an error if oldname is a symbolic link.
 func (r *Root) Link(oldname, newname string) error {
-	return rootLink(r, oldname, newname)
+	if r.opts.ReadOnly {
+		return r.plainOp(&LinkError{"linkat", oldname, newname, ErrPermission})
+	}
+	return r.plainOp(rootLink(r, oldname, newname))
 }
 
 // Symlink creates newname as a symbolic link to oldname.
@@ -245,7 +746,10 @@ func (r *Root) Link(oldname, newname string) error {
 // On Windows, a directory link is created if oldname references
 // a directory within the root. Otherwise a file link is created.
 func (r *Root) Symlink(oldname, newname string) error {
-	return rootSymlink(r, oldname, newname)
+	if r.opts.ReadOnly {
+		return r.plainOp(&LinkError{"symlinkat", oldname, newname, ErrPermission})
+	}
+	return r.plainOp(rootSymlink(r, oldname, newname))
 }
 
 // ReadFile reads the named file in the root and returns its contents.
@@ -273,6 +777,136 @@ func (r *Root) WriteFile(name string, data []byte, perm FileMode) error {
 	return err
 }
 
+// CreateTemp creates a new temporary file in the directory dir within the
+// root, opens it for reading and writing, and returns the resulting file.
+// If dir is the empty string, the file is created in the root directory.
+// The file name is generated from pattern as described for [CreateTemp].
+// Unlike CreateTemp, the file cannot be created outside the root,
+// even if dir is a symbolic link or is replaced by one.
+func (r *Root) CreateTemp(dir, pattern string) (*File, error) {
+	prefix, suffix, err := prefixAndSuffix(pattern)
+	if err != nil {
+		return nil, &PathError{Op: "createtemp", Path: pattern, Err: err}
+	}
+	prefix = rootJoinPath(dir, prefix)
+
+	try := 0
+	for {
+		name := prefix + nextRandom() + suffix
+		f, err := r.OpenFile(name, O_RDWR|O_CREATE|O_EXCL, 0o600)
+		if IsExist(err) {
+			if try++; try < 10000 {
+				continue
+			}
+			return nil, &PathError{Op: "createtemp", Path: prefix + "*" + suffix, Err: ErrExist}
+		}
+		return f, err
+	}
+}
+
+// MkdirTemp creates a new temporary directory in the directory dir within
+// the root and returns its name, relative to the root.
+// If dir is the empty string, the directory is created in the root directory.
+// The directory name is generated from pattern as described for [MkdirTemp].
+func (r *Root) MkdirTemp(dir, pattern string) (string, error) {
+	prefix, suffix, err := prefixAndSuffix(pattern)
+	if err != nil {
+		return "", &PathError{Op: "mkdirtemp", Path: pattern, Err: err}
+	}
+	prefix = rootJoinPath(dir, prefix)
+
+	try := 0
+	for {
+		name := prefix + nextRandom() + suffix
+		err := r.Mkdir(name, 0o700)
+		if err == nil {
+			return name, nil
+		}
+		if IsExist(err) {
+			if try++; try < 10000 {
+				continue
+			}
+			return "", &PathError{Op: "mkdirtemp", Path: prefix + "*" + suffix, Err: ErrExist}
+		}
+		return "", err
+	}
+}
+
+// rootJoinPath joins a directory and a name within a root.
+func rootJoinPath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return joinPath(dir, name)
+}
+
+// checkWritable returns an error if r is read-only.
+func (r *Root) checkWritable(op, name string) error {
+	if r.opts.ReadOnly {
+		return r.plainOp(&PathError{Op: op, Path: name, Err: ErrPermission})
+	}
+	return nil
+}
+
+// plainOp rewrites the Op of err to that of the equivalent
+// non-Root function if r.opts.PlainOps is set.
+// The original Op is kept in a *SyscallError wrapping the error.
+func (r *Root) plainOp(err error) error {
+	if !r.opts.PlainOps {
+		return err
+	}
+	switch e := err.(type) {
+	case *PathError:
+		e.Op, e.Err = plainOpErr(e.Op, e.Err)
+	case *LinkError:
+		e.Op, e.Err = plainOpErr(e.Op, e.Err)
+	}
+	return err
+}
+
+// plainOpErr returns the Op reported by the non-Root function equivalent
+// to the Root operation op, and err wrapped in a *SyscallError naming op
+// if the two differ.
+func plainOpErr(op string, err error) (string, error) {
+	plain := plainOpName(op)
+	if plain == op {
+		return op, err
+	}
+	return plain, &SyscallError{Syscall: op, Err: err}
+}
+
+// plainOpName returns the Op reported by the non-Root function
+// equivalent to the Root operation op.
+func plainOpName(op string) string {
+	switch op {
+	case "openat", "openat2":
+		return "open"
+	case "statat":
+		return "stat"
+	case "chmodat":
+		return "chmod"
+	case "chownat":
+		return "chown"
+	case "lchownat":
+		return "lchown"
+	case "chtimesat":
+		return "chtimes"
+	case "mkdirat":
+		return "mkdir"
+	case "readlinkat":
+		return "readlink"
+	case "removeat":
+		return "remove"
+	case "renameat":
+		return "rename"
+	case "linkat":
+		return "link"
+	case "symlinkat":
+		return "symlink"
+	}
+	return op
+}
+
 func (r *Root) logOpen(name string) {
 	if log := testlog.Logger(); log != nil {
 		// This won't be right if r's name has changed since it was opened,
@@ -303,7 +937,7 @@ func splitPathInRoot(s string, prefix, suffix []string) (_ []string, suffixSep s
 		return nil, "", errors.New("empty path")
 	}
 	if IsPathSeparator(s[0]) {
-		return nil, "", errPathEscapes
+		return nil, "", ErrPathEscapes
 	}
 
 	if runtime.GOOS == "windows" {
@@ -433,6 +1067,99 @@ func (rfs *rootFS) Lstat(name string) (FileInfo, error) {
 	return r.Lstat(name)
 }
 
+// DirFSRooted returns a file system (an fs.FS) for the tree of files
+// rooted at the directory dir, like [DirFS], but which, like [Root.FS],
+// does not permit access to files outside the tree, including through
+// symbolic links.
+//
+// Each method call opens dir as a [Root] for the duration of the call,
+// so that, as with DirFS, the file system refers to whatever directory
+// is named dir at the time of the call. A program which makes many calls
+// may instead open a Root itself and use [Root.FS], which keeps the
+// directory open.
+//
+// The directory dir must not be "".
+//
+// The result implements [io/fs.StatFS], [io/fs.ReadFileFS],
+// [io/fs.ReadDirFS], and [io/fs.ReadLinkFS].
+func DirFSRooted(dir string) fs.FS {
+	return rootedDirFS(dir)
+}
+
+var _ fs.StatFS = rootedDirFS("")
+var _ fs.ReadFileFS = rootedDirFS("")
+var _ fs.ReadDirFS = rootedDirFS("")
+var _ fs.ReadLinkFS = rootedDirFS("")
+
+type rootedDirFS string
+
+// root opens dir for the operation op on name.
+// Errors report name, not dir, as with DirFS.
+func (dir rootedDirFS) root(op, name string) (*rootFS, error) {
+	if dir == "" {
+		return nil, &PathError{Op: op, Path: name, Err: ErrInvalid}
+	}
+	r, err := OpenRoot(string(dir))
+	if err != nil {
+		return nil, &PathError{Op: op, Path: name, Err: underlyingError(err)}
+	}
+	return (*rootFS)(r), nil
+}
+
+func (dir rootedDirFS) Open(name string) (fs.File, error) {
+	rfs, err := dir.root("open", name)
+	if err != nil {
+		return nil, err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.Open(name)
+}
+
+func (dir rootedDirFS) ReadDir(name string) ([]DirEntry, error) {
+	rfs, err := dir.root("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.ReadDir(name)
+}
+
+func (dir rootedDirFS) ReadFile(name string) ([]byte, error) {
+	rfs, err := dir.root("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.ReadFile(name)
+}
+
+func (dir rootedDirFS) ReadLink(name string) (string, error) {
+	rfs, err := dir.root("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.ReadLink(name)
+}
+
+func (dir rootedDirFS) Stat(name string) (FileInfo, error) {
+	rfs, err := dir.root("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.Stat(name)
+}
+
+func (dir rootedDirFS) Lstat(name string) (FileInfo, error) {
+	rfs, err := dir.root("lstat", name)
+	if err != nil {
+		return nil, err
+	}
+	defer (*Root)(rfs).Close()
+	return rfs.Lstat(name)
+}
+
 // isValidRootFSPath reports whether name is a valid filename to pass a Root.FS method.
 func isValidRootFSPath(name string) bool {
 	if !fs.ValidPath(name) {
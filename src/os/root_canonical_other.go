@@ -0,0 +1,21 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !windows
+
+package os
+
+import "internal/filepathlite"
+
+func rootCanonicalName(r *Root) (string, error) {
+	name := r.Name()
+	if filepathlite.IsAbs(name) {
+		return filepathlite.Clean(name), nil
+	}
+	wd, err := Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepathlite.Clean(joinPath(wd, name)), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+// rootCaseSensitive is Root.CaseSensitive.
+func rootCaseSensitive(r *Root) (bool, error) {
+	if err := r.root.incref(); err != nil {
+		return false, err
+	}
+	defer r.root.decref()
+	v, err := syscall.Fpathconf(r.root.fd, unix.PC_CASE_SENSITIVE)
+	if err != nil {
+		return false, err
+	}
+	return v != 0, nil
+}
+
+// rootOpenFileFast attempts to open name relative to the root
+// with a single openat call.
+//
+// O_NOFOLLOW_ANY causes the open to fail if any component of the path
+// is a symbolic link. A path containing no symbolic links and no ".."
+// components cannot escape the root, so when the open succeeds
+// no further validation is required.
+//
+// rootOpenFileFast reports false if the fast path could not be used
+// or the open failed for any reason, in which case the caller resolves
+// the path one component at a time. This produces the same errors as
+// the slow path, and follows any symbolic links which were encountered.
+func rootOpenFileFast(r *Root, name string, flag int, perm FileMode) (fd int, ok bool) {
+	if !rootPathIsLocalNoDotDot(name) {
+		return -1, false
+	}
+	if err := r.root.incref(); err != nil {
+		return -1, false
+	}
+	defer r.root.decref()
+	err := ignoringEINTR(func() error {
+		var err error
+		fd, err = unix.Openat(r.root.fd, name, unix.O_NOFOLLOW_ANY|syscall.O_CLOEXEC|flag, uint32(perm))
+		return err
+	})
+	if err != nil {
+		return -1, false
+	}
+	return fd, true
+}
+
+// rootStatFast reports false, indicating that the caller
+// must resolve name one path component at a time.
+func rootStatFast(r *Root, name string, lstat bool) (FileInfo, bool) {
+	return nil, false
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || freebsd
+
+package os_test
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+// TestRootFastPathFallback checks which names are opened and statted by
+// the single-call fast path, and that the rest fall back to resolving
+// the path one component at a time. TestRootFastPathConsistency checks
+// that both report the same results.
+func TestRootFastPathFallback(t *testing.T) {
+	dir := makefs(t, rootFastPathFS)
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	fd, ok := os.RootOpenFileFast(root, "f", os.O_RDONLY, 0)
+	if !ok {
+		t.Skip("fast path not supported")
+	}
+	syscall.Close(fd)
+
+	// On darwin, O_NOFOLLOW_ANY rejects every symlink, and there is no
+	// fast path for stat. On FreeBSD, O_RESOLVE_BENEATH permits symlinks
+	// which stay within the root.
+	inRootSymlinkFast := runtime.GOOS == "freebsd"
+	statFast := runtime.GOOS == "freebsd"
+	for _, test := range []struct {
+		name string
+		fast bool
+	}{
+		{"f", true},
+		{"link", inRootSymlinkFast},
+		{"dlink/../f", false},
+		{"escape", false},
+		{"abs", false},
+		{"d/../f", false},
+		{"..", false},
+		{"../outside", false},
+		{"missing", false},
+	} {
+		fd, ok := os.RootOpenFileFast(root, test.name, os.O_RDONLY, 0)
+		if ok {
+			syscall.Close(fd)
+		}
+		if ok != test.fast {
+			t.Errorf("rootOpenFileFast(%q) = %v, want %v", test.name, ok, test.fast)
+		}
+		_, ok = os.RootStatFast(root, test.name, false)
+		if want := test.fast && statFast; ok != want {
+			t.Errorf("rootStatFast(%q, false) = %v, want %v", test.name, ok, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build freebsd
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+// rootOpenFileFast attempts to open name relative to the root
+// with a single openat call.
+//
+// O_RESOLVE_BENEATH causes the kernel to fail the open if resolving
+// the path would leave the directory tree rooted at r, including via
+// a symbolic link or an absolute path. Paths containing ".." components
+// are left to the slow path, which resolves ".." lexically against the
+// components already walked rather than against the physical parent.
+//
+// rootOpenFileFast reports false if the fast path could not be used
+// or the open failed for any reason, in which case the caller resolves
+// the path one component at a time. This produces the same errors as
+// the slow path.
+func rootOpenFileFast(r *Root, name string, flag int, perm FileMode) (fd int, ok bool) {
+	if !unix.SupportResolveBeneath() || !rootPathIsLocalNoDotDot(name) {
+		return -1, false
+	}
+	if err := r.root.incref(); err != nil {
+		return -1, false
+	}
+	defer r.root.decref()
+	err := ignoringEINTR(func() error {
+		var err error
+		fd, err = unix.Openat(r.root.fd, name, unix.O_RESOLVE_BENEATH|syscall.O_CLOEXEC|flag, uint32(perm))
+		return err
+	})
+	if err != nil {
+		return -1, false
+	}
+	return fd, true
+}
+
+// rootStatFast attempts to stat name relative to the root
+// with a single fstatat call using AT_RESOLVE_BENEATH.
+//
+// As with rootOpenFileFast, it reports false if the fast path could not
+// be used or the stat failed for any reason.
+func rootStatFast(r *Root, name string, lstat bool) (FileInfo, bool) {
+	if !unix.SupportResolveBeneath() || !rootPathIsLocalNoDotDot(name) {
+		return nil, false
+	}
+	if err := r.root.incref(); err != nil {
+		return nil, false
+	}
+	defer r.root.decref()
+	flags := unix.AT_RESOLVE_BENEATH
+	if lstat {
+		flags |= unix.AT_SYMLINK_NOFOLLOW
+	}
+	var fs fileStat
+	err := ignoringEINTR(func() error {
+		return unix.Fstatat(r.root.fd, name, &fs.sys, flags)
+	})
+	if err != nil {
+		return nil, false
+	}
+	fillFileStatFromSys(&fs, name)
+	return &fs, true
+}
@@ -7,7 +7,6 @@
 package os
 
 import (
-	"errors"
 	"slices"
 	"syscall"
 )
@@ -29,28 +28,35 @@ func checkPathEscapesLstat(r *Root, name string) error {
 	return checkPathEscapesInternal(r, name, true)
 }
 
-func checkPathEscapesInternal(r *Root, name string, lstat bool) error {
+func checkPathEscapesInternal(r *Root, name string, lstat bool) (err error) {
 	if r.root.closed.Load() {
 		return ErrClosed
 	}
 	parts, suffixSep, err := splitPathInRoot(name, nil, nil)
 	if err != nil {
+		r.root.stats.record(0, 0, 0, err)
 		return err
 	}
 
 	i := 0
+	steps := 0
+	restarts := 0
 	symlinks := 0
+	defer func() {
+		r.root.stats.record(steps, restarts, symlinks, err)
+	}()
 	base := r.root.name
 	for i < len(parts) {
 		if parts[i] == ".." {
 			// Resolve one or more parent ("..") path components.
+			restarts++
 			end := i + 1
 			for end < len(parts) && parts[end] == ".." {
 				end++
 			}
 			count := end - i
 			if count > i {
-				return errPathEscapes
+				return pathEscapeError(parts, i, "")
 			}
 			parts = slices.Delete(parts, i-count, end)
 			i -= count
@@ -61,15 +67,14 @@ func checkPathEscapesInternal(r *Root, name string, lstat bool) error {
 			continue
 		}
 
-		part := parts[i]
-		if i == len(parts)-1 {
-			if lstat {
-				break
-			}
-			part += suffixSep
+		if i == len(parts)-1 && lstat && suffixSep == "" {
+			break
 		}
 
-		next := joinPath(base, part)
+		// Look up the component without any trailing separators,
+		// which would cause Lstat to follow a symlink.
+		steps++
+		next := joinPath(base, parts[i])
 		fi, err := Lstat(next)
 		if err != nil {
 			if IsNotExist(err) {
@@ -80,17 +85,20 @@ func checkPathEscapesInternal(r *Root, name string, lstat bool) error {
 		if fi.Mode()&ModeSymlink != 0 {
 			link, err := Readlink(next)
 			if err != nil {
-				return errPathEscapes
+				return ErrPathEscapes
 			}
 			symlinks++
 			if symlinks > rootMaxSymlinks {
-				return errors.New("too many symlinks")
+				return syscall.ELOOP
 			}
 			newparts, newSuffixSep, err := splitPathInRoot(link, parts[:i], parts[i+1:])
 			if err != nil {
+				if err == ErrPathEscapes {
+					err = pathEscapeError(parts, i, link)
+				}
 				return err
 			}
-			if i == len(parts) {
+			if i == len(parts)-1 {
 				// suffixSep contains any trailing path separator characters
 				// in the link target.
 				// If we are replacing the remainder of the path, retain these.
@@ -102,7 +110,7 @@ func checkPathEscapesInternal(r *Root, name string, lstat bool) error {
 			parts = newparts
 			continue
 		}
-		if !fi.IsDir() && i < len(parts)-1 {
+		if !fi.IsDir() && (i < len(parts)-1 || suffixSep != "") {
 			return syscall.ENOTDIR
 		}
 
@@ -0,0 +1,134 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/filepathlite"
+	"internal/itoa"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+// ResolveFlags control how [Root.OpenFileWithResolve] resolves a path.
+// They correspond to the RESOLVE_* flags of openat2(2).
+//
+// ResolveFlags are only available on Linux.
+type ResolveFlags uint64
+
+const (
+	// ResolveNoXdev disallows traversal of mount points,
+	// including bind mounts.
+	ResolveNoXdev ResolveFlags = unix.RESOLVE_NO_XDEV
+	// ResolveNoMagicLinks disallows traversal of procfs-style magic links,
+	// such as /proc/self/fd/N.
+	ResolveNoMagicLinks ResolveFlags = unix.RESOLVE_NO_MAGICLINKS
+	// ResolveNoSymlinks disallows traversal of symbolic links,
+	// including magic links.
+	ResolveNoSymlinks ResolveFlags = unix.RESOLVE_NO_SYMLINKS
+	// ResolveCached requires that the path be resolved entirely from the
+	// kernel's lookup caches. If it cannot be, the open fails with EAGAIN
+	// and the caller should retry without ResolveCached.
+	ResolveCached ResolveFlags = unix.RESOLVE_CACHED
+)
+
+// OpenFileWithResolve is like [Root.OpenFile], but resolves name with a
+// single openat2(2) call using the given resolve flags in addition to
+// RESOLVE_BENEATH, which confines resolution to the root.
+//
+// Unlike other Root methods, OpenFileWithResolve leaves path resolution
+// entirely to the kernel: ".." components are resolved against the
+// physical parent directory rather than lexically.
+// As the path is resolved in a single step, errors are never wrapped in a
+// [RootPathError], and [RootOptions.Trace] reports a single [ResolveFinal]
+// event for the whole path. A path which escapes the root is reported as
+// [ErrPathEscapes], unless resolve includes [ResolveNoXdev], in which case
+// the kernel's EXDEV may also mean that a mount point was crossed.
+// The root's other options apply as they do to [Root.OpenFile].
+//
+// If the kernel does not support openat2 (Linux 5.6 and later) or
+// one of the requested flags, the returned error wraps [errors.ErrUnsupported]
+// or EINVAL.
+//
+// OpenFileWithResolve is only available on Linux.
+func (r *Root) OpenFileWithResolve(name string, flag int, perm FileMode, resolve ResolveFlags) (*File, error) {
+	if perm&0o777 != perm {
+		return nil, r.plainOp(&PathError{Op: "openat2", Path: name, Err: errors.New("unsupported file mode")})
+	}
+	if flag&(O_WRONLY|O_RDWR|O_APPEND|O_CREATE|O_TRUNC) != 0 {
+		if err := r.checkWritable("openat2", name); err != nil {
+			return nil, err
+		}
+	}
+	r.logOpen(name)
+	fd, err := rootOpenat2(r, name, flag, perm, resolve)
+	if err != nil {
+		return nil, r.plainOp(&PathError{Op: "openat2", Path: name, Err: err})
+	}
+	f := newFile(fd, joinPath(r.Name(), name), kindOpenFile, unix.HasNonblockFlag(flag))
+	if r.opts.TrackFDs {
+		rootTrackFile(r, f)
+	}
+	f.appendMode = flag&O_APPEND != 0
+	return f, nil
+}
+
+// rootOpenat2 opens name in r with a single openat2 call,
+// recording and tracing the operation as doInRoot does.
+func rootOpenat2(r *Root, name string, flag int, perm FileMode, resolve ResolveFlags) (fd int, err error) {
+	if err := r.root.incref(); err != nil {
+		return -1, err
+	}
+	defer r.root.decref()
+	if r.ctx != nil {
+		if err := r.ctx.Err(); err != nil {
+			return -1, err
+		}
+	}
+	how := unix.OpenHow{
+		Flags:   uint64(flag | syscall.O_CLOEXEC | syscall.O_LARGEFILE),
+		Resolve: uint64(resolve) | unix.RESOLVE_BENEATH,
+	}
+	if flag&O_CREATE != 0 {
+		// openat2 rejects a non-zero mode unless a file may be created.
+		how.Mode = uint64(syscallMode(perm))
+	}
+	err = ignoringEINTR(func() error {
+		var err error
+		fd, err = unix.Openat2(r.root.fd, name, &how)
+		return err
+	})
+	switch {
+	case err == syscall.EXDEV && resolve&ResolveNoXdev == 0:
+		// RESOLVE_BENEATH reports EXDEV for a path which escapes the root.
+		err = ErrPathEscapes
+	case err != nil && rootGone(r.root.fd, err):
+		err = ErrRootGone
+	}
+	if r.tracing() {
+		r.traceEvent(ResolveEvent{Kind: ResolveFinal, Path: name, Err: err}, r.root.fd)
+	}
+	r.root.stats.record(0, 0, 0, err)
+	return fd, err
+}
+
+func rootCanonicalName(r *Root) (string, error) {
+	if err := r.root.incref(); err != nil {
+		return "", err
+	}
+	defer r.root.decref()
+	name, err := Readlink("/proc/self/fd/" + itoa.Itoa(r.root.fd))
+	if err != nil {
+		return "", underlyingError(err)
+	}
+	if !filepathlite.IsAbs(name) {
+		// The directory has been removed, and the kernel
+		// reports a name such as "/path (deleted)",
+		// or the file system is not reachable from our root,
+		// and the kernel reports a name such as "anon_inode:...".
+		return "", ErrNotExist
+	}
+	return name, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestRootOpenFileWithResolve(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("file", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("..", filepath.Join(dir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	f, err := root.OpenFileWithResolve("link", os.O_RDONLY, 0, 0)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("openat2 not supported: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("OpenFileWithResolve(link, 0) = %v, want success", err)
+	}
+	got := make([]byte, 4)
+	if _, err := f.Read(got); err != nil || string(got) != "data" {
+		t.Errorf("Read = %q, %v; want %q", got, err, "data")
+	}
+	f.Close()
+
+	if f, err := root.OpenFileWithResolve("link", os.O_RDONLY, 0, os.ResolveNoSymlinks); err == nil {
+		f.Close()
+		t.Errorf("OpenFileWithResolve(link, ResolveNoSymlinks) succeeded, want ELOOP")
+	} else if !errors.Is(err, syscall.ELOOP) {
+		t.Errorf("OpenFileWithResolve(link, ResolveNoSymlinks) = %v, want ELOOP", err)
+	}
+	if f, err := root.OpenFileWithResolve("escape/x", os.O_RDONLY, 0, 0); err == nil {
+		f.Close()
+		t.Errorf("OpenFileWithResolve(escape/x) succeeded, want ErrPathEscapes")
+	} else if !errors.Is(err, os.ErrPathEscapes) {
+		t.Errorf("OpenFileWithResolve(escape/x) = %v, want ErrPathEscapes", err)
+	}
+
+	f, err = root.OpenFileWithResolve("new", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600, os.ResolveNoXdev)
+	if err != nil {
+		t.Fatalf("OpenFileWithResolve(new, O_CREATE) = %v, want success", err)
+	}
+	f.Close()
+	if fi, err := os.Stat(filepath.Join(dir, "new")); err != nil {
+		t.Fatal(err)
+	} else if perm := fi.Mode().Perm(); perm&^0o600 != 0 {
+		t.Errorf("created file mode = %v, want subset of 0600", perm)
+	}
+}
+
+func TestRootOpenFileWithResolveOptions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	var events []os.ResolveEvent
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		Trace:    func(ev os.ResolveEvent) { events = append(events, ev) },
+		PlainOps: true,
+		TrackFDs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	f, err := root.OpenFileWithResolve("file", os.O_RDONLY, 0, 0)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("openat2 not supported: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := root.ActiveFDs(), 2; got != want {
+		t.Errorf("ActiveFDs with file open = %v, want %v", got, want)
+	}
+	f.Close()
+	if got, want := root.ActiveFDs(), 1; got != want {
+		t.Errorf("ActiveFDs after file closed = %v, want %v", got, want)
+	}
+
+	_, err = root.OpenFileWithResolve("missing", os.O_RDONLY, 0, 0)
+	var pe *os.PathError
+	if !errors.As(err, &pe) || pe.Op != "open" || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OpenFileWithResolve(missing) = %v, want PathError with Op \"open\" and ErrNotExist", err)
+	}
+
+	if len(events) != 2 || events[0].Kind != os.ResolveFinal || events[0].Path != "file" || events[1].Path != "missing" || events[1].Err == nil {
+		t.Errorf("trace events = %+v, want a ResolveFinal event for each open", events)
+	}
+	if got := root.Stats().Operations; got != 2 {
+		t.Errorf("Stats().Operations = %v, want 2", got)
+	}
+}
+
+func TestRootMountID(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	rid, err := root.MountID()
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("statx mount ID not supported: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Root.MountID() = %v", err)
+	}
+
+	f, err := root.Open("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if fid, err := f.MountID(); err != nil || fid != rid {
+		t.Errorf("File.MountID() = %v, %v; want %v (the root's mount)", fid, err, rid)
+	}
+	if same, err := root.SameMount(f); err != nil || !same {
+		t.Errorf("Root.SameMount(file in root) = %v, %v; want true", same, err)
+	}
+
+	// /proc is always a separate mount from any temporary directory.
+	proc, err := os.Open("/proc/self")
+	if err != nil {
+		t.Skipf("opening /proc: %v", err)
+	}
+	defer proc.Close()
+	if same, err := root.SameMount(proc); err != nil || same {
+		t.Errorf("Root.SameMount(/proc/self) = %v, %v; want false", same, err)
+	}
+
+	f.Close()
+	if _, err := f.MountID(); err == nil {
+		t.Errorf("File.MountID() after Close succeeded, want error")
+	}
+}
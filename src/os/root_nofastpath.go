@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (unix && !darwin && !freebsd) || wasip1
+
+package os
+
+// rootOpenFileFast reports false, indicating that the caller
+// must resolve name one path component at a time.
+func rootOpenFileFast(r *Root, name string, flag int, perm FileMode) (fd int, ok bool) {
+	return -1, false
+}
+
+// rootStatFast reports false, indicating that the caller
+// must resolve name one path component at a time.
+func rootStatFast(r *Root, name string, lstat bool) (FileInfo, bool) {
+	return nil, false
+}
@@ -20,10 +20,12 @@ import (
 type root struct {
 	name   string
 	closed atomic.Bool
+
+	stats rootStats
 }
 
 // openRootNolog is OpenRoot.
-func openRootNolog(name string) (*Root, error) {
+func openRootNolog(name string, _ *RootOptions) (*Root, error) {
 	r, err := newRoot(name)
 	if err != nil {
 		return nil, &PathError{Op: "open", Path: name, Err: err}
@@ -53,7 +55,7 @@ func newRoot(name string) (*Root, error) {
 	if !fi.IsDir() {
 		return nil, errors.New("not a directory")
 	}
-	return &Root{&root{name: name}}, nil
+	return &Root{root: &root{name: name}}, nil
 }
 
 func (r *root) Close() error {
@@ -67,14 +69,36 @@ func (r *root) Name() string {
 	return r.name
 }
 
+// rootErr returns the underlying error of err, or ErrRootGone if err
+// reports a missing file and the root directory itself no longer exists.
+// It is only called once an operation has failed, so successful
+// operations cost nothing extra.
+func rootErr(r *Root, err error) error {
+	err = underlyingError(err)
+	if IsNotExist(err) {
+		if _, serr := Stat(r.root.name); IsNotExist(serr) {
+			return ErrRootGone
+		}
+	}
+	return err
+}
+
+// rootCaseSensitive is Root.CaseSensitive.
+func rootCaseSensitive(r *Root) (bool, error) {
+	if r.root.closed.Load() {
+		return false, ErrClosed
+	}
+	return true, nil
+}
+
 // rootOpenFileNolog is Root.OpenFile.
-func rootOpenFileNolog(r *Root, name string, flag int, perm FileMode) (*File, error) {
+func rootOpenFileNolog(r *Root, name string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
 	if err := checkPathEscapes(r, name); err != nil {
 		return nil, &PathError{Op: "openat", Path: name, Err: err}
 	}
-	f, err := openFileNolog(joinPath(r.root.name, name), flag, perm)
+	f, err := openFileNolog(joinPath(r.root.name, name), flag, perm, opts)
 	if err != nil {
-		return nil, &PathError{Op: "openat", Path: name, Err: underlyingError(err)}
+		return nil, &PathError{Op: "openat", Path: name, Err: rootErr(r, err)}
 	}
 	return f, nil
 }
@@ -94,7 +118,7 @@ func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
 		}
 	}
 	if err != nil {
-		return nil, &PathError{Op: "statat", Path: name, Err: underlyingError(err)}
+		return nil, &PathError{Op: "statat", Path: name, Err: rootErr(r, err)}
 	}
 	return fi, nil
 }
@@ -104,7 +128,7 @@ func rootChmod(r *Root, name string, mode FileMode) error {
 		return &PathError{Op: "chmodat", Path: name, Err: err}
 	}
 	if err := Chmod(joinPath(r.root.name, name), mode); err != nil {
-		return &PathError{Op: "chmodat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "chmodat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -114,7 +138,7 @@ func rootChown(r *Root, name string, uid, gid int) error {
 		return &PathError{Op: "chownat", Path: name, Err: err}
 	}
 	if err := Chown(joinPath(r.root.name, name), uid, gid); err != nil {
-		return &PathError{Op: "chownat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "chownat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -124,7 +148,7 @@ func rootLchown(r *Root, name string, uid, gid int) error {
 		return &PathError{Op: "lchownat", Path: name, Err: err}
 	}
 	if err := Lchown(joinPath(r.root.name, name), uid, gid); err != nil {
-		return &PathError{Op: "lchownat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "lchownat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -134,7 +158,7 @@ func rootChtimes(r *Root, name string, atime time.Time, mtime time.Time) error {
 		return &PathError{Op: "chtimesat", Path: name, Err: err}
 	}
 	if err := Chtimes(joinPath(r.root.name, name), atime, mtime); err != nil {
-		return &PathError{Op: "chtimesat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "chtimesat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -144,18 +168,18 @@ func rootMkdir(r *Root, name string, perm FileMode) error {
 		return &PathError{Op: "mkdirat", Path: name, Err: err}
 	}
 	if err := Mkdir(joinPath(r.root.name, name), perm); err != nil {
-		return &PathError{Op: "mkdirat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "mkdirat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
 
 func rootMkdirAll(r *Root, name string, perm FileMode) error {
-	// We only check for errPathEscapes here.
+	// We only check for ErrPathEscapes here.
 	// For errors such as ENOTDIR (a non-directory file appeared somewhere along the path),
 	// we let MkdirAll generate the error.
 	// MkdirAll will return a PathError referencing the exact location of the error,
 	// and we want to preserve that property.
-	if err := checkPathEscapes(r, name); err == errPathEscapes {
+	if err := checkPathEscapes(r, name); errors.Is(err, ErrPathEscapes) {
 		return &PathError{Op: "mkdirat", Path: name, Err: err}
 	}
 	prefix := r.root.name + string(PathSeparator)
@@ -165,7 +189,7 @@ func rootMkdirAll(r *Root, name string, perm FileMode) error {
 			pe.Path = stringslite.TrimPrefix(pe.Path, prefix)
 			return pe
 		}
-		return &PathError{Op: "mkdirat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "mkdirat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -177,11 +201,11 @@ func rootRemove(r *Root, name string) error {
 	if endsWithDot(name) {
 		// We don't want to permit removing the root itself, so check for that.
 		if filepathlite.Clean(name) == "." {
-			return &PathError{Op: "removeat", Path: name, Err: errPathEscapes}
+			return &PathError{Op: "removeat", Path: name, Err: ErrPathEscapes}
 		}
 	}
 	if err := Remove(joinPath(r.root.name, name)); err != nil {
-		return &PathError{Op: "removeat", Path: name, Err: underlyingError(err)}
+		return &PathError{Op: "removeat", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -199,8 +223,8 @@ func rootRemoveAll(r *Root, name string) error {
 		}
 		return &PathError{Op: "RemoveAll", Path: name, Err: err}
 	}
-	if err := RemoveAll(joinPath(r.root.name, name)); err != nil {
-		return &PathError{Op: "RemoveAll", Path: name, Err: underlyingError(err)}
+	if err := removeAll(&removeAllState{ctx: r.ctx}, joinPath(r.root.name, name)); err != nil {
+		return &PathError{Op: "RemoveAll", Path: name, Err: rootErr(r, err)}
 	}
 	return nil
 }
@@ -211,7 +235,7 @@ func rootReadlink(r *Root, name string) (string, error) {
 	}
 	name, err := Readlink(joinPath(r.root.name, name))
 	if err != nil {
-		return "", &PathError{Op: "readlinkat", Path: name, Err: underlyingError(err)}
+		return "", &PathError{Op: "readlinkat", Path: name, Err: rootErr(r, err)}
 	}
 	return name, nil
 }
@@ -225,7 +249,7 @@ func rootRename(r *Root, oldname, newname string) error {
 	}
 	err := Rename(joinPath(r.root.name, oldname), joinPath(r.root.name, newname))
 	if err != nil {
-		return &LinkError{"renameat", oldname, newname, underlyingError(err)}
+		return &LinkError{"renameat", oldname, newname, rootErr(r, err)}
 	}
 	return nil
 }
@@ -243,7 +267,7 @@ func rootLink(r *Root, oldname, newname string) error {
 	}
 	err := Link(fullOldName, joinPath(r.root.name, newname))
 	if err != nil {
-		return &LinkError{"linkat", oldname, newname, underlyingError(err)}
+		return &LinkError{"linkat", oldname, newname, rootErr(r, err)}
 	}
 	return nil
 }
@@ -254,7 +278,13 @@ func rootSymlink(r *Root, oldname, newname string) error {
 	}
 	err := Symlink(oldname, joinPath(r.root.name, newname))
 	if err != nil {
-		return &LinkError{"symlinkat", oldname, newname, underlyingError(err)}
+		return &LinkError{"symlinkat", oldname, newname, rootErr(r, err)}
 	}
 	return nil
 }
+
+func rootTrackFile(r *Root, f *File) {}
+
+func rootActiveFDs(r *Root) int {
+	return 0
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (unix && !darwin) || wasip1
+
+package os
+
+// rootCaseSensitive is Root.CaseSensitive.
+func rootCaseSensitive(r *Root) (bool, error) {
+	if err := r.root.incref(); err != nil {
+		return false, err
+	}
+	r.root.decref()
+	return true, nil
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !wasip1
+
+package os
+
+// restrictRoot applies platform-specific restrictions to r
+// based on its options.
+func restrictRoot(r *Root) error {
+	return nil
+}
@@ -7,9 +7,12 @@
 package os
 
 import (
+	"internal/godebug"
+	"internal/itoa"
 	"runtime"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -26,6 +29,44 @@ type root struct {
 	fd     sysfdType
 	refs   int  // number of active operations
 	closed bool // set when closed
+
+	stats rootStats
+
+	// fds is the number of descriptors opened through the root
+	// and not yet closed, when RootOptions.TrackFDs is set.
+	fds atomic.Int64
+
+	// openStack is the stack which opened the root,
+	// recorded when GODEBUG=osrootleak=1 or osrootleak=2 is set.
+	openStack []byte
+}
+
+// osrootleak reports Roots which are garbage collected without being closed.
+// osrootleak=1 logs each such Root to standard error;
+// osrootleak=2 crashes the program, for use in tests.
+var osrootleak = godebug.New("osrootleak")
+
+// setFinalizer arranges for r to be closed when it is garbage collected.
+func (r *root) setFinalizer() {
+	if v := osrootleak.Value(); v == "1" || v == "2" {
+		buf := make([]byte, 4096)
+		r.openStack = buf[:runtime.Stack(buf, false)]
+	}
+	runtime.SetFinalizer(r, (*root).finalize)
+}
+
+// finalize is the finalizer for a root which was never closed.
+func (r *root) finalize() {
+	if r.openStack != nil {
+		msg := "os: Root " + r.name + " was garbage collected without being closed; opened at:\n" + string(r.openStack)
+		switch osrootleak.Value() {
+		case "1":
+			print(msg)
+		case "2":
+			panic(msg)
+		}
+	}
+	r.Close()
 }
 
 func (r *root) Close() error {
@@ -106,7 +147,7 @@ func rootChtimes(r *Root, name string, atime time.Time, mtime time.Time) error {
 }
 
 func rootMkdir(r *Root, name string, perm FileMode) error {
-	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRootNoFollow(r, name, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, mkdirat(parent, name, perm)
 	})
 	if err != nil {
@@ -185,7 +226,7 @@ func rootReadlink(r *Root, name string) (string, error) {
 }
 
 func rootRemove(r *Root, name string) error {
-	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRootNoFollow(r, name, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, removeat(parent, name)
 	})
 	if err != nil {
@@ -205,7 +246,7 @@ func rootRemoveAll(r *Root, name string) error {
 		return &PathError{Op: "RemoveAll", Path: name, Err: syscall.EINVAL}
 	}
 	_, err := doInRoot(r, name, nil, func(parent sysfdType, name string) (struct{}, error) {
-		return struct{}{}, removeAllFrom(parent, name)
+		return struct{}{}, removeAllFrom(&removeAllState{ctx: r.ctx}, parent, name, name)
 	})
 	if IsNotExist(err) {
 		return nil
@@ -217,8 +258,8 @@ func rootRemoveAll(r *Root, name string) error {
 }
 
 func rootRename(r *Root, oldname, newname string) error {
-	_, err := doInRoot(r, oldname, nil, func(oldparent sysfdType, oldname string) (struct{}, error) {
-		_, err := doInRoot(r, newname, nil, func(newparent sysfdType, newname string) (struct{}, error) {
+	_, err := doInRootNoFollow(r, oldname, func(oldparent sysfdType, oldname string) (struct{}, error) {
+		_, err := doInRootNoFollow(r, newname, func(newparent sysfdType, newname string) (struct{}, error) {
 			return struct{}{}, renameat(oldparent, oldname, newparent, newname)
 		})
 		return struct{}{}, err
@@ -231,7 +272,7 @@ func rootRename(r *Root, oldname, newname string) error {
 
 func rootLink(r *Root, oldname, newname string) error {
 	_, err := doInRoot(r, oldname, nil, func(oldparent sysfdType, oldname string) (struct{}, error) {
-		_, err := doInRoot(r, newname, nil, func(newparent sysfdType, newname string) (struct{}, error) {
+		_, err := doInRootNoFollow(r, newname, func(newparent sysfdType, newname string) (struct{}, error) {
 			return struct{}{}, linkat(oldparent, oldname, newparent, newname)
 		})
 		return struct{}{}, err
@@ -260,25 +301,50 @@ func rootLink(r *Root, oldname, newname string) error {
 //
 // If f or openDirFunc return a *PathError, doInRoot will set PathError.Path to the
 // full path which caused the error.
+//
+// A final symlink named with a trailing separator is followed within the root.
+// Use doInRootNoFollow for operations which act on such a symlink itself.
 func doInRoot[T any](r *Root, name string, openDirFunc func(parent sysfdType, name string) (sysfdType, error), f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
+	return resolveInRoot(r, name, true, openDirFunc, f)
+}
+
+// doInRootNoFollow is doInRoot for operations, such as removing and renaming,
+// which the system performs on a final symlink itself, even when it is named
+// with a trailing separator. f is passed any trailing separators unchanged.
+func doInRootNoFollow[T any](r *Root, name string, f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
+	return resolveInRoot(r, name, false, nil, f)
+}
+
+// resolveInRoot implements doInRoot and doInRootNoFollow.
+func resolveInRoot[T any](r *Root, name string, followSep bool, openDirFunc func(parent sysfdType, name string) (sysfdType, error), f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
 	if err := r.root.incref(); err != nil {
 		return ret, err
 	}
 	defer r.root.decref()
 
+	if r.opts.WindowsBackupSemantics {
+		restore, err := enableBackupPrivileges()
+		if err != nil {
+			return ret, err
+		}
+		defer restore()
+	}
+
 	parts, suffixSep, err := splitPathInRoot(name, nil, nil)
 	if err != nil {
+		r.root.stats.record(0, 0, 0, err)
 		return ret, err
 	}
 	if openDirFunc == nil {
 		openDirFunc = rootOpenDir
 	}
 
+	tracing := r.tracing()
 	rootfd := r.root.fd
 	dirfd := rootfd
 	defer func() {
 		if dirfd != rootfd {
-			syscall.Close(dirfd)
+			r.closeDir(dirfd)
 		}
 	}()
 
@@ -295,11 +361,55 @@ func doInRoot[T any](r *Root, name string, openDirFunc func(parent sysfdType, na
 	steps := 0
 	restarts := 0
 	symlinks := 0
+	defer func() {
+		if err != nil && rootGone(r.root.fd, err) {
+			// The caller's file may well exist;
+			// it is the root which does not.
+			err = ErrRootGone
+		}
+		// Each ".." restart is counted as a step.
+		r.root.stats.record(steps-restarts, restarts, symlinks, err)
+	}()
+
+	// linkParts is the path of the symlink most recently followed,
+	// and linkTarget is its target.
+	// parts[linkStart:linkEnd] holds what remains of linkTarget in the path,
+	// so that a ".." component which escapes the root can be attributed to it.
+	var linkParts []string
+	var linkTarget string
+	linkStart, linkEnd := 0, 0
+
+	// links records the symlinks followed, for RootPathError.
+	var links []RootSymlink
+	// traceErr wraps err in a RootPathError if the Root traces errors
+	// and resolution failed after opening a directory or following a symlink.
+	traceErr := func(err error) error {
+		if !r.opts.TraceErrors || (i == 0 && len(links) == 0) {
+			return err
+		}
+		if _, ok := err.(*RootPathError); ok {
+			// Rename and Link resolve the new name within the
+			// operation on the old one. Keep the trace of the
+			// resolution which failed.
+			return err
+		}
+		return &RootPathError{
+			Traversed: slices.Clone(parts[:i]),
+			Component: parts[i],
+			Symlinks:  links,
+			Err:       err,
+		}
+	}
 Loop:
 	for {
+		if r.ctx != nil {
+			if err := r.ctx.Err(); err != nil {
+				return ret, err
+			}
+		}
 		steps++
 		if steps > maxSteps && restarts > maxRestarts {
-			return ret, syscall.ENAMETOOLONG
+			return ret, &ResolutionLimitError{Steps: steps, Restarts: restarts}
 		}
 
 		if parts[i] == ".." {
@@ -315,15 +425,29 @@ Loop:
 			}
 			count := end - i
 			if count > i {
-				return ret, errPathEscapes
+				if linkParts != nil && linkStart <= i && i < linkEnd {
+					return ret, pathEscapeError(linkParts, len(linkParts)-1, linkTarget)
+				}
+				return ret, pathEscapeError(parts, i, "")
 			}
 			parts = slices.Delete(parts, i-count, end)
+			switch {
+			case end <= linkStart:
+				linkStart -= 2 * count
+				linkEnd -= 2 * count
+			case i-count < linkEnd:
+				linkStart = min(linkStart, i-count)
+				linkEnd = max(linkEnd-2*count, linkStart)
+			}
 			if len(parts) == 0 {
 				parts = []string{"."}
 			}
+			if tracing {
+				r.traceEvent(ResolveEvent{Kind: ResolveRestart, Path: joinParts(parts)}, rootfd)
+			}
 			i = 0
 			if dirfd != rootfd {
-				syscall.Close(dirfd)
+				r.closeDir(dirfd)
 			}
 			dirfd = rootfd
 			continue
@@ -336,16 +460,25 @@ Loop:
 			// which should be followed.
 			// suffixSep contains any trailing separator characters
 			// which we rejoin to the final part at this time.
-			ret, err = f(dirfd, parts[i]+suffixSep)
+			ret, err = doFinalInRoot(dirfd, parts[i], suffixSep, followSep, f)
+			if tracing {
+				r.traceStep(ResolveFinal, parts[:i+1], dirfd, err)
+			}
 			if err == nil {
 				return
 			}
 		} else {
 			var fd sysfdType
 			fd, err = openDirFunc(dirfd, parts[i])
+			if tracing {
+				r.traceStep(ResolveOpen, parts[:i+1], dirfd, err)
+			}
 			if err == nil {
+				if r.opts.TrackFDs {
+					r.root.fds.Add(1)
+				}
 				if dirfd != rootfd {
-					syscall.Close(dirfd)
+					r.closeDir(dirfd)
 				}
 				dirfd = fd
 			}
@@ -356,47 +489,168 @@ Loop:
 		case errSymlink:
 			symlinks++
 			if symlinks > rootMaxSymlinks {
-				return ret, syscall.ELOOP
+				return ret, traceErr(syscall.ELOOP)
 			}
 			newparts, newSuffixSep, err := splitPathInRoot(string(e), parts[:i], parts[i+1:])
 			if err != nil {
+				if err == ErrPathEscapes {
+					err = pathEscapeError(parts, i, string(e))
+				}
 				return ret, err
 			}
-			if i == len(parts)-1 {
+			linkParts = slices.Clone(parts[:i+1])
+			linkTarget = string(e)
+			if r.opts.TraceErrors {
+				links = append(links, RootSymlink{Path: joinParts(linkParts), Target: linkTarget})
+			}
+			linkStart, linkEnd = i, len(newparts)-(len(parts)-i-1)
+			if i == len(parts)-1 && newSuffixSep != "" {
 				// suffixSep contains any trailing path separator characters
 				// in the link target.
 				// If we are replacing the remainder of the path, retain these.
 				// If we're replacing some intermediate component of the path,
 				// ignore them, since intermediate components must always be
 				// directories.
+				// A link named with a trailing separator must also refer
+				// to a directory, so keep any separators after its name.
 				suffixSep = newSuffixSep
 			}
 			if len(newparts) < i || !slices.Equal(parts[:i], newparts[:i]) {
 				// Some component in the path which we have already traversed
 				// has changed. We need to restart parsing from the root.
+				if tracing {
+					r.traceEvent(ResolveEvent{Kind: ResolveRestart, Path: joinParts(newparts)}, rootfd)
+				}
 				i = 0
 				if dirfd != rootfd {
-					syscall.Close(dirfd)
+					r.closeDir(dirfd)
 				}
 				dirfd = rootfd
 			}
 			parts = newparts
 			continue Loop
 		case *PathError:
-			// This is strings.Join(parts[:i+1], PathSeparator).
-			e.Path = parts[0]
-			for _, part := range parts[1 : i+1] {
-				e.Path += string(PathSeparator) + part
-			}
+			e.Path = joinParts(parts[:i+1])
+			e.Err = traceErr(e.Err)
 			return ret, e
 		default:
-			return ret, err
+			return ret, traceErr(err)
 		}
 
 		i++
 	}
 }
 
+// doFinalInRoot calls f to perform an operation on name, the last element
+// of a path, in the directory dirfd. suffixSep holds any trailing separators.
+//
+// The system follows a symlink named with a trailing separator when opening
+// or inspecting it, even when asked not to, which could take the operation
+// outside the root. Unless followSep is false, doFinalInRoot therefore
+// returns errSymlink for such a symlink, for doInRoot to follow, and ENOTDIR
+// for any other file which is not a directory. The separators add nothing
+// to the name of a directory, so they are dropped, and f will not follow
+// a symlink which replaces it.
+func doFinalInRoot[T any](dirfd sysfdType, name, suffixSep string, followSep bool, f func(parent sysfdType, name string) (T, error)) (ret T, err error) {
+	if suffixSep == "" || !followSep {
+		return f(dirfd, name+suffixSep)
+	}
+	fd, err := rootOpenDir(dirfd, name)
+	switch err.(type) {
+	case nil:
+		syscall.Close(fd)
+		return f(dirfd, name)
+	case errSymlink:
+		return ret, err
+	}
+	if err == syscall.ENOTDIR {
+		return ret, err
+	}
+	// name does not exist, or cannot be opened.
+	// Let the system report the result of the operation.
+	return f(dirfd, name+suffixSep)
+}
+
+// osrootdebug=1 logs each step of Root path resolution to standard error.
+var osrootdebug = godebug.New("#osrootdebug")
+
+// tracing reports whether steps of path resolution in r
+// should be passed to traceEvent.
+func (r *Root) tracing() bool {
+	return r.opts.Trace != nil || osrootdebug.Value() == "1"
+}
+
+// traceStep reports the result of opening or operating on
+// the last of parts in the directory dirfd.
+func (r *Root) traceStep(kind ResolveEventKind, parts []string, dirfd sysfdType, err error) {
+	ev := ResolveEvent{Kind: kind, Path: joinParts(parts)}
+	switch e := err.(type) {
+	case errSymlink:
+		ev.Kind = ResolveSymlink
+		ev.Target = string(e)
+	case *PathError:
+		ev.Err = e.Err
+	default:
+		ev.Err = err
+	}
+	r.traceEvent(ev, dirfd)
+}
+
+// traceEvent reports ev, which occurred in the directory dirfd,
+// to r's Trace function and the osrootdebug log.
+func (r *Root) traceEvent(ev ResolveEvent, dirfd sysfdType) {
+	if osrootdebug.Value() == "1" {
+		var kind string
+		switch ev.Kind {
+		case ResolveOpen:
+			kind = "open"
+		case ResolveSymlink:
+			kind = "symlink"
+		case ResolveRestart:
+			kind = "restart"
+		case ResolveFinal:
+			kind = "final"
+		}
+		s := "os: root " + r.Name() + ": " + kind + " " + ev.Path +
+			" (dirfd " + itoa.Uitoa(uint(dirfd)) + ")"
+		if ev.Target != "" {
+			s += " -> " + ev.Target
+		}
+		if ev.Err != nil {
+			s += ": " + ev.Err.Error()
+		}
+		print(s + "\n")
+	}
+	if r.opts.Trace != nil {
+		r.opts.Trace(ev)
+	}
+}
+
+// closeDir closes fd, an intermediate directory opened by doInRoot.
+func (r *Root) closeDir(fd sysfdType) {
+	syscall.Close(fd)
+	if r.opts.TrackFDs {
+		r.root.fds.Add(-1)
+	}
+}
+
+// rootTrackFile counts f, opened through r, until it is closed.
+func rootTrackFile(r *Root, f *File) {
+	r.root.fds.Add(1)
+	f.rootFDs.Store(&r.root.fds)
+}
+
+func rootActiveFDs(r *Root) int {
+	n := int(r.root.fds.Load())
+	r.root.mu.Lock()
+	defer r.root.mu.Unlock()
+	if !r.root.closed || r.root.refs > 0 {
+		// The root's own descriptor is still open.
+		n++
+	}
+	return n
+}
+
 // errSymlink reports that a file being operated on is actually a symlink,
 // and the target of that symlink.
 type errSymlink string
@@ -0,0 +1,42 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "internal/syscall/unix"
+
+// Unveil restricts the calling process's view of the filesystem
+// to include the directory of the Root, with the given permissions,
+// by calling unveil(2).
+//
+// The permissions string has the same form as the permissions argument
+// to unveil(2): zero or more of the characters 'r', 'w', 'x', and 'c'.
+// Unveil may be called more than once, for the same or different Roots,
+// until the process calls [UnveilLock].
+//
+// unveil(2) operates on paths rather than open directories.
+// Unveil passes the kernel the name of the directory given to [OpenRoot],
+// which is interpreted relative to the process's current working directory.
+// If that name no longer refers to the directory of the Root,
+// the wrong directory may be unveiled.
+//
+// Unveil is only available on OpenBSD.
+func (r *Root) Unveil(permissions string) error {
+	if err := r.root.incref(); err != nil {
+		return &PathError{Op: "unveil", Path: r.Name(), Err: err}
+	}
+	defer r.root.decref()
+	if err := unix.Unveil(r.Name(), permissions); err != nil {
+		return &PathError{Op: "unveil", Path: r.Name(), Err: err}
+	}
+	return nil
+}
+
+// UnveilLock prevents any further calls to unveil(2), including calls to
+// [Root.Unveil], for the remainder of the life of the process.
+//
+// UnveilLock is only available on OpenBSD.
+func UnveilLock() error {
+	return NewSyscallError("unveil", unix.UnveilLock())
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"errors"
+	"fmt"
+	"internal/testenv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootUnveil(t *testing.T) {
+	// unveil affects the whole process, so run the test in a child.
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		if err := runRootUnveilHelper(os.Getenv("GO_UNVEIL_ROOT"), os.Getenv("GO_UNVEIL_OTHER")); err != nil {
+			fmt.Print(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	dir := t.TempDir()
+	rootDir := filepath.Join(dir, "root")
+	otherDir := filepath.Join(dir, "other")
+	for _, d := range []string{rootDir, otherDir} {
+		if err := os.Mkdir(d, 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "file"), nil, 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := testenv.Command(t, testenv.Executable(t), fmt.Sprintf("-test.run=^%s$", t.Name()), "-test.v")
+	cmd = testenv.CleanCmdEnv(cmd)
+	cmd.Env = append(cmd.Env,
+		"GO_WANT_HELPER_PROCESS=1",
+		"GO_UNVEIL_ROOT="+rootDir,
+		"GO_UNVEIL_OTHER="+otherDir,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("running %q failed: %v\n%s", cmd, err, out)
+	}
+}
+
+func runRootUnveilHelper(rootDir, otherDir string) error {
+	root, err := os.OpenRoot(rootDir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	if err := root.Unveil("r"); err != nil {
+		return fmt.Errorf("root.Unveil: %v", err)
+	}
+	if err := os.UnveilLock(); err != nil {
+		return fmt.Errorf("UnveilLock: %v", err)
+	}
+	if _, err := os.ReadFile(filepath.Join(rootDir, "file")); err != nil {
+		return fmt.Errorf("reading unveiled file: %v", err)
+	}
+	if _, err := os.ReadFile(filepath.Join(otherDir, "file")); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading file outside unveiled root: %v, want ErrNotExist", err)
+	}
+	if err := root.Unveil("rw"); !errors.Is(err, fs.ErrPermission) {
+		return fmt.Errorf("root.Unveil after UnveilLock: %v, want ErrPermission", err)
+	}
+	return nil
+}
@@ -15,8 +15,10 @@ func checkPathEscapes(r *Root, name string) error {
 		return ErrClosed
 	}
 	if !filepathlite.IsLocal(name) {
-		return errPathEscapes
+		r.root.stats.record(0, 0, 0, ErrPathEscapes)
+		return ErrPathEscapes
 	}
+	r.root.stats.record(0, 0, 0, nil)
 	return nil
 }
 
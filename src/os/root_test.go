@@ -6,6 +6,7 @@ package os_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"internal/testenv"
@@ -1919,3 +1920,808 @@ func TestRootWriteReadFile(t *testing.T) {
 		t.Fatalf("root.ReadFile(%q) = %q, %v; want %q, nil", name, got, err, want)
 	}
 }
+
+func TestRootCreateTemp(t *testing.T) {
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	if err := root.Mkdir("sub", 0o777); err != nil {
+		t.Fatal(err)
+	}
+	for _, sub := range []string{"", "sub"} {
+		f, err := root.CreateTemp(sub, "prefix-*.txt")
+		if err != nil {
+			t.Fatalf("root.CreateTemp(%q) = %v", sub, err)
+		}
+		f.Close()
+		if filepath.Dir(f.Name()) != filepath.Join(dir, sub) {
+			t.Errorf("root.CreateTemp(%q) created %q; want a file in %q", sub, f.Name(), filepath.Join(dir, sub))
+		}
+		base := filepath.Base(f.Name())
+		if !strings.HasPrefix(base, "prefix-") || !strings.HasSuffix(base, ".txt") {
+			t.Errorf("root.CreateTemp(%q) created %q; want prefix-*.txt", sub, base)
+		}
+
+		name, err := root.MkdirTemp(sub, "dir")
+		if err != nil {
+			t.Fatalf("root.MkdirTemp(%q) = %v", sub, err)
+		}
+		if fi, err := root.Stat(name); err != nil || !fi.IsDir() {
+			t.Errorf("root.Stat(%q) = %v, %v; want a directory", name, fi, err)
+		}
+	}
+
+	if _, err := root.CreateTemp("", "a/b*"); err == nil {
+		t.Errorf("root.CreateTemp with a separator in the pattern succeeded")
+	}
+	if _, err := root.CreateTemp("..", "x*"); err == nil {
+		t.Errorf("root.CreateTemp outside the root succeeded")
+	}
+	if _, err := root.MkdirTemp("..", "x*"); err == nil {
+		t.Errorf("root.MkdirTemp outside the root succeeded")
+	}
+}
+
+func TestTempRoot(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	if runtime.GOOS == "windows" {
+		t.Setenv("TMP", os.Getenv("TMPDIR"))
+	}
+	root, err := os.TempRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	if root.Name() != os.TempDir() {
+		t.Errorf("TempRoot().Name() = %q; want %q", root.Name(), os.TempDir())
+	}
+	f, err := root.CreateTemp("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+}
+
+func TestPreopens(t *testing.T) {
+	roots, err := os.Preopens()
+	if runtime.GOOS != "wasip1" {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Fatalf("Preopens() = %v, %v; want ErrUnsupported", roots, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) == 0 {
+		t.Fatalf("Preopens() returned no directories")
+	}
+	for _, r := range roots {
+		if _, err := r.Stat("."); err != nil {
+			t.Errorf("preopen %q: Stat(.) = %v", r.Name(), err)
+		}
+		if err := r.Close(); err != nil {
+			t.Errorf("preopen %q: Close() = %v", r.Name(), err)
+		}
+	}
+	// Closing the roots must not affect other file operations.
+	if _, err := os.Stat(t.TempDir()); err != nil {
+		t.Errorf("Stat after closing preopens: %v", err)
+	}
+}
+
+func TestRootReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "file"), []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	r, err := os.OpenRootWithOptions(dir, &os.RootOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	sub, err := r.OpenRoot("sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	if b, err := r.ReadFile("sub/file"); err != nil || string(b) != "data" {
+		t.Errorf(`ReadFile("sub/file") = %q, %v; want "data", nil`, b, err)
+	}
+	if b, err := sub.ReadFile("file"); err != nil || string(b) != "data" {
+		t.Errorf(`sub.ReadFile("file") = %q, %v; want "data", nil`, b, err)
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func(r *os.Root) error
+	}{{
+		name: "Create",
+		f: func(r *os.Root) error {
+			_, err := r.Create("new")
+			return err
+		},
+	}, {
+		name: "OpenFile O_WRONLY",
+		f: func(r *os.Root) error {
+			_, err := r.OpenFile("file", os.O_WRONLY, 0)
+			return err
+		},
+	}, {
+		name: "WriteFile",
+		f:    func(r *os.Root) error { return r.WriteFile("file", nil, 0o666) },
+	}, {
+		name: "Mkdir",
+		f:    func(r *os.Root) error { return r.Mkdir("dir", 0o777) },
+	}, {
+		name: "MkdirAll",
+		f:    func(r *os.Root) error { return r.MkdirAll("a/b", 0o777) },
+	}, {
+		name: "Chmod",
+		f:    func(r *os.Root) error { return r.Chmod("file", 0o666) },
+	}, {
+		name: "Chtimes",
+		f:    func(r *os.Root) error { return r.Chtimes("file", time.Now(), time.Now()) },
+	}, {
+		name: "Remove",
+		f:    func(r *os.Root) error { return r.Remove("file") },
+	}, {
+		name: "RemoveAll",
+		f:    func(r *os.Root) error { return r.RemoveAll("file") },
+	}, {
+		name: "Rename",
+		f:    func(r *os.Root) error { return r.Rename("file", "new") },
+	}, {
+		name: "Link",
+		f:    func(r *os.Root) error { return r.Link("file", "new") },
+	}, {
+		name: "Symlink",
+		f:    func(r *os.Root) error { return r.Symlink("file", "new") },
+	}} {
+		if err := test.f(sub); !errors.Is(err, os.ErrPermission) {
+			t.Errorf("%v: %v, want ErrPermission", test.name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub", "file")); err != nil {
+		t.Errorf("file removed from read-only root: %v", err)
+	}
+}
+
+func TestRootCaseSensitive(t *testing.T) {
+	dir := t.TempDir()
+	r, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sensitive, err := r.CaseSensitive()
+	if err != nil {
+		t.Fatalf("CaseSensitive: %v", err)
+	}
+	if err := r.WriteFile("file", nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.Stat("FILE")
+	if sensitive && err == nil {
+		t.Errorf("CaseSensitive() = true, but Stat(%q) found %q", "FILE", "file")
+	}
+	if !sensitive && err != nil {
+		t.Errorf("CaseSensitive() = false, but Stat(%q): %v", "FILE", err)
+	}
+
+	r.Close()
+	if _, err := r.CaseSensitive(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("CaseSensitive after Close: %v, want ErrClosed", err)
+	}
+}
+
+func TestRootCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	r, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if err := r.Mkdir("sub", 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.WriteFile("src", []byte("contents"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CopyFile("src", "sub/dst", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.ReadFile("sub/dst"); err != nil || string(got) != "contents" {
+		t.Errorf("ReadFile(dst) = %q, %v; want %q", got, err, "contents")
+	}
+	if err := r.CopyFile("src", "sub/dst", nil); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("CopyFile to existing file: %v, want ErrExist", err)
+	}
+	if err := r.CopyFile("src", "../escape", nil); err == nil {
+		t.Errorf("CopyFile to path escaping root succeeded, want error")
+	}
+	if err := r.CopyFile("../src", "dst", nil); err == nil {
+		t.Errorf("CopyFile from path escaping root succeeded, want error")
+	}
+
+	ro, err := os.OpenRootWithOptions(dir, &os.RootOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if err := ro.CopyFile("src", "dst", nil); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("CopyFile in read-only root: %v, want ErrPermission", err)
+	}
+}
+
+func TestRootPathEscapeError(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("Root does not resolve symlinks on " + runtime.GOOS)
+	}
+	testenv.MustHaveSymlink(t)
+	dir := makefs(t, []string{
+		"a/b/",
+		"a/link => ../../target",
+		"link => a/link",
+	})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	sep := string(os.PathSeparator)
+	for _, test := range []struct {
+		name string
+		want *os.PathEscapeError
+	}{{
+		name: "a/link",
+		want: &os.PathEscapeError{Prefix: "a", Component: "link", Target: "../../target"},
+	}, {
+		name: "link",
+		want: &os.PathEscapeError{Prefix: "a", Component: "link", Target: "../../target"},
+	}} {
+		_, err := root.Open(test.name)
+		if !errors.Is(err, os.ErrPathEscapes) {
+			t.Errorf("root.Open(%q) = %v, want ErrPathEscapes", test.name, err)
+			continue
+		}
+		var pe *os.PathEscapeError
+		if !errors.As(err, &pe) {
+			t.Errorf("root.Open(%q) = %v, want PathEscapeError", test.name, err)
+			continue
+		}
+		if *pe != *test.want {
+			t.Errorf("root.Open(%q): PathEscapeError = %+v, want %+v", test.name, *pe, *test.want)
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		// Windows cleans paths before resolving them,
+		// so ".." components are never reached.
+		_, err = root.Open("a/b/../../../target")
+		var pe *os.PathEscapeError
+		if !errors.As(err, &pe) {
+			t.Fatalf("root.Open(%q) = %v, want PathEscapeError", "a/b/../../../target", err)
+		}
+		if want := (os.PathEscapeError{Prefix: "a" + sep + "b", Component: ".."}); *pe != want {
+			t.Errorf("PathEscapeError = %+v, want %+v", *pe, want)
+		}
+	}
+}
+
+func TestRootPathErrorTrace(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("Root does not resolve paths component by component on " + runtime.GOOS)
+	}
+	testenv.MustHaveSymlink(t)
+	dir := makefs(t, []string{
+		"a/b/",
+		"a/link => b/missing/c",
+		"loop => loop",
+	})
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{TraceErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	_, err = root.Open("a/link/file")
+	if !os.IsNotExist(err) {
+		t.Fatalf("root.Open(%q) = %v, want not exist", "a/link/file", err)
+	}
+	var rpe *os.RootPathError
+	if !errors.As(err, &rpe) {
+		t.Fatalf("root.Open(%q) = %v, want RootPathError", "a/link/file", err)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(rpe.Traversed, want) {
+		t.Errorf("Traversed = %q, want %q", rpe.Traversed, want)
+	}
+	if want := "missing"; rpe.Component != want {
+		t.Errorf("Component = %q, want %q", rpe.Component, want)
+	}
+	wantLinks := []os.RootSymlink{{Path: "a" + string(os.PathSeparator) + "link", Target: "b/missing/c"}}
+	if !slices.Equal(rpe.Symlinks, wantLinks) {
+		t.Errorf("Symlinks = %v, want %v", rpe.Symlinks, wantLinks)
+	}
+
+	_, err = root.Open("loop")
+	if !errors.As(err, &rpe) {
+		t.Fatalf("root.Open(%q) = %v, want RootPathError", "loop", err)
+	}
+	if len(rpe.Symlinks) == 0 || rpe.Symlinks[0] != (os.RootSymlink{Path: "loop", Target: "loop"}) {
+		t.Errorf("Symlinks = %v, want chain of loop => loop", rpe.Symlinks)
+	}
+
+	// An error in the root directory itself has no trace.
+	_, err = root.Open("missing")
+	if errors.As(err, &rpe) {
+		t.Errorf("root.Open(%q) = %v, want no RootPathError", "missing", err)
+	}
+
+	// A failure resolving the new name of a rename is traced once.
+	err = root.Rename("a/b", "a/link/new")
+	if !errors.As(err, &rpe) {
+		t.Fatalf("root.Rename(%q, %q) = %v, want RootPathError", "a/b", "a/link/new", err)
+	}
+	if _, ok := rpe.Err.(*os.RootPathError); ok {
+		t.Errorf("root.Rename(%q, %q) = %v, wrapped in RootPathError twice", "a/b", "a/link/new", err)
+	}
+	if want := "missing"; rpe.Component != want {
+		t.Errorf("Component = %q, want %q", rpe.Component, want)
+	}
+}
+
+func TestRootStats(t *testing.T) {
+	testenv.MustHaveSymlink(t)
+	dir := makefs(t, []string{
+		"a/b/target",
+		"link => a/b/target",
+	})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	if got := root.Stats(); got != (os.RootStats{}) {
+		t.Errorf("Stats() on new root = %+v, want zero", got)
+	}
+	if _, err := root.Stat("a/b/target"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := root.Stat("link"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := root.Stat("a/../../target"); err == nil {
+		t.Fatal("root.Stat of escaping path succeeded, want error")
+	}
+	got := root.Stats()
+	if got.Operations != 3 {
+		t.Errorf("Stats().Operations = %v, want 3", got.Operations)
+	}
+	if got.Symlinks != 1 {
+		t.Errorf("Stats().Symlinks = %v, want 1", got.Symlinks)
+	}
+	if got.Escapes != 1 {
+		t.Errorf("Stats().Escapes = %v, want 1", got.Escapes)
+	}
+}
+
+func TestRootTrace(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("Trace is not supported on " + runtime.GOOS)
+	}
+	testenv.MustHaveSymlink(t)
+	dir := makefs(t, []string{
+		"a/target",
+		"link => a/target",
+	})
+	var events []os.ResolveEvent
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		Trace: func(ev os.ResolveEvent) {
+			events = append(events, ev)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	if _, err := root.Stat("link"); err != nil {
+		t.Fatal(err)
+	}
+	sep := string(os.PathSeparator)
+	want := []os.ResolveEvent{
+		{Kind: os.ResolveSymlink, Path: "link", Target: "a/target"},
+		{Kind: os.ResolveOpen, Path: "a"},
+		{Kind: os.ResolveFinal, Path: "a" + sep + "target"},
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("trace events:\n%v\nwant:\n%v", events, want)
+	}
+
+	events = nil
+	if _, err := root.Stat("a/missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("root.Stat(%q) = %v, want ErrNotExist", "a/missing", err)
+	}
+	if len(events) != 2 || events[1].Kind != os.ResolveFinal || !errors.Is(events[1].Err, os.ErrNotExist) {
+		t.Errorf("trace events = %v, want final event with ErrNotExist", events)
+	}
+}
+
+func TestRootDebugLog(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("osrootdebug is not supported on " + runtime.GOOS)
+	}
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		root, err := os.OpenRoot(os.Getenv("GO_ROOT_DIR"))
+		if err != nil {
+			fmt.Print(err)
+			os.Exit(1)
+		}
+		root.Stat("a/missing")
+		os.Exit(0)
+	}
+	testenv.MustHaveExec(t)
+
+	dir := makefs(t, []string{"a/"})
+	cmd := testenv.Command(t, testenv.Executable(t), fmt.Sprintf("-test.run=^%s$", t.Name()))
+	cmd = testenv.CleanCmdEnv(cmd)
+	cmd.Env = append(cmd.Env,
+		"GO_WANT_HELPER_PROCESS=1",
+		"GO_ROOT_DIR="+dir,
+		"GODEBUG=osrootdebug=1",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running %q failed: %v\n%s", cmd, err, out)
+	}
+	for _, want := range []string{
+		"os: root " + dir + ": open a ",
+		"os: root " + dir + ": final a" + string(os.PathSeparator) + "missing ",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestRootTraceRace uses RootOptions.Trace to replace a directory
+// with a symlink out of the root after the directory has been opened.
+func TestRootTraceRace(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("Trace is not supported on " + runtime.GOOS)
+	case "windows":
+		t.Skip("cannot rename an open directory on " + runtime.GOOS)
+	}
+	testenv.MustHaveSymlink(t)
+	dir := makefs(t, []string{
+		"a/target",
+	})
+	outside := filepath.Join(filepath.Dir(dir), "outside")
+	if err := os.Mkdir(outside, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "target"), []byte("outside"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		Trace: func(ev os.ResolveEvent) {
+			if ev.Kind != os.ResolveOpen || ev.Path != "a" {
+				return
+			}
+			if err := os.Rename(filepath.Join(dir, "a"), filepath.Join(dir, "b")); err != nil {
+				t.Error(err)
+			}
+			if err := os.Symlink(outside, filepath.Join(dir, "a")); err != nil {
+				t.Error(err)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	got, err := root.ReadFile("a/target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a/target"; string(got) != want {
+		t.Errorf("root.ReadFile(%q) = %q, want %q", "a/target", got, want)
+	}
+}
+
+func TestRootGone(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "root")
+	if err := os.Mkdir(dir, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	if _, err := root.Open("missing"); errors.Is(err, os.ErrRootGone) {
+		t.Fatalf("root.Open(%q) in existing root = %v, want not ErrRootGone", "missing", err)
+	}
+	if err := os.Remove(dir); err != nil {
+		t.Skipf("cannot remove open root directory: %v", err)
+	}
+	if _, err := root.Open("missing"); !errors.Is(err, os.ErrRootGone) {
+		t.Errorf("root.Open(%q) in removed root = %v, want ErrRootGone", "missing", err)
+	}
+}
+
+func TestRootPlainOps(t *testing.T) {
+	dir := t.TempDir()
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{PlainOps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	for _, test := range []struct {
+		op   string
+		call func() error
+	}{
+		{"open", func() error { _, err := root.Open("missing"); return err }},
+		{"stat", func() error { _, err := root.Stat("missing"); return err }},
+		{"lstat", func() error { _, err := root.Lstat("missing"); return err }},
+		{"remove", func() error { return root.Remove("missing") }},
+		{"mkdir", func() error { return root.Mkdir("missing/dir", 0o777) }},
+		{"rename", func() error { return root.Rename("missing", "other") }},
+	} {
+		err := test.call()
+		var op string
+		switch e := err.(type) {
+		case *os.PathError:
+			op = e.Op
+		case *os.LinkError:
+			op = e.Op
+		default:
+			t.Errorf("%v: got error %v (%T), want PathError or LinkError", test.op, err, err)
+			continue
+		}
+		if op != test.op {
+			t.Errorf("%v: got error with Op %q, want %q: %v", test.op, op, test.op, err)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%v: got error %v, want ErrNotExist", test.op, err)
+		}
+		if serr := (*os.SyscallError)(nil); !errors.As(err, &serr) || serr.Syscall == test.op {
+			t.Errorf("%v: got error %v, want the system call name kept in a SyscallError", test.op, err)
+		}
+	}
+}
+
+func TestRootCanonicalName(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir("root", 0o777); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRoot("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	name, err := root.CanonicalName()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filepath.IsAbs(name) {
+		t.Errorf("CanonicalName() = %q, want absolute path", name)
+	}
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	// On Linux, the name is derived from the open directory.
+	if err := os.Rename("root", "renamed"); err != nil {
+		t.Fatal(err)
+	}
+	name, err = root.CanonicalName()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := filepath.EvalSymlinks(filepath.Join(dir, "renamed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != want {
+		t.Errorf("after rename, CanonicalName() = %q, want %q", name, want)
+	}
+}
+
+func TestRootResolutionLimit(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9", "windows":
+		t.Skip("Root does not resolve .. components step by step on " + runtime.GOOS)
+	}
+	dir := makefs(t, []string{"a/"})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	name := strings.Repeat("a/../", 300) + "a"
+	_, err = root.Stat(name)
+	if !errors.Is(err, os.ErrResolutionLimit) {
+		t.Fatalf("root.Stat(long path) = %v, want ErrResolutionLimit", err)
+	}
+	var le *os.ResolutionLimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("root.Stat(long path) = %v, want ResolutionLimitError", err)
+	}
+	if le.Steps <= 255 || le.Restarts <= 8 {
+		t.Errorf("ResolutionLimitError = %+v, want Steps > 255 and Restarts > 8", *le)
+	}
+}
+
+func TestRootLeak(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("osrootleak is not supported on " + runtime.GOOS)
+	}
+	if os.Getenv("GO_WANT_HELPER_PROCESS") == "1" {
+		func() {
+			if _, err := os.OpenRoot(os.Getenv("GO_ROOT_DIR")); err != nil {
+				fmt.Print(err)
+				os.Exit(1)
+			}
+		}()
+		for range 10 {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		os.Exit(0)
+	}
+	testenv.MustHaveExec(t)
+
+	dir := t.TempDir()
+	cmd := testenv.Command(t, testenv.Executable(t), fmt.Sprintf("-test.run=^%s$", t.Name()))
+	cmd = testenv.CleanCmdEnv(cmd)
+	cmd.Env = append(cmd.Env,
+		"GO_WANT_HELPER_PROCESS=1",
+		"GO_ROOT_DIR="+dir,
+		"GODEBUG=osrootleak=1",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running %q failed: %v\n%s", cmd, err, out)
+	}
+	want := "os: Root " + dir + " was garbage collected without being closed"
+	if !strings.Contains(string(out), want) {
+		t.Errorf("output does not contain %q:\n%s", want, out)
+	}
+	if !strings.Contains(string(out), "TestRootLeak") {
+		t.Errorf("output does not contain the stack which opened the root:\n%s", out)
+	}
+}
+
+func TestRootActiveFDs(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("ActiveFDs is not supported on " + runtime.GOOS)
+	}
+	dir := makefs(t, []string{"a/b/file"})
+	var root *os.Root
+	inFlight := -1
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		TrackFDs: true,
+		Trace: func(ev os.ResolveEvent) {
+			if ev.Kind == os.ResolveFinal {
+				// The directory a/b is held open while the file is opened.
+				inFlight = root.ActiveFDs()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := root.ActiveFDs(), 1; got != want {
+		t.Errorf("ActiveFDs() on new root = %v, want %v", got, want)
+	}
+	f, err := root.Open("a/b/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := inFlight, 2; got != want {
+		t.Errorf("ActiveFDs() during Open = %v, want %v", got, want)
+	}
+	if got, want := root.ActiveFDs(), 2; got != want {
+		t.Errorf("ActiveFDs() with open file = %v, want %v", got, want)
+	}
+	f.Close()
+	f.Close()
+	if got, want := root.ActiveFDs(), 1; got != want {
+		t.Errorf("ActiveFDs() after closing file = %v, want %v", got, want)
+	}
+	root.Close()
+	if got, want := root.ActiveFDs(), 0; got != want {
+		t.Errorf("ActiveFDs() after closing root = %v, want %v", got, want)
+	}
+}
+
+func TestRootContext(t *testing.T) {
+	dir := makefs(t, []string{"a/b/file"})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	if f, err := root.OpenContext(ctx, "a/b/file"); err != nil {
+		t.Fatalf("root.OpenContext(a/b/file) = %v", err)
+	} else {
+		f.Close()
+	}
+	if _, err := root.StatContext(ctx, "a/b/file"); err != nil {
+		t.Fatalf("root.StatContext(a/b/file) = %v", err)
+	}
+
+	cancel()
+	checkErr := func(op string, err error) {
+		t.Helper()
+		var pe *os.PathError
+		if !errors.As(err, &pe) || !errors.Is(err, context.Canceled) {
+			t.Errorf("%v with canceled context = %v, want PathError wrapping context.Canceled", op, err)
+		}
+	}
+	_, err = root.OpenContext(ctx, "a/b/file")
+	checkErr("root.OpenContext", err)
+	_, err = root.StatContext(ctx, "a/b/file")
+	checkErr("root.StatContext", err)
+	err = root.RemoveAllContext(ctx, "a")
+	checkErr("root.RemoveAllContext", err)
+	if _, err := root.Stat("a/b/file"); err != nil {
+		t.Errorf("root.Stat(a/b/file) after canceled RemoveAllContext = %v, want success", err)
+	}
+}
+
+func TestRootContextCancelDuringResolution(t *testing.T) {
+	switch runtime.GOOS {
+	case "js", "plan9":
+		t.Skip("Root does not resolve paths step by step on " + runtime.GOOS)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	dir := makefs(t, []string{"a/b/file"})
+	root, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		Trace: func(ev os.ResolveEvent) {
+			if ev.Kind == os.ResolveOpen {
+				cancel()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	_, err = root.OpenContext(ctx, "a/b/file")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("root.OpenContext canceled during resolution = %v, want context.Canceled", err)
+	}
+	if n := root.ActiveFDs(); n != 1 {
+		t.Errorf("root.ActiveFDs() = %v after canceled open, want 1", n)
+	}
+}
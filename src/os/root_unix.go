@@ -9,7 +9,6 @@ package os
 import (
 	"errors"
 	"internal/syscall/unix"
-	"runtime"
 	"syscall"
 	"time"
 )
@@ -20,7 +19,7 @@ import (
 type sysfdType = int
 
 // openRootNolog is OpenRoot.
-func openRootNolog(name string) (*Root, error) {
+func openRootNolog(name string, _ *RootOptions) (*Root, error) {
 	var fd int
 	err := ignoringEINTR(func() error {
 		var err error
@@ -52,11 +51,11 @@ func newRoot(fd int, name string) (*Root, error) {
 		syscall.CloseOnExec(fd)
 	}
 
-	r := &Root{&root{
+	r := &Root{root: &root{
 		fd:   fd,
 		name: name,
 	}}
-	runtime.SetFinalizer(r.root, (*root).Close)
+	r.root.setFinalizer()
 	return r, nil
 }
 
@@ -79,7 +78,13 @@ func openRootInRoot(r *Root, name string) (*Root, error) {
 }
 
 // rootOpenFileNolog is Root.OpenFile.
-func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File, error) {
+func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode, _ *OpenFileOptions) (*File, error) {
+	if !root.tracing() {
+		if fd, ok := rootOpenFileFast(root, name, flag, perm); ok {
+			root.root.stats.record(0, 0, 0, nil)
+			return newFile(fd, joinPath(root.Name(), name), kindOpenFile, unix.HasNonblockFlag(flag)), nil
+		}
+	}
 	fd, err := doInRoot(root, name, nil, func(parent int, name string) (fd int, err error) {
 		ignoringEINTR(func() error {
 			fd, err = unix.Openat(parent, name, syscall.O_NOFOLLOW|syscall.O_CLOEXEC|flag, uint32(perm))
@@ -107,6 +112,12 @@ func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File,
 	return f, nil
 }
 
+// enableBackupPrivileges implements RootOptions.WindowsBackupSemantics,
+// which is ignored on Unix.
+func enableBackupPrivileges() (restore func(), err error) {
+	return func() {}, nil
+}
+
 func rootOpenDir(parent int, name string) (int, error) {
 	var (
 		fd  int
@@ -128,6 +139,12 @@ func rootOpenDir(parent int, name string) (int, error) {
 }
 
 func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
+	if !r.tracing() {
+		if fi, ok := rootStatFast(r, name, lstat); ok {
+			r.root.stats.record(0, 0, 0, nil)
+			return fi, nil
+		}
+	}
 	fi, err := doInRoot(r, name, nil, func(parent sysfdType, n string) (FileInfo, error) {
 		var fs fileStat
 		if err := unix.Fstatat(parent, n, &fs.sys, unix.AT_SYMLINK_NOFOLLOW); err != nil {
@@ -146,7 +163,7 @@ func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
 }
 
 func rootSymlink(r *Root, oldname, newname string) error {
-	_, err := doInRoot(r, newname, nil, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRootNoFollow(r, newname, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, symlinkat(oldname, parent, name)
 	})
 	if err != nil {
@@ -300,3 +317,35 @@ func readlinkat(fd int, name string) (string, error) {
 		}
 	}
 }
+
+// rootPathIsLocalNoDotDot reports whether name is a non-empty relative path
+// containing no ".." components.
+func rootPathIsLocalNoDotDot(name string) bool {
+	if name == "" || IsPathSeparator(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); {
+		j := i
+		for j < len(name) && !IsPathSeparator(name[j]) {
+			j++
+		}
+		if name[i:j] == ".." {
+			return false
+		}
+		i = j + 1
+	}
+	return true
+}
+
+// rootGone reports whether err, returned by an operation in the root
+// directory fd, is due to the root directory having been removed.
+func rootGone(fd int, err error) bool {
+	if err = underlyingError(err); err != syscall.ENOENT && err != syscall.ESTALE {
+		return false
+	}
+	var st syscall.Stat_t
+	if err := ignoringEINTR(func() error { return syscall.Fstat(fd, &st) }); err != nil {
+		return err == syscall.ESTALE
+	}
+	return st.Nlink == 0
+}
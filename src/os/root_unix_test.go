@@ -7,6 +7,7 @@
 package os_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -162,3 +163,168 @@ func TestRootConsistencyLchown(t *testing.T) {
 		})
 	}
 }
+
+func TestRootPathErrorDefault(t *testing.T) {
+	dir := makefs(t, []string{
+		"a/b/",
+		"a/link => b/missing/c",
+	})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	// Without RootOptions.TraceErrors, the Err of a PathError
+	// is the underlying error, whatever the depth of the failure.
+	for _, name := range []string{"a/b/missing", "a/link/file"} {
+		_, err := root.Open(name)
+		pe, ok := err.(*os.PathError)
+		if !ok {
+			t.Fatalf("root.Open(%q) = %v, want PathError", name, err)
+		}
+		if pe.Err != syscall.ENOENT {
+			t.Errorf("root.Open(%q): Err = %#v, want ENOENT", name, pe.Err)
+		}
+	}
+}
+
+func TestRootSymlinkLoop(t *testing.T) {
+	dir := makefs(t, []string{
+		"a => b",
+		"b => a",
+	})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	if _, err := root.Open("a"); !errors.Is(err, syscall.ELOOP) {
+		t.Errorf("root.Open(%q) = %v, want ELOOP", "a", err)
+	}
+}
+
+func TestRootSymlinkTrailingSlashEscape(t *testing.T) {
+	dir := makefs(t, []string{
+		"escape => ../outside",
+		"link => escape/",
+		"file",
+		"flink => file/",
+		"../outside/",
+	})
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	for _, name := range []string{"escape/", "link", "link/"} {
+		if _, err := root.Stat(name); !errors.Is(err, os.ErrPathEscapes) {
+			t.Errorf("root.Stat(%q) = %v, want ErrPathEscapes", name, err)
+		}
+		if f, err := root.Open(name); !errors.Is(err, os.ErrPathEscapes) {
+			if err == nil {
+				f.Close()
+			}
+			t.Errorf("root.Open(%q) = %v, want ErrPathEscapes", name, err)
+		}
+	}
+	for _, name := range []string{"escape/", "link/"} {
+		if _, err := root.Lstat(name); !errors.Is(err, os.ErrPathEscapes) {
+			t.Errorf("root.Lstat(%q) = %v, want ErrPathEscapes", name, err)
+		}
+	}
+	if _, err := root.Stat("flink"); !errors.Is(err, syscall.ENOTDIR) {
+		t.Errorf("root.Stat(%q) = %v, want ENOTDIR", "flink", err)
+	}
+}
+
+// rootFastPathFS is a filesystem layout exercising the cases in which
+// the single-call fast paths used by some systems must fall back to
+// resolving a path one component at a time.
+var rootFastPathFS = []string{
+	"f",
+	"d/",
+	"link => f",
+	"dlink => d",
+	"escape => ../outside",
+	"abs => $ABS/f",
+	"../outside",
+}
+
+var rootFastPathNames = []string{
+	"f",
+	"link",
+	"dlink/../f",
+	"escape",
+	"abs",
+	"d/../f",
+	"..",
+	"../outside",
+	"missing",
+	"f/",
+}
+
+// TestRootFastPathConsistency checks that opening and statting a file
+// without tracing, which permits a fast path on some systems, reports
+// the same results as with tracing, which always resolves the path
+// one component at a time.
+func TestRootFastPathConsistency(t *testing.T) {
+	dir := makefs(t, rootFastPathFS)
+	fast, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fast.Close()
+	slow, err := os.OpenRootWithOptions(dir, &os.RootOptions{
+		Trace: func(os.ResolveEvent) {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slow.Close()
+
+	for _, name := range rootFastPathNames {
+		for _, test := range []struct {
+			op   string
+			call func(r *os.Root, name string) (string, error)
+		}{{
+			op: "Open",
+			call: func(r *os.Root, name string) (string, error) {
+				f, err := r.Open(name)
+				if err != nil {
+					return "", err
+				}
+				defer f.Close()
+				fi, err := f.Stat()
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprint(fi.Mode()), nil
+			},
+		}, {
+			op: "Stat",
+			call: func(r *os.Root, name string) (string, error) {
+				fi, err := r.Stat(name)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprint(fi.Mode()), nil
+			},
+		}, {
+			op: "Lstat",
+			call: func(r *os.Root, name string) (string, error) {
+				fi, err := r.Lstat(name)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprint(fi.Mode()), nil
+			},
+		}} {
+			got, gotErr := test.call(fast, name)
+			want, wantErr := test.call(slow, name)
+			if got != want || fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+				t.Errorf("root.%v(%q) = %q, %v; with tracing %q, %v", test.op, name, got, gotErr, want, wantErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build wasip1
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"syscall"
+)
+
+const (
+	// rootRights are the rights used by operations on a Root's directory.
+	rootRights = syscall.RIGHT_PATH_OPEN |
+		syscall.RIGHT_FD_READDIR |
+		syscall.RIGHT_FD_FILESTAT_GET |
+		syscall.RIGHT_PATH_FILESTAT_GET |
+		syscall.RIGHT_PATH_READLINK |
+		rootWriteRights
+
+	// rootWriteRights are the rights used by operations which modify
+	// the contents of a Root.
+	rootWriteRights = syscall.RIGHT_PATH_CREATE_DIRECTORY |
+		syscall.RIGHT_PATH_CREATE_FILE |
+		syscall.RIGHT_PATH_LINK_SOURCE |
+		syscall.RIGHT_PATH_LINK_TARGET |
+		syscall.RIGHT_PATH_RENAME_SOURCE |
+		syscall.RIGHT_PATH_RENAME_TARGET |
+		syscall.RIGHT_PATH_FILESTAT_SET_SIZE |
+		syscall.RIGHT_PATH_FILESTAT_SET_TIMES |
+		syscall.RIGHT_PATH_SYMLINK |
+		syscall.RIGHT_PATH_REMOVE_DIRECTORY |
+		syscall.RIGHT_PATH_UNLINK_FILE
+
+	// fileWriteRights are the rights of a file descriptor
+	// which permit modifying the file.
+	fileWriteRights = syscall.RIGHT_FD_DATASYNC |
+		syscall.RIGHT_FD_WRITE |
+		syscall.RIGHT_FD_ALLOCATE |
+		syscall.RIGHT_FD_FILESTAT_SET_SIZE |
+		syscall.RIGHT_FD_FILESTAT_SET_TIMES |
+		syscall.RIGHT_FDSTAT_SET_FLAGS
+)
+
+// restrictRoot limits the rights of r's descriptor to those used by
+// operations on a Root. If r is read-only, rights which permit
+// modification are removed from both the descriptor and any
+// descriptors opened relative to it.
+func restrictRoot(r *Root) error {
+	if err := r.root.incref(); err != nil {
+		return err
+	}
+	defer r.root.decref()
+	base, inheriting, err := unix.FdstatGetRights(r.root.fd)
+	if err != nil {
+		return err
+	}
+	base &= rootRights
+	if r.opts.ReadOnly {
+		base &^= rootWriteRights
+		inheriting &^= rootWriteRights | fileWriteRights
+	}
+	err = unix.FdstatSetRights(r.root.fd, base, inheriting)
+	if err == syscall.ENOSYS || err == syscall.ENOTSUP {
+		// Some runtimes no longer implement WASI rights.
+		err = nil
+	}
+	return err
+}
@@ -63,7 +63,7 @@ func rootCleanPath(s string, prefix, suffix []string) (string, error) {
 
 	s, ok := stringslite.CutPrefix(s, fixedPrefix)
 	if !ok {
-		return "", errPathEscapes
+		return "", ErrPathEscapes
 	}
 	s = stringslite.TrimPrefix(s, `\`)
 	if s == "" {
@@ -71,7 +71,7 @@ func rootCleanPath(s string, prefix, suffix []string) (string, error) {
 	}
 
 	if !filepathlite.IsLocal(s) {
-		return "", errPathEscapes
+		return "", ErrPathEscapes
 	}
 
 	return s, nil
@@ -83,10 +83,17 @@ func rootCleanPath(s string, prefix, suffix []string) (string, error) {
 type sysfdType = syscall.Handle
 
 // openRootNolog is OpenRoot.
-func openRootNolog(name string) (*Root, error) {
+func openRootNolog(name string, opts *RootOptions) (*Root, error) {
 	if name == "" {
 		return nil, &PathError{Op: "open", Path: name, Err: syscall.ENOENT}
 	}
+	if opts != nil && opts.WindowsBackupSemantics {
+		restore, err := enableBackupPrivileges()
+		if err != nil {
+			return nil, &PathError{Op: "open", Path: name, Err: err}
+		}
+		defer restore()
+	}
 	path := fixLongPath(name)
 	fd, err := syscall.Open(path, syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
 	if err != nil {
@@ -109,14 +116,88 @@ func newRoot(fd syscall.Handle, name string) (*Root, error) {
 		return nil, &PathError{Op: "open", Path: name, Err: errors.New("not a directory")}
 	}
 
-	r := &Root{&root{
+	r := &Root{root: &root{
 		fd:   fd,
 		name: name,
 	}}
-	runtime.SetFinalizer(r.root, (*root).Close)
+	r.root.setFinalizer()
 	return r, nil
 }
 
+// enableBackupPrivileges enables SeBackupPrivilege and SeRestorePrivilege
+// on the current thread. It locks the goroutine to the thread and
+// impersonates the process's own security context, so that the privileges
+// are not enabled for other threads. The returned restore function reverts
+// the thread to its previous state.
+//
+// It returns an error if the process holds neither privilege.
+func enableBackupPrivileges() (restore func(), err error) {
+	runtime.LockOSThread()
+	if err := windows.ImpersonateSelf(windows.SecurityImpersonation); err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+	restore = func() {
+		windows.RevertToSelf()
+		runtime.UnlockOSThread()
+	}
+	ct, err := windows.GetCurrentThread()
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	var t syscall.Token
+	err = windows.OpenThreadToken(ct, syscall.TOKEN_QUERY|windows.TOKEN_ADJUST_PRIVILEGES, false, &t)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	defer syscall.CloseHandle(syscall.Handle(t))
+
+	enabled := false
+	for _, name := range []string{"SeBackupPrivilege", "SeRestorePrivilege"} {
+		var tp windows.TOKEN_PRIVILEGES
+		tp.PrivilegeCount = 1
+		tp.Privileges[0].Attributes = windows.SE_PRIVILEGE_ENABLED
+		p, _ := syscall.UTF16PtrFromString(name)
+		if err = windows.LookupPrivilegeValue(nil, p, &tp.Privileges[0].Luid); err != nil {
+			continue
+		}
+		// AdjustTokenPrivileges reports ERROR_NOT_ALL_ASSIGNED
+		// when the token does not hold the privilege.
+		if err = windows.AdjustTokenPrivileges(t, false, &tp, 0, nil, nil); err == nil {
+			enabled = true
+		}
+	}
+	if !enabled {
+		restore()
+		if err == windows.ERROR_NOT_ALL_ASSIGNED {
+			err = ErrPermission
+		}
+		return nil, err
+	}
+	return restore, nil
+}
+
+// rootCaseSensitive is Root.CaseSensitive.
+func rootCaseSensitive(r *Root) (bool, error) {
+	if err := r.root.incref(); err != nil {
+		return false, err
+	}
+	defer r.root.decref()
+	var info windows.FILE_CASE_SENSITIVE_INFO
+	err := windows.GetFileInformationByHandleEx(r.root.fd, windows.FileCaseSensitiveInfo, (*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	switch err {
+	case nil:
+		return info.Flags&windows.FILE_CS_FLAG_CASE_SENSITIVE_DIR != 0, nil
+	case windows.ERROR_INVALID_PARAMETER, windows.ERROR_NOT_SUPPORTED, windows.ERROR_INVALID_FUNCTION:
+		// Windows versions before Windows 10 1803 and filesystems
+		// other than NTFS do not support case-sensitive directories.
+		return false, nil
+	}
+	return false, err
+}
+
 // openRootInRoot is Root.OpenRoot.
 func openRootInRoot(r *Root, name string) (*Root, error) {
 	fd, err := doInRoot(r, name, nil, rootOpenDir)
@@ -127,19 +208,31 @@ func openRootInRoot(r *Root, name string) (*Root, error) {
 }
 
 // rootOpenFileNolog is Root.OpenFile.
-func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File, error) {
+func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
+	shareMode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+	var fileFlags uint32
+	if opts != nil {
+		if opts.WindowsShareMode != 0 {
+			shareMode = opts.WindowsShareMode
+		}
+		fileFlags = opts.WindowsFileFlags
+	}
 	fd, err := doInRoot(root, name, nil, func(parent syscall.Handle, name string) (syscall.Handle, error) {
-		return openat(parent, name, flag, perm)
+		return openatWithOptions(parent, name, flag, perm, shareMode, fileFlags)
 	})
 	if err != nil {
 		return nil, &PathError{Op: "openat", Path: name, Err: err}
 	}
-	// openat always returns a non-blocking handle.
-	return newFile(fd, joinPath(root.Name(), name), "file", false), nil
+	// The handle is blocking unless FILE_FLAG_OVERLAPPED was requested.
+	return newFile(fd, joinPath(root.Name(), name), "file", fileFlags&windows.FILE_FLAG_OVERLAPPED != 0), nil
 }
 
 func openat(dirfd syscall.Handle, name string, flag int, perm FileMode) (syscall.Handle, error) {
-	h, err := windows.Openat(dirfd, name, uint64(flag)|syscall.O_CLOEXEC|windows.O_NOFOLLOW_ANY, syscallMode(perm))
+	return openatWithOptions(dirfd, name, flag, perm, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE, 0)
+}
+
+func openatWithOptions(dirfd syscall.Handle, name string, flag int, perm FileMode, shareMode, fileFlags uint32) (syscall.Handle, error) {
+	h, err := windows.OpenatWithOptions(dirfd, name, uint64(flag)|syscall.O_CLOEXEC|windows.O_NOFOLLOW_ANY, syscallMode(perm), shareMode, fileFlags)
 	if err == syscall.ELOOP || err == syscall.ENOTDIR {
 		if link, err := readReparseLinkAt(dirfd, name); err == nil {
 			return syscall.InvalidHandle, errSymlink(link)
@@ -178,7 +271,98 @@ func readReparseLinkAt(dirfd syscall.Handle, name string) (string, error) {
 		return "", err
 	}
 	defer syscall.CloseHandle(h)
-	return readReparseLinkHandle(h)
+	return readRootReparseLink(dirfd, h)
+}
+
+// readRootReparseLink returns the target of the reparse point h,
+// located in the directory dirfd, for resolution within a root.
+//
+// Junctions (mount points) always have absolute targets.
+// We convert a junction's target into a path relative to dirfd,
+// so that it is resolved like a relative symbolic link:
+// a junction referencing a location within the root is followed,
+// and one referencing a location outside the root escapes it.
+func readRootReparseLink(dirfd, h syscall.Handle) (string, error) {
+	link, tag, err := readReparseLinkHandleTag(h)
+	if err != nil || tag != windows.IO_REPARSE_TAG_MOUNT_POINT {
+		return link, err
+	}
+	dir, err := windows.FinalPath(dirfd, windows.VOLUME_NAME_DOS)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := stringslite.CutPrefix(dir, `\\?\UNC\`); ok {
+		dir = `\\` + s
+	} else {
+		dir = stringslite.TrimPrefix(dir, `\\?\`)
+	}
+	return junctionRelativeTarget(dir, link), nil
+}
+
+// junctionRelativeTarget converts target, the absolute target of a junction
+// in the directory dir, into a path relative to dir.
+// If target is on a different volume than dir, it is returned unchanged.
+func junctionRelativeTarget(dir, target string) string {
+	dvol := filepathlite.VolumeName(dir)
+	tvol := filepathlite.VolumeName(target)
+	if !equalFoldNT(dvol, tvol) {
+		return target
+	}
+	dparts := splitPathElems(dir[len(dvol):])
+	tparts := splitPathElems(target[len(tvol):])
+	i := 0
+	for i < len(dparts) && i < len(tparts) && equalFoldNT(dparts[i], tparts[i]) {
+		i++
+	}
+	var rel []string
+	for range len(dparts) - i {
+		rel = append(rel, "..")
+	}
+	rel = append(rel, tparts[i:]...)
+	if len(rel) == 0 {
+		return "."
+	}
+	s := rel[0]
+	for _, e := range rel[1:] {
+		s += `\` + e
+	}
+	return s
+}
+
+// splitPathElems splits path into its non-empty elements.
+func splitPathElems(path string) []string {
+	var elems []string
+	for len(path) > 0 {
+		i := 0
+		for i < len(path) && !IsPathSeparator(path[i]) {
+			i++
+		}
+		if i > 0 {
+			elems = append(elems, path[:i])
+		}
+		if i < len(path) {
+			i++
+		}
+		path = path[i:]
+	}
+	return elems
+}
+
+// equalFoldNT reports whether a and b are equal under the
+// case-insensitive comparison used by the Windows object manager.
+func equalFoldNT(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ua, err := windows.NewNTUnicodeString(a)
+	if err != nil {
+		return false
+	}
+	ub, err := windows.NewNTUnicodeString(b)
+	if err != nil {
+		return false
+	}
+	return windows.RtlEqualUnicodeString(ua, ub, true)
 }
 
 func rootOpenDir(parent syscall.Handle, name string) (syscall.Handle, error) {
@@ -223,7 +407,7 @@ func rootStat(r *Root, name string, lstat bool) (FileInfo, error) {
 			return nil, err
 		}
 		if !lstat && fi.(*fileStat).isReparseTagNameSurrogate() {
-			link, err := readReparseLinkHandle(fd)
+			link, err := readRootReparseLink(parent, fd)
 			if err != nil {
 				return nil, err
 			}
@@ -274,7 +458,7 @@ func rootSymlink(r *Root, oldname, newname string) error {
 		flags |= windows.SYMLINKAT_RELATIVE
 	}
 
-	_, err := doInRoot(r, newname, nil, func(parent sysfdType, name string) (struct{}, error) {
+	_, err := doInRootNoFollow(r, newname, func(parent sysfdType, name string) (struct{}, error) {
 		return struct{}{}, windows.Symlinkat(oldname, parent, name, flags)
 	})
 	if err != nil {
@@ -283,6 +467,44 @@ func rootSymlink(r *Root, oldname, newname string) error {
 	return nil
 }
 
+// CreateJunction creates link as a directory junction referencing
+// the directory target within the root. See [CreateJunction] for more details.
+//
+// Unlike [Root.Symlink], target is a path relative to the root
+// rather than to the directory containing link, and it may not
+// escape the root. The junction records the absolute path of target,
+// and continues to reference that path if the root directory is moved.
+//
+// CreateJunction is only available on Windows.
+func (r *Root) CreateJunction(link, target string) error {
+	if r.opts.ReadOnly {
+		return &LinkError{"junction", target, link, ErrPermission}
+	}
+	if !filepathlite.IsLocal(target) {
+		return &LinkError{"junction", target, link, ErrPathEscapes}
+	}
+	if err := r.root.incref(); err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	dir, err := windows.FinalPath(r.root.fd, windows.VOLUME_NAME_DOS)
+	r.root.decref()
+	if err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	abs := dir
+	if t := filepathlite.Clean(target); t != "." {
+		abs += `\` + t
+	}
+	substitute, printName := junctionTargetNames(abs)
+	_, err = doInRootNoFollow(r, link, func(parent sysfdType, name string) (struct{}, error) {
+		return struct{}{}, windows.Junctionat(substitute, printName, parent, name)
+	})
+	if err != nil {
+		return &LinkError{"junction", target, link, err}
+	}
+	return nil
+}
+
 func chmodat(parent syscall.Handle, name string, mode FileMode) error {
 	// Currently, on Windows os.Chmod("symlink") will act on "symlink",
 	// not on any file it points to.
@@ -306,7 +528,12 @@ func chmodat(parent syscall.Handle, name string, mode FileMode) error {
 		return err
 	}
 	defer syscall.CloseHandle(h)
+	return chmodHandle(h, mode)
+}
 
+// chmodHandle sets or clears the read-only attribute of h
+// according to the owner-writable bit of mode.
+func chmodHandle(h syscall.Handle, mode FileMode) error {
 	var d syscall.ByHandleFileInformation
 	if err := syscall.GetFileInformationByHandle(h, &d); err != nil {
 		return err
@@ -402,3 +629,35 @@ func modeAt(parent syscall.Handle, name string) (FileMode, error) {
 	}
 	return fi.Mode(), nil
 }
+
+// rootGone reports whether err, returned by an operation in the root
+// directory h, is due to the root directory having been removed.
+func rootGone(h syscall.Handle, err error) bool {
+	switch underlyingError(err) {
+	case syscall.ERROR_FILE_NOT_FOUND, syscall.ERROR_PATH_NOT_FOUND, syscall.ERROR_ACCESS_DENIED:
+	default:
+		return false
+	}
+	// A directory which has been deleted while open is either
+	// pending deletion or, with POSIX semantics, has no links.
+	var info windows.FILE_STANDARD_INFO
+	if err := windows.GetFileInformationByHandleEx(h, windows.FileStandardInfo, (*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		return false
+	}
+	return info.DeletePending || info.NumberOfLinks == 0
+}
+
+func rootCanonicalName(r *Root) (string, error) {
+	if err := r.root.incref(); err != nil {
+		return "", err
+	}
+	defer r.root.decref()
+	name, err := windows.FinalPath(r.root.fd, windows.VOLUME_NAME_DOS)
+	if err != nil {
+		return "", err
+	}
+	if rest, ok := stringslite.CutPrefix(name, `\\?\UNC\`); ok {
+		return `\\` + rest, nil
+	}
+	return stringslite.TrimPrefix(name, `\\?\`), nil
+}
@@ -10,8 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"internal/syscall/windows"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
 	"testing"
 	"unsafe"
@@ -228,3 +231,466 @@ func TestRootSymlinkToDirectory(t *testing.T) {
 		})
 	}
 }
+
+// Verify that operations in a Root work on paths longer than MAX_PATH.
+func TestRootWindowsLongPaths(t *testing.T) {
+	r, err := os.OpenRoot(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Build a relative path of several hundred characters,
+	// so that its full path exceeds MAX_PATH.
+	component := strings.Repeat("d", 100)
+	dir := component
+	for range 5 {
+		dir += `\` + component
+	}
+	if err := r.MkdirAll(dir, 0o777); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	longName := strings.Repeat("f", 240)
+	file := dir + `\` + longName
+	if err := r.WriteFile(file, []byte("data"), 0o666); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if got, err := r.ReadFile(file); err != nil || string(got) != "data" {
+		t.Fatalf("ReadFile = %q, %v; want %q, nil", got, err, "data")
+	}
+	renamed := dir + `\` + strings.Repeat("r", 240)
+	if err := r.Rename(file, renamed); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	linked := dir + `\` + strings.Repeat("l", 240)
+	if err := r.Link(renamed, linked); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if _, err := r.Stat(linked); err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if err := r.RemoveAll(component); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if _, err := r.Stat(component); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Stat after RemoveAll: %v, want ErrNotExist", err)
+	}
+}
+
+func TestRootOpenFileWithOptionsShareMode(t *testing.T) {
+	dir := t.TempDir()
+	r, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Root.OpenFile permits deleting an open file by default.
+	f, err := r.Create("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Rename("a", "b"); err != nil {
+		t.Errorf("Rename with file open: %v", err)
+	}
+	f.Close()
+
+	// Without FILE_SHARE_DELETE, it does not.
+	f, err = r.OpenFileWithOptions("b", os.O_RDWR, 0, &os.OpenFileOptions{
+		WindowsShareMode: windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := r.Rename("b", "c"); err == nil {
+		t.Errorf("Rename of file opened without FILE_SHARE_DELETE succeeded, want error")
+	}
+}
+
+func TestRootOpenFileWithOptionsInvalidFlags(t *testing.T) {
+	r, err := os.OpenRoot(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	const FILE_FLAG_POSIX_SEMANTICS = 0x01000000 // not supported by Root
+	f, err := r.OpenFileWithOptions("a", os.O_RDWR|os.O_CREATE, 0666, &os.OpenFileOptions{
+		WindowsFileFlags: FILE_FLAG_POSIX_SEMANTICS,
+	})
+	if err == nil {
+		f.Close()
+		t.Fatalf("OpenFileWithOptions with unsupported flag succeeded, want error")
+	}
+	if !errors.Is(err, syscall.EINVAL) {
+		t.Errorf("OpenFileWithOptions with unsupported flag: %v, want EINVAL", err)
+	}
+}
+
+func TestRootWindowsBackupSemantics(t *testing.T) {
+	dir := t.TempDir()
+	r, err := os.OpenRootWithOptions(dir, &os.RootOptions{WindowsBackupSemantics: true})
+	if errors.Is(err, os.ErrPermission) {
+		t.Skipf("process does not hold backup privileges: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if err := r.Mkdir("sub", 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.WriteFile("sub/file", []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.ReadFile("sub/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "data" {
+		t.Errorf("ReadFile = %q, want %q", got, "data")
+	}
+	sub, err := r.OpenRoot("sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	if _, err := sub.Stat("file"); err != nil {
+		t.Errorf("Stat in child root: %v", err)
+	}
+}
+
+func TestRootWindowsJunction(t *testing.T) {
+	// Junction targets are compared against the final path of the
+	// directory containing them, so avoid 8.3 short names in the temp dir.
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootDir := filepath.Join(base, "root")
+	target := filepath.Join(rootDir, "target")
+	if err := os.MkdirAll(target, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "file"), []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	mkjunction := func(link, target string) {
+		t.Helper()
+		var rd reparseData
+		rd.addSubstituteName(`\??\` + target)
+		rd.addPrintName(target)
+		if err := createMountPoint(link, &rd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkjunction(filepath.Join(rootDir, "in"), target)
+	mkjunction(filepath.Join(rootDir, "out"), base)
+
+	r, err := os.OpenRoot(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Lstat reports junctions in the same way as os.Lstat.
+	fi, err := r.Lstat("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantfi, err := os.Lstat(filepath.Join(rootDir, "in"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fi.Mode().Type(), wantfi.Mode().Type(); got != want {
+		t.Errorf("Root.Lstat(junction).Mode().Type() = %v, want %v", got, want)
+	}
+	if fi.IsDir() {
+		t.Errorf("Root.Lstat(junction).IsDir() = true, want false")
+	}
+
+	// Readlink returns the junction's target.
+	if got, err := r.Readlink("in"); err != nil || got != target {
+		t.Errorf("Root.Readlink(junction) = %q, %v; want %q", got, err, target)
+	}
+
+	// Junctions referencing a location within the root are followed.
+	if got, err := r.ReadFile(`in\file`); err != nil || string(got) != "data" {
+		t.Errorf("Root.ReadFile through junction = %q, %v; want %q", got, err, "data")
+	}
+	if fi, err := r.Stat("in"); err != nil || !fi.IsDir() {
+		t.Errorf("Root.Stat(junction) = %v, %v; want directory", fi, err)
+	}
+
+	// Junctions referencing a location outside the root are not.
+	if _, err := r.ReadFile(`out\root\target\file`); err == nil {
+		t.Errorf("Root.ReadFile through junction escaping root succeeded, want error")
+	}
+}
+
+func TestRootWindowsJunctionRelativeTarget(t *testing.T) {
+	for _, test := range []struct {
+		dir, target, want string
+	}{
+		{`C:\root`, `C:\root\a`, `a`},
+		{`C:\root\a`, `C:\root\b\c`, `..\b\c`},
+		{`C:\root\a`, `C:\ROOT\A\B`, `B`},
+		{`C:\root\a`, `C:\root\a`, `.`},
+		{`C:\root\a\b`, `C:\`, `..\..\..`},
+		{`C:\root`, `D:\root\a`, `D:\root\a`},
+		{`\\server\share\dir`, `\\server\share\other`, `..\other`},
+		{`C:\root`, `\\?\Volume{00000000-0000-0000-0000-000000000000}\a`, `\\?\Volume{00000000-0000-0000-0000-000000000000}\a`},
+	} {
+		if got := os.JunctionRelativeTarget(test.dir, test.target); got != test.want {
+			t.Errorf("junctionRelativeTarget(%q, %q) = %q, want %q", test.dir, test.target, got, test.want)
+		}
+	}
+}
+
+func TestRootStreams(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file")
+	if err := os.WriteFile(name, []byte("main"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name+":s1", []byte("stream one"), 0o666); err != nil {
+		t.Skipf("alternate data streams not supported: %v", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	f, err := root.OpenStream("file", "s2", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+	if err != nil {
+		t.Fatalf("root.OpenStream(file, s2, O_CREATE) = %v", err)
+	}
+	if _, err := f.Write([]byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	want := []os.StreamInfo{{Name: "s1", Size: 10}, {Name: "s2", Size: 3}}
+	got, err := root.ListStreams("file")
+	if err != nil {
+		t.Fatalf("root.ListStreams(file) = %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("root.ListStreams(file) = %v, want %v", got, want)
+	}
+
+	f, err = root.OpenStream("file", "s1", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("root.OpenStream(file, s1) = %v", err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil || string(b) != "stream one" {
+		t.Errorf("reading stream s1 = %q, %v; want %q", b, err, "stream one")
+	}
+	got, err = f.Streams()
+	if err != nil {
+		t.Fatalf("File.Streams() = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("File.Streams() on a stream = %v, want none", got)
+	}
+
+	main, err := root.Open("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer main.Close()
+	got, err = main.Streams()
+	if err != nil {
+		t.Fatalf("File.Streams() = %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("File.Streams() = %v, want %v", got, want)
+	}
+
+	for _, stream := range []string{"", "a:b", `a\b`, "a/b", "s1:$DATA"} {
+		if f, err := root.OpenStream("file", stream, os.O_RDONLY, 0); err == nil {
+			f.Close()
+			t.Errorf("root.OpenStream(file, %q) succeeded, want error", stream)
+		}
+	}
+}
+
+func TestCreateJunction(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(base, "target")
+	if err := os.Mkdir(target, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "file"), []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	link := filepath.Join(base, "link")
+	if err := os.CreateJunction(link, target); err != nil {
+		t.Fatalf("CreateJunction = %v", err)
+	}
+	if got, err := os.Readlink(link); err != nil || got != target {
+		t.Errorf("Readlink(junction) = %q, %v; want %q", got, err, target)
+	}
+	if got, err := os.ReadFile(filepath.Join(link, "file")); err != nil || string(got) != "data" {
+		t.Errorf("ReadFile through junction = %q, %v; want %q", got, err, "data")
+	}
+	if err := os.CreateJunction(link, target); err == nil {
+		t.Errorf("CreateJunction over existing file succeeded, want error")
+	}
+}
+
+func TestRootCreateJunction(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(base, "a", "target"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "a", "target", "file"), []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	r, err := os.OpenRoot(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// The target is relative to the root, not to the link's directory.
+	if err := r.CreateJunction(`a\link`, `a\target`); err != nil {
+		t.Fatalf("Root.CreateJunction = %v", err)
+	}
+	if got, err := r.ReadFile(`a\link\file`); err != nil || string(got) != "data" {
+		t.Errorf("Root.ReadFile through junction = %q, %v; want %q", got, err, "data")
+	}
+	want := filepath.Join(base, "a", "target")
+	if got, err := os.Readlink(filepath.Join(base, "a", "link")); err != nil || got != want {
+		t.Errorf("Readlink(junction) = %q, %v; want %q", got, err, want)
+	}
+
+	for _, target := range []string{"..", `..\x`, `C:\`, `\x`} {
+		if err := r.CreateJunction("escape", target); err == nil {
+			t.Errorf("Root.CreateJunction(escape, %q) succeeded, want error", target)
+		}
+	}
+}
+
+func TestFileAttributes(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file")
+	if err := os.WriteFile(name, nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	r, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for _, test := range []struct {
+		name string
+		get  func() (os.FileAttributes, error)
+		set  func(os.FileAttributes) error
+	}{{
+		name: "os",
+		get:  func() (os.FileAttributes, error) { return os.GetFileAttributes(name) },
+		set:  func(a os.FileAttributes) error { return os.SetFileAttributes(name, a) },
+	}, {
+		name: "Root",
+		get:  func() (os.FileAttributes, error) { return r.GetFileAttributes("file") },
+		set:  func(a os.FileAttributes) error { return r.SetFileAttributes("file", a) },
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, want := range []os.FileAttributes{
+				os.FileAttributeHidden | os.FileAttributeNotContentIndexed,
+				os.FileAttributeSystem | os.FileAttributeTemporary,
+				os.FileAttributeReadOnly,
+				0,
+			} {
+				if err := test.set(want); err != nil {
+					t.Fatalf("SetFileAttributes(%#x) = %v", want, err)
+				}
+				got, err := test.get()
+				if err != nil {
+					t.Fatalf("GetFileAttributes() = %v", err)
+				}
+				if got != want {
+					t.Errorf("after SetFileAttributes(%#x), GetFileAttributes() = %#x", want, got)
+				}
+			}
+			if err := test.set(syscall.FILE_ATTRIBUTE_DIRECTORY); err == nil {
+				t.Errorf("SetFileAttributes(FILE_ATTRIBUTE_DIRECTORY) succeeded, want error")
+			}
+		})
+	}
+
+	ro, err := os.OpenRootWithOptions(dir, &os.RootOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if err := ro.SetFileAttributes("file", os.FileAttributeHidden); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("SetFileAttributes in read-only root = %v, want ErrPermission", err)
+	}
+}
+
+func TestChmodACL(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file")
+	if err := os.WriteFile(name, []byte("data"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	r, err := os.OpenRootWithOptions(dir, &os.RootOptions{WindowsChmodACL: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for _, test := range []struct {
+		name  string
+		chmod func(os.FileMode) error
+	}{{
+		name:  "os",
+		chmod: func(mode os.FileMode) error { return os.ChmodACL(name, mode) },
+	}, {
+		name:  "Root",
+		chmod: func(mode os.FileMode) error { return r.Chmod("file", mode) },
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			// Without read permission, the owner cannot read the file,
+			// but may still change its permissions.
+			if err := test.chmod(0o200); err != nil {
+				t.Fatalf("chmod(0o200) = %v", err)
+			}
+			if _, err := os.ReadFile(name); !errors.Is(err, os.ErrPermission) {
+				t.Errorf("ReadFile after chmod(0o200) = %v, want ErrPermission", err)
+			}
+			if err := test.chmod(0o600); err != nil {
+				t.Fatalf("chmod(0o600) = %v", err)
+			}
+			if got, err := os.ReadFile(name); err != nil || string(got) != "data" {
+				t.Errorf("ReadFile after chmod(0o600) = %q, %v, want %q, nil", got, err, "data")
+			}
+			if err := test.chmod(0o400); err != nil {
+				t.Fatalf("chmod(0o400) = %v", err)
+			}
+			fi, err := os.Stat(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fi.Mode().Perm(); got&0o200 != 0 {
+				t.Errorf("after chmod(0o400), mode = %v, want read-only", got)
+			}
+			if err := test.chmod(0o600); err != nil {
+				t.Fatalf("chmod(0o600) = %v", err)
+			}
+		})
+	}
+}
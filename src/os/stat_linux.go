@@ -14,32 +14,38 @@ func fillFileStatFromSys(fs *fileStat, name string) {
 	fs.name = filepathlite.Base(name)
 	fs.size = fs.sys.Size
 	fs.modTime = time.Unix(fs.sys.Mtim.Unix())
-	fs.mode = FileMode(fs.sys.Mode & 0777)
-	switch fs.sys.Mode & syscall.S_IFMT {
+	fs.mode = fileModeFromUnix(fs.sys.Mode)
+}
+
+// fileModeFromUnix converts the st_mode bits of a Unix file to a FileMode.
+func fileModeFromUnix(mode uint32) FileMode {
+	m := FileMode(mode & 0777)
+	switch mode & syscall.S_IFMT {
 	case syscall.S_IFBLK:
-		fs.mode |= ModeDevice
+		m |= ModeDevice
 	case syscall.S_IFCHR:
-		fs.mode |= ModeDevice | ModeCharDevice
+		m |= ModeDevice | ModeCharDevice
 	case syscall.S_IFDIR:
-		fs.mode |= ModeDir
+		m |= ModeDir
 	case syscall.S_IFIFO:
-		fs.mode |= ModeNamedPipe
+		m |= ModeNamedPipe
 	case syscall.S_IFLNK:
-		fs.mode |= ModeSymlink
+		m |= ModeSymlink
 	case syscall.S_IFREG:
 		// nothing to do
 	case syscall.S_IFSOCK:
-		fs.mode |= ModeSocket
+		m |= ModeSocket
 	}
-	if fs.sys.Mode&syscall.S_ISGID != 0 {
-		fs.mode |= ModeSetgid
+	if mode&syscall.S_ISGID != 0 {
+		m |= ModeSetgid
 	}
-	if fs.sys.Mode&syscall.S_ISUID != 0 {
-		fs.mode |= ModeSetuid
+	if mode&syscall.S_ISUID != 0 {
+		m |= ModeSetuid
 	}
-	if fs.sys.Mode&syscall.S_ISVTX != 0 {
-		fs.mode |= ModeSticky
+	if mode&syscall.S_ISVTX != 0 {
+		m |= ModeSticky
 	}
+	return m
 }
 
 // For testing.
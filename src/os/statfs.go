@@ -0,0 +1,35 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// An FSInfo describes a file system, as reported by [Statfs].
+type FSInfo struct {
+	Total      uint64 // size of the file system in bytes
+	Free       uint64 // free space in bytes
+	Available  uint64 // free space in bytes available to the caller
+	BlockSize  int64  // size in bytes of the unit in which space is allocated
+	MaxNameLen int    // maximum length of a file name
+	ReadOnly   bool   // whether the file system is mounted read-only
+}
+
+// Statfs returns information about the file system containing the
+// named file. Available may be less than Free when some space is
+// reserved, for example for the superuser, or limited by quotas.
+//
+// On Darwin, FreeBSD, Linux and OpenBSD, Statfs uses statfs(2), and
+// MaxNameLen is in bytes.
+// On Windows, it uses NtQueryVolumeInformationFile and
+// GetVolumeInformationByHandleW, and MaxNameLen is in UTF-16 code units.
+// On other systems, Statfs returns an error wrapping
+// [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func Statfs(name string) (FSInfo, error) {
+	info, err := statfs(name)
+	if err != nil {
+		return FSInfo{}, &PathError{Op: "statfs", Path: name, Err: err}
+	}
+	return info, nil
+}
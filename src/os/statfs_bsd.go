@@ -0,0 +1,10 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || freebsd || openbsd
+
+package os
+
+// mntReadOnly is the MNT_RDONLY mount flag.
+const mntReadOnly = 0x1
@@ -0,0 +1,27 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func statfs(name string) (FSInfo, error) {
+	var st syscall.Statfs_t
+	if err := ignoringEINTR(func() error {
+		return syscall.Statfs(name, &st)
+	}); err != nil {
+		return FSInfo{}, err
+	}
+	bsize := uint64(st.Bsize)
+	return FSInfo{
+		Total:     st.Blocks * bsize,
+		Free:      st.Bfree * bsize,
+		Available: st.Bavail * bsize,
+		BlockSize: int64(bsize),
+		// statfs does not report the limit, but every
+		// file system Darwin supports uses NAME_MAX.
+		MaxNameLen: 255,
+		ReadOnly:   st.Flags&mntReadOnly != 0,
+	}, nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func statfs(name string) (FSInfo, error) {
+	var st syscall.Statfs_t
+	if err := ignoringEINTR(func() error {
+		return syscall.Statfs(name, &st)
+	}); err != nil {
+		return FSInfo{}, err
+	}
+	bsize := st.Bsize
+	return FSInfo{
+		Total:      st.Blocks * bsize,
+		Free:       st.Bfree * bsize,
+		Available:  uint64(max(st.Bavail, 0)) * bsize,
+		BlockSize:  int64(bsize),
+		MaxNameLen: int(st.Namemax),
+		ReadOnly:   st.Flags&mntReadOnly != 0,
+	}, nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func statfs(name string) (FSInfo, error) {
+	var st syscall.Statfs_t
+	if err := ignoringEINTR(func() error {
+		return syscall.Statfs(name, &st)
+	}); err != nil {
+		return FSInfo{}, err
+	}
+	// Blocks are counted in units of the fragment size,
+	// which kernels before 2.6 do not report.
+	bsize := uint64(st.Frsize)
+	if bsize == 0 {
+		bsize = uint64(st.Bsize)
+	}
+	return FSInfo{
+		Total:      uint64(st.Blocks) * bsize,
+		Free:       uint64(st.Bfree) * bsize,
+		Available:  uint64(st.Bavail) * bsize,
+		BlockSize:  int64(bsize),
+		MaxNameLen: int(st.Namelen),
+		ReadOnly:   st.Flags&syscall.MS_RDONLY != 0,
+	}, nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+func statfs(name string) (FSInfo, error) {
+	var st syscall.Statfs_t
+	if err := ignoringEINTR(func() error {
+		return syscall.Statfs(name, &st)
+	}); err != nil {
+		return FSInfo{}, err
+	}
+	bsize := uint64(st.F_bsize)
+	return FSInfo{
+		Total:      st.F_blocks * bsize,
+		Free:       st.F_bfree * bsize,
+		Available:  uint64(max(st.F_bavail, 0)) * bsize,
+		BlockSize:  int64(bsize),
+		MaxNameLen: int(st.F_namemax),
+		ReadOnly:   st.F_flags&mntReadOnly != 0,
+	}, nil
+}
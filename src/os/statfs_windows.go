@@ -0,0 +1,41 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+func statfs(name string) (FSInfo, error) {
+	namep, err := syscall.UTF16PtrFromString(fixLongPath(name))
+	if err != nil {
+		return FSInfo{}, err
+	}
+	h, err := syscall.CreateFile(namep, 0, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE, nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return FSInfo{}, err
+	}
+	defer syscall.CloseHandle(h)
+
+	var size windows.FILE_FS_FULL_SIZE_INFORMATION
+	if err := windows.NtQueryVolumeInformationFile(h, &windows.IO_STATUS_BLOCK{}, unsafe.Pointer(&size), uint32(unsafe.Sizeof(size)), windows.FileFsFullSizeInformation); err != nil {
+		return FSInfo{}, err.(windows.NTStatus).Errno()
+	}
+	var maxNameLen, flags uint32
+	if err := windows.GetVolumeInformationByHandle(h, nil, 0, nil, &maxNameLen, &flags, nil, 0); err != nil {
+		return FSInfo{}, err
+	}
+	bsize := uint64(size.SectorsPerAllocationUnit) * uint64(size.BytesPerSector)
+	return FSInfo{
+		Total:      uint64(size.TotalAllocationUnits) * bsize,
+		Free:       uint64(size.ActualAvailableAllocationUnits) * bsize,
+		Available:  uint64(size.CallerAvailableAllocationUnits) * bsize,
+		BlockSize:  int64(bsize),
+		MaxNameLen: int(maxNameLen),
+		ReadOnly:   flags&windows.FILE_READ_ONLY_VOLUME != 0,
+	}, nil
+}
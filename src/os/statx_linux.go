@@ -0,0 +1,127 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/syscall/unix"
+	"syscall"
+	"time"
+)
+
+// A StatxMask selects the fields requested from, and reported by, statx(2).
+type StatxMask uint32
+
+// The bits of a StatxMask.
+const (
+	StatxBasicStats  StatxMask = unix.STATX_BASIC_STATS   // type, mode, nlink, uid, gid, atime, mtime, ctime, ino, size and blocks
+	StatxBtime       StatxMask = unix.STATX_BTIME         // birth time
+	StatxMntID       StatxMask = unix.STATX_MNT_ID        // mount ID
+	StatxDioAlign    StatxMask = unix.STATX_DIOALIGN      // direct I/O alignment
+	StatxMntIDUnique StatxMask = unix.STATX_MNT_ID_UNIQUE // unique mount ID, which is never reused
+)
+
+// A StatxAttr is a set of file attributes reported by statx(2).
+type StatxAttr uint64
+
+// The bits of a StatxAttr.
+const (
+	StatxAttrCompressed StatxAttr = 0x4      // compressed by the file system
+	StatxAttrImmutable  StatxAttr = 0x10     // cannot be modified
+	StatxAttrAppend     StatxAttr = 0x20     // can only be opened for appending
+	StatxAttrNodump     StatxAttr = 0x40     // not a candidate for backup
+	StatxAttrEncrypted  StatxAttr = 0x800    // requires a key to be decrypted
+	StatxAttrAutomount  StatxAttr = 0x1000   // an automount trigger
+	StatxAttrMountRoot  StatxAttr = 0x2000   // the root of a mount
+	StatxAttrVerity     StatxAttr = 0x100000 // protected by fs-verity
+	StatxAttrDax        StatxAttr = 0x200000 // in the DAX (cpu direct access) state
+)
+
+// Statx describes a file as reported by statx(2).
+// Only the fields selected by Mask are valid.
+type Statx struct {
+	Mask           StatxMask // fields filled in by the kernel
+	Blksize        uint32    // preferred block size for I/O
+	Attributes     StatxAttr // file attributes
+	AttributesMask StatxAttr // attributes supported by the file system
+	Nlink          uint32
+	Uid            uint32
+	Gid            uint32
+	Mode           FileMode
+	Ino            uint64
+	Size           int64
+	Blocks         uint64 // number of 512-byte blocks allocated
+	Atime          time.Time
+	Btime          time.Time
+	Ctime          time.Time
+	Mtime          time.Time
+	RdevMajor      uint32 // device ID, if the file is a device
+	RdevMinor      uint32
+	DevMajor       uint32 // ID of the device containing the file
+	DevMinor       uint32
+	MntID          uint64
+	DioMemAlign    uint32 // memory buffer alignment for direct I/O
+	DioOffsetAlign uint32 // file offset alignment for direct I/O
+}
+
+// Statx returns extended information about the file, as reported by
+// statx(2) for the open file descriptor. The mask selects the fields
+// to retrieve; the kernel may fill in more or fewer fields than
+// requested, and reports those it filled in in the Mask field of
+// the result. Because the information is read from the descriptor
+// itself, it describes exactly the file that will be read or written.
+//
+// Kernels before Linux 4.11 do not implement statx(2),
+// and Statx returns an error wrapping [errors.ErrUnsupported].
+//
+// Statx is only available on Linux.
+func (f *File) Statx(mask StatxMask) (*Statx, error) {
+	if err := f.checkValid("statx"); err != nil {
+		return nil, err
+	}
+	var stx unix.Statx_t
+	var err error
+	if cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.Statx(int(fd), "", unix.AT_EMPTY_PATH, int(mask), &stx)
+		})
+	}); cerr != nil {
+		err = cerr
+	}
+	if err == syscall.ENOSYS {
+		err = errors.ErrUnsupported
+	}
+	if err != nil {
+		return nil, f.wrapErr("statx", err)
+	}
+	return &Statx{
+		Mask:           StatxMask(stx.Mask),
+		Blksize:        stx.Blksize,
+		Attributes:     StatxAttr(stx.Attributes),
+		AttributesMask: StatxAttr(stx.AttributesMask),
+		Nlink:          stx.Nlink,
+		Uid:            stx.Uid,
+		Gid:            stx.Gid,
+		Mode:           fileModeFromUnix(uint32(stx.Mode)),
+		Ino:            stx.Ino,
+		Size:           int64(stx.Size),
+		Blocks:         stx.Blocks,
+		Atime:          statxTime(stx.Atime),
+		Btime:          statxTime(stx.Btime),
+		Ctime:          statxTime(stx.Ctime),
+		Mtime:          statxTime(stx.Mtime),
+		RdevMajor:      stx.RdevMajor,
+		RdevMinor:      stx.RdevMinor,
+		DevMajor:       stx.DevMajor,
+		DevMinor:       stx.DevMinor,
+		MntID:          stx.MntID,
+		DioMemAlign:    stx.DioMemAlign,
+		DioOffsetAlign: stx.DioOffsetAlign,
+	}, nil
+}
+
+func statxTime(ts unix.StatxTimestamp) time.Time {
+	return time.Unix(ts.Sec, int64(ts.Nsec))
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStatx(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(name, []byte("hello"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stx, err := f.Statx(os.StatxBasicStats | os.StatxBtime)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skipf("Statx: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Statx: %v", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stx.Mask&os.StatxBasicStats != os.StatxBasicStats {
+		t.Errorf("Statx mask %#x does not include the basic stats", stx.Mask)
+	}
+	if stx.Size != fi.Size() {
+		t.Errorf("Statx size = %d, want %d", stx.Size, fi.Size())
+	}
+	if stx.Mode != fi.Mode() {
+		t.Errorf("Statx mode = %v, want %v", stx.Mode, fi.Mode())
+	}
+	if !stx.Mtime.Equal(fi.ModTime()) {
+		t.Errorf("Statx mtime = %v, want %v", stx.Mtime, fi.ModTime())
+	}
+	if stx.Mask&os.StatxBtime != 0 && stx.Btime.After(stx.Mtime) {
+		t.Errorf("Statx btime %v is after mtime %v", stx.Btime, stx.Mtime)
+	}
+}
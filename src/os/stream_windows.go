@@ -0,0 +1,173 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"internal/stringslite"
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+// StreamInfo describes a named data stream of a file,
+// also known as an NTFS alternate data stream.
+//
+// StreamInfo is only available on Windows.
+type StreamInfo struct {
+	Name string // name of the stream, without the leading ':' or trailing ":$DATA"
+	Size int64  // length of the stream in bytes
+}
+
+// Streams returns the named data streams of the file.
+// The file's default, unnamed data stream is not included.
+//
+// Streams is only available on Windows.
+func (f *File) Streams() ([]StreamInfo, error) {
+	if f == nil {
+		return nil, ErrInvalid
+	}
+	streams, err := readStreams(f.pfd.Sysfd)
+	if err != nil {
+		return nil, &PathError{Op: "streams", Path: f.name, Err: err}
+	}
+	return streams, nil
+}
+
+// ListStreams returns the named data streams of the file or directory
+// in the root. The default, unnamed data stream is not included.
+//
+// ListStreams is only available on Windows.
+func (r *Root) ListStreams(name string) ([]StreamInfo, error) {
+	streams, err := doInRoot(r, name, nil, func(parent syscall.Handle, n string) ([]StreamInfo, error) {
+		h, err := openStreamBaseAt(parent, n)
+		if err != nil {
+			return nil, err
+		}
+		defer syscall.CloseHandle(h)
+		return readStreams(h)
+	})
+	if err != nil {
+		return nil, &PathError{Op: "liststreams", Path: name, Err: err}
+	}
+	return streams, nil
+}
+
+// OpenStream opens the named data stream of the file in the root,
+// using the same flag and perm semantics as [Root.OpenFile].
+//
+// The file must already exist. If the O_CREATE flag is passed,
+// a stream is created within it.
+// Symbolic links in name are followed as in [Root.OpenFile];
+// stream must be a bare stream name, such as "Zone.Identifier".
+//
+// OpenStream is only available on Windows.
+func (r *Root) OpenStream(name, stream string, flag int, perm FileMode) (*File, error) {
+	if perm&0o777 != perm {
+		return nil, &PathError{Op: "openat", Path: name, Err: errors.New("unsupported file mode")}
+	}
+	if !isStreamName(stream) {
+		return nil, &PathError{Op: "openat", Path: name + ":" + stream, Err: windows.ERROR_INVALID_NAME}
+	}
+	if flag&(O_WRONLY|O_RDWR|O_APPEND|O_CREATE|O_TRUNC) != 0 {
+		if err := r.checkWritable("openat", name); err != nil {
+			return nil, err
+		}
+	}
+	r.logOpen(name)
+	fd, err := doInRoot(r, name, nil, func(parent syscall.Handle, n string) (syscall.Handle, error) {
+		base, err := openStreamBaseAt(parent, n)
+		if err != nil {
+			return syscall.InvalidHandle, err
+		}
+		defer syscall.CloseHandle(base)
+		// A name beginning with ':' is opened as a stream
+		// of the file referenced by the root handle.
+		return windows.Openat(base, ":"+stream, uint64(flag)|syscall.O_CLOEXEC, syscallMode(perm))
+	})
+	if err != nil {
+		return nil, &PathError{Op: "openat", Path: name + ":" + stream, Err: err}
+	}
+	f := newFile(fd, joinPath(r.Name(), name)+":"+stream, "file", false)
+	f.appendMode = flag&O_APPEND != 0
+	return f, nil
+}
+
+// openStreamBaseAt opens the file whose streams are to be accessed,
+// reporting symbolic links to doInRoot so that they are followed
+// within the root.
+func openStreamBaseAt(parent syscall.Handle, name string) (syscall.Handle, error) {
+	h, err := openat(parent, name, windows.O_OPEN_REPARSE, 0)
+	if err != nil {
+		return syscall.InvalidHandle, err
+	}
+	fi, err := statHandle(name, h)
+	if err == nil && fi.(*fileStat).isReparseTagNameSurrogate() {
+		var link string
+		link, err = readRootReparseLink(parent, h)
+		if err == nil {
+			err = errSymlink(link)
+		}
+	}
+	if err != nil {
+		syscall.CloseHandle(h)
+		return syscall.InvalidHandle, err
+	}
+	return h, nil
+}
+
+// isStreamName reports whether s is a non-empty stream name
+// containing no path separators, colons, or NUL characters.
+func isStreamName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case ':', '\\', '/', 0:
+			return false
+		}
+	}
+	return true
+}
+
+// readStreams returns the named data streams of the file open as h.
+func readStreams(h syscall.Handle) ([]StreamInfo, error) {
+	buf := make([]byte, 1024)
+	for {
+		err := windows.GetFileInformationByHandleEx(h, windows.FileStreamInfo, &buf[0], uint32(len(buf)))
+		if err == nil {
+			break
+		}
+		switch err {
+		case syscall.ERROR_HANDLE_EOF:
+			// The file has no streams. This is the case for directories
+			// with no named streams.
+			return nil, nil
+		case syscall.ERROR_MORE_DATA:
+			buf = make([]byte, 2*len(buf))
+			continue
+		}
+		return nil, err
+	}
+	var streams []StreamInfo
+	for off := 0; ; {
+		info := (*windows.FILE_STREAM_INFO)(unsafe.Pointer(&buf[off]))
+		nameBuf := unsafe.Slice(&info.StreamName[0], info.StreamNameLength/2)
+		name := syscall.UTF16ToString(nameBuf)
+		// Stream names have the form ":name:$DATA".
+		// The default stream is "::$DATA".
+		name = stringslite.TrimPrefix(name, ":")
+		name = stringslite.TrimSuffix(name, ":$DATA")
+		if name != "" {
+			streams = append(streams, StreamInfo{Name: name, Size: info.StreamSize})
+		}
+		if info.NextEntryOffset == 0 {
+			break
+		}
+		off += int(info.NextEntryOffset)
+	}
+	return streams, nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// Sync commits the contents of all file systems to stable storage,
+// as the sync command does.
+//
+// On Unix systems, Sync uses sync(2), which on some systems schedules
+// the writes without waiting for them to complete. On Windows, Sync
+// flushes each fixed and removable drive, which requires administrator
+// privilege; it flushes every drive it can and returns the first error.
+// On other systems, Sync returns an error wrapping [errors.ErrUnsupported].
+func Sync() error {
+	return syncAll()
+}
+
+// Syncfs commits the contents of the file system containing the named
+// file to stable storage, as [File.Syncfs] does for an open file.
+//
+// On Linux, Syncfs uses syncfs(2). On other Unix systems, it is the
+// same as [Sync]. On Windows, it flushes the volume containing the file,
+// which requires administrator privilege. On other systems, Syncfs
+// returns an error wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*PathError].
+func Syncfs(name string) error {
+	return syncfsName(name)
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (!unix && !windows) || aix
+
+package os
+
+import "errors"
+
+func syncAll() error {
+	return NewSyscallError("sync", errors.ErrUnsupported)
+}
+
+func syncfsName(name string) error {
+	return &PathError{Op: "syncfs", Path: name, Err: errors.ErrUnsupported}
+}
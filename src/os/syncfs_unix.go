@@ -0,0 +1,30 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix && !aix
+
+package os
+
+import (
+	"runtime"
+	"syscall"
+)
+
+func syncAll() error {
+	syscall.Sync()
+	return nil
+}
+
+func syncfsName(name string) error {
+	f, err := Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if runtime.GOOS == "linux" || runtime.GOOS == "android" {
+		return f.Syncfs()
+	}
+	syscall.Sync()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/stringslite"
+	"internal/syscall/windows"
+	"syscall"
+)
+
+func syncAll() error {
+	drives, err := windows.GetLogicalDrives()
+	if err != nil {
+		return NewSyscallError("GetLogicalDrives", err)
+	}
+	var firstErr error
+	for i := range 26 {
+		if drives&(1<<i) == 0 {
+			continue
+		}
+		letter := string(rune('A' + i))
+		root, _ := syscall.UTF16PtrFromString(letter + `:\`)
+		switch windows.GetDriveType(root) {
+		case windows.DRIVE_FIXED, windows.DRIVE_REMOVABLE:
+		default:
+			continue
+		}
+		volume := `\\.\` + letter + ":"
+		if err := flushVolume(volume); err != nil && firstErr == nil {
+			firstErr = &PathError{Op: "sync", Path: volume, Err: err}
+		}
+	}
+	return firstErr
+}
+
+func syncfsName(name string) error {
+	if _, err := Stat(name); err != nil {
+		return err
+	}
+	volume, err := volumeName(name)
+	if err == nil {
+		err = flushVolume(volume)
+	}
+	if err != nil {
+		return &PathError{Op: "syncfs", Path: name, Err: err}
+	}
+	return nil
+}
+
+// volumeName returns the name of the volume containing the named file,
+// in the form \\?\Volume{GUID}, which opens the volume itself.
+func volumeName(name string) (string, error) {
+	namew, err := syscall.UTF16FromString(fixLongPath(name))
+	if err != nil {
+		return "", err
+	}
+	// The mount point is no longer than the absolute form of name.
+	mountPoint := make([]uint16, len(namew)+syscall.MAX_PATH)
+	if err := windows.GetVolumePathName(&namew[0], &mountPoint[0], uint32(len(mountPoint))); err != nil {
+		return "", err
+	}
+	var volume [50]uint16 // \\?\Volume{GUID}\ and a NUL
+	if err := windows.GetVolumeNameForVolumeMountPoint(&mountPoint[0], &volume[0], uint32(len(volume))); err != nil {
+		return "", err
+	}
+	// Without the trailing backslash, the name refers to the volume
+	// rather than to its root directory.
+	s := syscall.UTF16ToString(volume[:])
+	return stringslite.TrimSuffix(s, `\`), nil
+}
+
+// flushVolume flushes the write buffers of the named volume.
+func flushVolume(volume string) error {
+	volumep, err := syscall.UTF16PtrFromString(volume)
+	if err != nil {
+		return err
+	}
+	h, err := syscall.CreateFile(volumep, syscall.GENERIC_READ|syscall.GENERIC_WRITE, syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE, nil, syscall.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(h)
+	return syscall.FlushFileBuffers(h)
+}
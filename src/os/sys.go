@@ -4,7 +4,50 @@
 
 package os
 
+import (
+	"errors"
+	"internal/stringslite"
+)
+
 // Hostname returns the host name reported by the kernel.
 func Hostname() (name string, err error) {
 	return hostname()
 }
+
+// HostnameFQDN returns the fully qualified domain name of the host,
+// such as "build1.example.com", for uses such as TLS certificates and
+// cluster membership that need a name that is unique beyond the local
+// network.
+//
+// On Windows, it returns the fully qualified physical DNS name reported by
+// GetComputerNameEx. On other systems, if the host name reported by the
+// kernel is not already qualified, HostnameFQDN looks for its canonical
+// name in /etc/hosts. It does not query DNS; use the net package to look
+// up the canonical name of a host.
+//
+// If the name cannot be fully qualified, HostnameFQDN returns an error.
+func HostnameFQDN() (name string, err error) {
+	name, err = hostnameFQDN()
+	if err != nil {
+		return "", err
+	}
+	if stringslite.IndexByte(name, '.') < 0 {
+		return "", errors.New("cannot determine fully qualified domain name of " + name)
+	}
+	return name, nil
+}
+
+// Sethostname sets the host name reported by the kernel to name.
+// Setting the host name usually requires privilege.
+//
+// On Linux, Sethostname uses sethostname(2), which sets the host name
+// of the calling process's UTS namespace. On DragonFly BSD, FreeBSD,
+// and NetBSD, it sets the kern.hostname sysctl. On Windows, it sets the
+// physical DNS host name with SetComputerNameEx, which takes effect when
+// the system restarts. On other systems, Sethostname returns an error
+// wrapping [errors.ErrUnsupported].
+//
+// If there is an error, it will be of type [*SyscallError].
+func Sethostname(name string) error {
+	return sethostname(name)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package os
+
+import (
+	"internal/stringslite"
+	"slices"
+)
+
+func hostnameFQDN() (name string, err error) {
+	name, err = Hostname()
+	if err != nil || stringslite.IndexByte(name, '.') >= 0 {
+		return name, err
+	}
+	if data, err := ReadFile("/etc/hosts"); err == nil {
+		if fqdn := hostsCanonicalName(string(data), name); fqdn != "" {
+			return fqdn, nil
+		}
+	}
+	return name, nil
+}
+
+// hostsCanonicalName returns the first qualified name on the line of an
+// /etc/hosts file that lists name, or "" if there is none.
+func hostsCanonicalName(hosts, name string) string {
+	for hosts != "" {
+		var line string
+		line, hosts, _ = stringslite.Cut(hosts, "\n")
+		line, _, _ = stringslite.Cut(line, "#")
+		fields := lineFields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		names := fields[1:]
+		if !slices.ContainsFunc(names, func(n string) bool { return equalFoldASCII(n, name) }) {
+			continue
+		}
+		for _, n := range names {
+			if stringslite.IndexByte(n, '.') >= 0 {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
+// lineFields splits a line of a configuration file into fields
+// separated by spaces and tabs.
+func lineFields(line string) []string {
+	var fields []string
+	for {
+		for line != "" && (line[0] == ' ' || line[0] == '\t' || line[0] == '\r') {
+			line = line[1:]
+		}
+		if line == "" {
+			return fields
+		}
+		i := 0
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' && line[i] != '\r' {
+			i++
+		}
+		fields = append(fields, line[:i])
+		line = line[i:]
+	}
+}
@@ -51,3 +51,7 @@ func hostname() (name string, err error) {
 	}
 	return string(buf[:n]), nil
 }
+
+func sethostname(name string) error {
+	return NewSyscallError("sethostname", syscall.Sethostname([]byte(name)))
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !dragonfly && !freebsd && !linux && !netbsd && !windows
+
+package os
+
+import "errors"
+
+func sethostname(name string) error {
+	return NewSyscallError("sethostname", errors.ErrUnsupported)
+}
@@ -11,8 +11,14 @@ import (
 
 func hostname() (name string, err error) {
 	// Use PhysicalDnsHostname to uniquely identify host in a cluster
-	const format = windows.ComputerNamePhysicalDnsHostname
+	return computerName(windows.ComputerNamePhysicalDnsHostname)
+}
+
+func hostnameFQDN() (name string, err error) {
+	return computerName(windows.ComputerNamePhysicalDnsFullyQualified)
+}
 
+func computerName(format uint32) (name string, err error) {
 	n := uint32(64)
 	for {
 		b := make([]uint16, n)
@@ -31,3 +37,11 @@ func hostname() (name string, err error) {
 		}
 	}
 }
+
+func sethostname(name string) error {
+	namep, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return NewSyscallError("SetComputerNameEx", err)
+	}
+	return NewSyscallError("SetComputerNameEx", windows.SetComputerNameEx(windows.ComputerNamePhysicalDnsHostname, namep))
+}
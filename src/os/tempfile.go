@@ -33,6 +33,13 @@ func nextRandom() string {
 // The caller can use the file's Name method to find the pathname of the file.
 // It is the caller's responsibility to remove the file when it is no longer needed.
 func CreateTemp(dir, pattern string) (*File, error) {
+	return CreateTempMode(dir, pattern, 0o600)
+}
+
+// CreateTempMode is like [CreateTemp], but creates the file with the
+// permission bits perm (before umask) rather than 0o600, so that no
+// other mode is visible between creating the file and a later Chmod.
+func CreateTempMode(dir, pattern string, perm FileMode) (*File, error) {
 	if dir == "" {
 		dir = TempDir()
 	}
@@ -46,7 +53,7 @@ func CreateTemp(dir, pattern string) (*File, error) {
 	try := 0
 	for {
 		name := prefix + nextRandom() + suffix
-		f, err := OpenFile(name, O_RDWR|O_CREATE|O_EXCL, 0600)
+		f, err := OpenFile(name, O_RDWR|O_CREATE|O_EXCL, perm)
 		if IsExist(err) {
 			if try++; try < 10000 {
 				continue
@@ -84,6 +91,13 @@ func prefixAndSuffix(pattern string) (prefix, suffix string, err error) {
 // Multiple programs or goroutines calling MkdirTemp simultaneously will not choose the same directory.
 // It is the caller's responsibility to remove the directory when it is no longer needed.
 func MkdirTemp(dir, pattern string) (string, error) {
+	return MkdirTempMode(dir, pattern, 0o700)
+}
+
+// MkdirTempMode is like [MkdirTemp], but creates the directory with the
+// permission bits perm (before umask) rather than 0o700, so that no
+// other mode is visible between creating the directory and a later Chmod.
+func MkdirTempMode(dir, pattern string, perm FileMode) (string, error) {
 	if dir == "" {
 		dir = TempDir()
 	}
@@ -97,7 +111,7 @@ func MkdirTemp(dir, pattern string) (string, error) {
 	try := 0
 	for {
 		name := prefix + nextRandom() + suffix
-		err := Mkdir(name, 0700)
+		err := Mkdir(name, perm)
 		if err == nil {
 			return name, nil
 		}
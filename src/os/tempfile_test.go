@@ -10,6 +10,7 @@ import (
 	. "os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -24,6 +25,52 @@ func TestCreateTemp(t *testing.T) {
 	}
 }
 
+func TestCreateTempMode(t *testing.T) {
+	t.Parallel()
+
+	f, err := CreateTempMode(t.TempDir(), "foo", 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTempMode(t, fi, 0o640)
+}
+
+func TestMkdirTempMode(t *testing.T) {
+	t.Parallel()
+
+	name, err := MkdirTempMode(t.TempDir(), "foo", 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, err := Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Fatalf("MkdirTempMode created %v, not a directory", fi.Mode())
+	}
+	checkTempMode(t, fi, 0o750)
+}
+
+// checkTempMode checks that fi has the permission bits perm,
+// less any removed by the umask, which is assumed to leave the
+// owner's bits alone.
+func checkTempMode(t *testing.T, fi FileInfo, perm FileMode) {
+	t.Helper()
+	switch runtime.GOOS {
+	case "windows", "js", "wasip1":
+		t.Skipf("permission bits are not supported on %s", runtime.GOOS)
+	}
+	if got := fi.Mode().Perm(); got&^perm != 0 || got&0o700 != perm&0o700 {
+		t.Errorf("mode = %v, want %v (before umask)", got, perm)
+	}
+}
+
 func TestCreateTempPattern(t *testing.T) {
 	t.Parallel()
 
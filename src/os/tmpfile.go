@@ -0,0 +1,90 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "runtime"
+
+// openTmpFile opens an unnamed file in the directory dir,
+// for OpenFileOptions.TmpFile.
+//
+// Where the system does not support O_TMPFILE, the file is created
+// with a random name in dir, and removed when it is closed.
+func openTmpFile(dir string, flag int, perm FileMode, opts *OpenFileOptions) (*File, error) {
+	if tmpfileSupported {
+		f, err := openFileNolog(dir, flag|o_TMPFILE, perm, opts)
+		if err == nil || !tmpfileUnsupportedError(err) {
+			return f, err
+		}
+	}
+
+	flag |= O_CREATE | O_EXCL
+	prefix := joinPath(dir, ".tmpfile")
+	try := 0
+	for {
+		name := prefix + nextRandom()
+		f, err := openFileNolog(name, flag, perm, opts)
+		if IsExist(err) {
+			if try++; try < 10000 {
+				continue
+			}
+			return nil, &PathError{Op: "open", Path: prefix + "*", Err: ErrExist}
+		}
+		if err != nil {
+			return nil, err
+		}
+		f.tmpName = name
+		return f, nil
+	}
+}
+
+// LinkInto creates name in the directory dir as a hard link to the file.
+// It is used to give a name to a file opened with [OpenFileOptions.TmpFile],
+// once it has been written:
+//
+//	f, err := os.OpenFileWithOptions(dir, os.O_WRONLY, 0o644, &os.OpenFileOptions{TmpFile: true})
+//	... write and sync f ...
+//	err = f.LinkInto(dir, "data")
+//
+// The new name appears atomically, complete with the file's contents,
+// so that other programs never see a partially written file.
+// Like [Link], LinkInto does not replace an existing file.
+//
+// On Linux, LinkInto uses linkat(2), and if the program crashes before
+// calling LinkInto, the unnamed file disappears. On other systems, and on
+// file systems which do not support O_TMPFILE, the file has a temporary
+// name until LinkInto succeeds or the file is closed; if the program
+// crashes first, the file is left behind under that name.
+// On these systems, LinkInto on a file not opened with TmpFile
+// links its name.
+//
+// If there is an error, it will be of type [*LinkError].
+func (f *File) LinkInto(dir, name string) error {
+	if err := f.checkValid("link"); err != nil {
+		return err
+	}
+	newname := joinPath(dir, name)
+	var err error
+	if f.tmpName != "" {
+		err = Link(f.tmpName, newname)
+		if err == nil && Remove(f.tmpName) == nil {
+			// Some systems cannot remove an open file;
+			// Close will try again.
+			// Otherwise the file is now known by its new name,
+			// which later calls to LinkInto link from.
+			f.tmpName = ""
+			f.name = newname
+		}
+	} else {
+		err = linkInto(f, newname)
+	}
+	runtime.KeepAlive(f)
+	if err != nil {
+		if le, ok := err.(*LinkError); ok {
+			return le
+		}
+		return &LinkError{"link", f.name, newname, err}
+	}
+	return nil
+}
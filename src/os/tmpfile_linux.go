@@ -0,0 +1,42 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/itoa"
+	"internal/syscall/unix"
+	"syscall"
+)
+
+const o_TMPFILE = unix.O_TMPFILE
+
+const tmpfileSupported = true
+
+// tmpfileUnsupportedError reports whether err from opening a file
+// with O_TMPFILE means the file system does not support it.
+// Other errors, including EISDIR, are returned to the caller.
+func tmpfileUnsupportedError(err error) bool {
+	return underlyingErrorIs(err, syscall.EOPNOTSUPP)
+}
+
+func linkInto(f *File, newname string) error {
+	var err error
+	cerr := f.pfd.RawControl(func(fd uintptr) {
+		err = ignoringEINTR(func() error {
+			return unix.Linkat(int(fd), "", unix.AT_FDCWD, newname, unix.AT_EMPTY_PATH)
+		})
+		if err == syscall.ENOENT || err == syscall.EPERM {
+			// Linking with AT_EMPTY_PATH requires CAP_DAC_READ_SEARCH
+			// before Linux 6.10. Link the file through /proc instead.
+			err = ignoringEINTR(func() error {
+				return unix.Linkat(unix.AT_FDCWD, "/proc/self/fd/"+itoa.Uitoa(uint(fd)), unix.AT_FDCWD, newname, unix.AT_SYMLINK_FOLLOW)
+			})
+		}
+	})
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+// o_TMPFILE is not used, since tmpfileSupported is false.
+const o_TMPFILE = 0
+
+const tmpfileSupported = false
+
+func tmpfileUnsupportedError(err error) bool {
+	return true
+}
+
+func linkInto(f *File, newname string) error {
+	return Link(f.name, newname)
+}
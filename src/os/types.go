@@ -7,6 +7,7 @@ package os
 import (
 	"io/fs"
 	"syscall"
+	"time"
 )
 
 // Getpagesize returns the underlying system's memory page size.
@@ -74,3 +75,50 @@ func SameFile(fi1, fi2 FileInfo) bool {
 	}
 	return sameFile(fs1, fs2)
 }
+
+// SameFilesystem reports whether fi1 and fi2 describe files on the same
+// file system, so that, for example, a file can be renamed or hard linked
+// from the directory of one into the directory of the other. It compares
+// the device IDs on Unix systems and the volume serial numbers on Windows.
+// SameFilesystem only applies to results returned by this package's [Stat].
+// It returns false in other cases.
+func SameFilesystem(fi1, fi2 FileInfo) bool {
+	fs1, ok1 := fi1.(*fileStat)
+	fs2, ok2 := fi2.(*fileStat)
+	if !ok1 || !ok2 {
+		return false
+	}
+	return sameFilesystem(fs1, fs2)
+}
+
+// SameFilesystemPath reports whether the named files are on the same file
+// system, as described for [SameFilesystem]. It follows symbolic links.
+// If there is an error, it will be of type [*PathError].
+func SameFilesystemPath(name1, name2 string) (bool, error) {
+	fi1, err := Stat(name1)
+	if err != nil {
+		return false, err
+	}
+	fi2, err := Stat(name2)
+	if err != nil {
+		return false, err
+	}
+	return SameFilesystem(fi1, fi2), nil
+}
+
+// FileBirthTime returns the time at which the file described by fi was
+// created, and reports whether the birth time is known.
+// It uses the birth time reported by the system on Darwin, FreeBSD and
+// NetBSD, and the creation time on Windows. The birth time is unknown if
+// the file system does not record it, and on other systems, including
+// Linux, where stat(2) does not report it; on Linux, File.Statx reports
+// the birth time of an open file instead.
+// FileBirthTime only applies to results returned by this package's [Stat].
+// It returns false in other cases.
+func FileBirthTime(fi FileInfo) (time.Time, bool) {
+	fs, ok := fi.(*fileStat)
+	if !ok {
+		return time.Time{}, false
+	}
+	return birthTime(fs)
+}
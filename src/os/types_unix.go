@@ -28,3 +28,7 @@ func (fs *fileStat) Sys() any           { return &fs.sys }
 func sameFile(fs1, fs2 *fileStat) bool {
 	return fs1.sys.Dev == fs2.sys.Dev && fs1.sys.Ino == fs2.sys.Ino
 }
+
+func sameFilesystem(fs1, fs2 *fileStat) bool {
+	return fs1.sys.Dev == fs2.sys.Dev
+}
@@ -362,6 +362,13 @@ func sameFile(fs1, fs2 *fileStat) bool {
 	return fs1.vol == fs2.vol && fs1.idxhi == fs2.idxhi && fs1.idxlo == fs2.idxlo
 }
 
+func sameFilesystem(fs1, fs2 *fileStat) bool {
+	if fs1.loadFileId() != nil || fs2.loadFileId() != nil {
+		return false
+	}
+	return fs1.vol == fs2.vol
+}
+
 // For testing.
 func atime(fi FileInfo) time.Time {
 	return time.Unix(0, fi.Sys().(*syscall.Win32FileAttributeData).LastAccessTime.Nanoseconds())
@@ -0,0 +1,61 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "errors"
+
+// A UserDirKind identifies one of the current user's well-known
+// directories, as reported by [UserDir].
+type UserDirKind int
+
+const (
+	UserDirDesktop   UserDirKind = iota // the desktop
+	UserDirDocuments                    // documents
+	UserDirDownloads                    // downloaded files
+	UserDirMusic                        // music
+	UserDirPictures                     // pictures
+	UserDirVideos                       // videos (Movies on Darwin)
+	UserDirTemplates                    // document templates
+	UserDirPublic                       // files shared with other users
+)
+
+// userDirNames holds the default name of each directory, as used by
+// xdg-user-dirs and the Darwin home directory layout.
+var userDirNames = [...]string{
+	UserDirDesktop:   "Desktop",
+	UserDirDocuments: "Documents",
+	UserDirDownloads: "Downloads",
+	UserDirMusic:     "Music",
+	UserDirPictures:  "Pictures",
+	UserDirVideos:    "Videos",
+	UserDirTemplates: "Templates",
+	UserDirPublic:    "Public",
+}
+
+// UserDir returns the location of one of the current user's well-known
+// directories, such as their downloads or documents directory.
+// The directory is not guaranteed to exist.
+//
+// On Unix systems, it returns the directory configured in
+// $XDG_CONFIG_HOME/user-dirs.dirs (or $HOME/.config/user-dirs.dirs) as
+// specified by https://www.freedesktop.org/wiki/Software/xdg-user-dirs/,
+// which may be localized; if the directory is not configured there,
+// it returns the default, such as $HOME/Downloads.
+// On Darwin, it returns the standard directory in $HOME, such as
+// $HOME/Downloads or $HOME/Movies.
+// On Windows, it returns the known folder reported by SHGetKnownFolderPath,
+// such as FOLDERID_Downloads.
+//
+// If the directory kind does not exist on the current platform,
+// such as [UserDirTemplates] on Darwin, or on Plan 9, where there are no
+// such directories, UserDir returns [errors.ErrUnsupported].
+// If the location cannot be determined (for example, $HOME is not defined),
+// it returns an error.
+func UserDir(kind UserDirKind) (string, error) {
+	if kind < 0 || int(kind) >= len(userDirNames) {
+		return "", errors.New("invalid UserDirKind")
+	}
+	return userDir(kind)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package os
+
+import (
+	"errors"
+	"internal/stringslite"
+	"runtime"
+)
+
+// userDirXDGNames holds the key of each directory in user-dirs.dirs,
+// without the XDG_ prefix and _DIR suffix.
+var userDirXDGNames = [...]string{
+	UserDirDesktop:   "DESKTOP",
+	UserDirDocuments: "DOCUMENTS",
+	UserDirDownloads: "DOWNLOAD",
+	UserDirMusic:     "MUSIC",
+	UserDirPictures:  "PICTURES",
+	UserDirVideos:    "VIDEOS",
+	UserDirTemplates: "TEMPLATES",
+	UserDirPublic:    "PUBLICSHARE",
+}
+
+func userDir(kind UserDirKind) (string, error) {
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		if kind == UserDirTemplates {
+			return "", errors.ErrUnsupported
+		}
+		home := Getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		name := userDirNames[kind]
+		if kind == UserDirVideos {
+			name = "Movies"
+		}
+		return home + "/" + name, nil
+
+	case "plan9":
+		return "", errors.ErrUnsupported
+
+	default: // Unix
+		home := Getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		if config, err := UserConfigDir(); err == nil {
+			if data, err := ReadFile(config + "/user-dirs.dirs"); err == nil {
+				if dir, ok := parseUserDirs(string(data), userDirXDGNames[kind], home); ok {
+					return dir, nil
+				}
+			}
+		}
+		return home + "/" + userDirNames[kind], nil
+	}
+}
+
+// parseUserDirs returns the value of XDG_<key>_DIR in the contents
+// of a user-dirs.dirs file, with $HOME replaced by home.
+//
+// The file is written by xdg-user-dirs-update as shell assignments,
+// one per line, where each value is a double-quoted string that is
+// either an absolute path or starts with $HOME. As in other
+// implementations, the file is not interpreted by a shell and
+// lines in any other form are ignored.
+// If the key appears more than once, the last value is used.
+func parseUserDirs(data, key, home string) (dir string, ok bool) {
+	prefix := "XDG_" + key + "_DIR"
+	for data != "" {
+		var line string
+		line, data, _ = stringslite.Cut(data, "\n")
+		rest, found := stringslite.CutPrefix(trimBlanks(line), prefix)
+		if !found {
+			continue
+		}
+		if rest, found = stringslite.CutPrefix(trimBlanks(rest), "="); !found {
+			continue
+		}
+		if rest, found = stringslite.CutPrefix(trimBlanks(rest), `"`); !found {
+			continue
+		}
+		rest, relative := stringslite.CutPrefix(rest, "$HOME")
+		if relative {
+			if !stringslite.HasPrefix(rest, "/") && !stringslite.HasPrefix(rest, `"`) {
+				continue
+			}
+		} else if !stringslite.HasPrefix(rest, "/") {
+			continue
+		}
+		var v []byte
+		closed := false
+		for i := 0; i < len(rest); i++ {
+			c := rest[i]
+			if c == '"' {
+				closed = true
+				break
+			}
+			if c == '\\' && i+1 < len(rest) {
+				i++
+				c = rest[i]
+			}
+			v = append(v, c)
+		}
+		if !closed {
+			continue
+		}
+		s := string(v)
+		if relative {
+			s = home + s
+		}
+		for len(s) > 1 && s[len(s)-1] == '/' {
+			s = s[:len(s)-1]
+		}
+		dir, ok = s, true
+	}
+	return dir, ok
+}
+
+// trimBlanks returns s without leading spaces and tabs.
+func trimBlanks(s string) string {
+	for s != "" && (s[0] == ' ' || s[0] == '\t') {
+		s = s[1:]
+	}
+	return s
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/syscall/windows"
+	"syscall"
+	"unsafe"
+)
+
+var userDirFolderIDs = [...]*syscall.GUID{
+	UserDirDesktop:   &windows.FOLDERID_Desktop,
+	UserDirDocuments: &windows.FOLDERID_Documents,
+	UserDirDownloads: &windows.FOLDERID_Downloads,
+	UserDirMusic:     &windows.FOLDERID_Music,
+	UserDirPictures:  &windows.FOLDERID_Pictures,
+	UserDirVideos:    &windows.FOLDERID_Videos,
+	UserDirTemplates: &windows.FOLDERID_Templates,
+	UserDirPublic:    &windows.FOLDERID_Public,
+}
+
+func userDir(kind UserDirKind) (string, error) {
+	var p *uint16
+	err := windows.SHGetKnownFolderPath(userDirFolderIDs[kind], 0, 0, &p)
+	// The buffer must be freed even if the call fails.
+	defer windows.CoTaskMemFree(unsafe.Pointer(p))
+	if err != nil {
+		return "", NewSyscallError("SHGetKnownFolderPath", err)
+	}
+	return windows.UTF16PtrToString(p), nil
+}
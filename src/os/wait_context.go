@@ -0,0 +1,75 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix || (js && wasm) || wasip1
+
+package os
+
+import (
+	"context"
+	"internal/poll"
+	"time"
+)
+
+func (p *Process) waitContext(ctx context.Context) (*ProcessState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Which type of Process do we have?
+	if p.handle != nil {
+		// pidfd
+		return p.pidfdWaitContext(ctx)
+	} else {
+		// Regular PID
+		return p.pidWaitContext(ctx)
+	}
+}
+
+// waitFDReady calls ready until it reports true or ctx is done,
+// using the runtime poller to wait for fd to become readable between
+// calls. If fd cannot be added to the poller, it falls back to pollReady.
+// waitFDReady closes fd before returning.
+func waitFDReady(ctx context.Context, fd int, ready func() bool) error {
+	pfd := poll.FD{
+		Sysfd:    fd,
+		IsStream: true,
+	}
+	defer pfd.Close()
+	if err := pfd.Init("file", true); err != nil {
+		return pollReady(ctx, ready)
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		// Set a deadline in the past to wake up RawRead.
+		pfd.SetReadDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	err := pfd.RawRead(func(uintptr) bool {
+		return ready()
+	})
+	if err == poll.ErrDeadlineExceeded {
+		return ctx.Err()
+	}
+	return err
+}
+
+// pollReady calls ready at increasing intervals
+// until it reports true or ctx is done.
+func pollReady(ctx context.Context, ready func() bool) error {
+	const maxDelay = 100 * time.Millisecond
+	delay := time.Millisecond
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	for !ready() {
+		t.Reset(delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+		delay = min(2*delay, maxDelay)
+	}
+	return nil
+}
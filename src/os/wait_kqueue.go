@@ -0,0 +1,59 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package os
+
+import (
+	"context"
+	"syscall"
+)
+
+// pidWaitContext is like pidWait, but uses a kqueue EVFILT_PROC
+// filter to wait for the process to exit until ctx is done.
+func (p *Process) pidWaitContext(ctx context.Context) (*ProcessState, error) {
+	switch p.pidStatus() {
+	case statusReleased:
+		return nil, syscall.EINVAL
+	}
+
+	syscall.ForkLock.RLock()
+	kq, err := syscall.Kqueue()
+	if err == nil {
+		syscall.CloseOnExec(kq)
+	}
+	syscall.ForkLock.RUnlock()
+	if err != nil {
+		return nil, NewSyscallError("kqueue", err)
+	}
+
+	var ev [1]syscall.Kevent_t
+	syscall.SetKevent(&ev[0], p.Pid, syscall.EVFILT_PROC, syscall.EV_ADD|syscall.EV_ONESHOT)
+	ev[0].Fflags = syscall.NOTE_EXIT
+	_, err = ignoringEINTR2(func() (int, error) {
+		return syscall.Kevent(kq, ev[:], nil, nil)
+	})
+	if err != nil {
+		syscall.Close(kq)
+		if err != syscall.ESRCH {
+			return nil, NewSyscallError("kevent", err)
+		}
+		// The process has already exited, or is not ours;
+		// let pidWait reap it or report the error.
+		return p.pidWait()
+	}
+
+	err = waitFDReady(ctx, kq, func() bool {
+		n, err := ignoringEINTR2(func() (int, error) {
+			return syscall.Kevent(kq, nil, ev[:], &syscall.Timespec{})
+		})
+		// On error, let pidWait report it.
+		return err != nil || n > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.pidWait()
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || solaris || (js && wasm) || wasip1
+
+package os
+
+import (
+	"context"
+	"syscall"
+)
+
+// pidWaitContext is like pidWait, but polls for the process to exit
+// until ctx is done. As with pidWait on these systems, a concurrent
+// call to Process.Signal may in an extremely unlikely case send a
+// signal to the wrong process, see issue #13987.
+func (p *Process) pidWaitContext(ctx context.Context) (*ProcessState, error) {
+	switch p.pidStatus() {
+	case statusReleased:
+		return nil, syscall.EINVAL
+	}
+
+	var (
+		ps   *ProcessState
+		werr error
+	)
+	err := pollReady(ctx, func() bool {
+		var (
+			status syscall.WaitStatus
+			rusage syscall.Rusage
+		)
+		pid1, err := ignoringEINTR2(func() (int, error) {
+			return syscall.Wait4(p.Pid, &status, wnohang, &rusage)
+		})
+		if err != nil {
+			werr = NewSyscallError("wait", err)
+			return true
+		}
+		if pid1 == 0 {
+			return false
+		}
+		p.doRelease(statusDone)
+		ps = &ProcessState{
+			pid:    pid1,
+			status: status,
+			rusage: &rusage,
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ps, werr
+}
@@ -0,0 +1,9 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+// wnohang is the WNOHANG option to wait4 from <sys/wait.h>,
+// which package syscall does not define on AIX.
+const wnohang = 0x1
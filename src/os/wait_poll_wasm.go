@@ -0,0 +1,11 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build js || wasip1
+
+package os
+
+// wnohang is the WNOHANG option to wait4. Wait4 is not implemented
+// on these systems, so the value is never interpreted.
+const wnohang = 0
@@ -10,6 +10,7 @@
 package os
 
 import (
+	"context"
 	"internal/syscall/unix"
 	"runtime"
 	"syscall"
@@ -35,3 +36,25 @@ func (p *Process) blockUntilWaitable() (bool, error) {
 	}
 	return true, nil
 }
+
+// pidWaitContext is like pidWait, but polls for the process to exit
+// until ctx is done. It is only used if pidfd is not available.
+func (p *Process) pidWaitContext(ctx context.Context) (*ProcessState, error) {
+	switch p.pidStatus() {
+	case statusReleased:
+		return nil, syscall.EINVAL
+	}
+
+	err := pollReady(ctx, func() bool {
+		var info unix.SiginfoChild
+		err := ignoringEINTR(func() error {
+			return unix.Waitid(unix.P_PID, p.Pid, &info, syscall.WEXITED|syscall.WNOHANG|syscall.WNOWAIT, nil)
+		})
+		// On error, let pidWait report it.
+		return err != nil || info.Pid != 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.pidWait()
+}
@@ -11,10 +11,6 @@ import (
 
 var pollCopyFileRange = poll.CopyFileRange
 
-func (f *File) writeTo(w io.Writer) (written int64, handled bool, err error) {
-	return 0, false, nil
-}
-
 func (f *File) readFrom(r io.Reader) (written int64, handled bool, err error) {
 	// copy_file_range(2) doesn't support destinations opened with
 	// O_APPEND, so don't bother to try zero-copy with these system calls.
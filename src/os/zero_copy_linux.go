@@ -7,7 +7,6 @@ package os
 import (
 	"internal/poll"
 	"io"
-	"syscall"
 )
 
 var (
@@ -15,30 +14,6 @@ var (
 	pollSplice        = poll.Splice
 )
 
-func (f *File) writeTo(w io.Writer) (written int64, handled bool, err error) {
-	pfd, network := getPollFDAndNetwork(w)
-	// TODO(panjf2000): same as File.spliceToFile.
-	if pfd == nil || !pfd.IsStream || !isUnixOrTCP(string(network)) {
-		return
-	}
-
-	sc, err := f.SyscallConn()
-	if err != nil {
-		return
-	}
-
-	rerr := sc.Read(func(fd uintptr) (done bool) {
-		written, err, handled = poll.SendFile(pfd, fd, 0)
-		return true
-	})
-
-	if err == nil {
-		err = rerr
-	}
-
-	return written, handled, wrapSyscallError("sendfile", err)
-}
-
 func (f *File) readFrom(r io.Reader) (written int64, handled bool, err error) {
 	// Neither copy_file_range(2) nor splice(2) supports destinations opened with
 	// O_APPEND, so don't bother to try zero-copy with these system calls.
@@ -117,34 +92,3 @@ func (f *File) copyFileRange(r io.Reader) (written int64, handled bool, err erro
 	}
 	return written, handled, wrapSyscallError("copy_file_range", err)
 }
-
-// getPollFDAndNetwork tries to get the poll.FD and network type from the given interface
-// by expecting the underlying type of i to be the implementation of syscall.Conn
-// that contains a *net.rawConn.
-func getPollFDAndNetwork(i any) (*poll.FD, poll.String) {
-	sc, ok := i.(syscall.Conn)
-	if !ok {
-		return nil, ""
-	}
-	rc, err := sc.SyscallConn()
-	if err != nil {
-		return nil, ""
-	}
-	irc, ok := rc.(interface {
-		PollFD() *poll.FD
-		Network() poll.String
-	})
-	if !ok {
-		return nil, ""
-	}
-	return irc.PollFD(), irc.Network()
-}
-
-func isUnixOrTCP(network string) bool {
-	switch network {
-	case "tcp", "tcp4", "tcp6", "unix":
-		return true
-	default:
-		return false
-	}
-}
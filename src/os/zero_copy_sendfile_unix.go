@@ -0,0 +1,13 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || (darwin && !ios) || dragonfly || freebsd || solaris
+
+package os
+
+// sendFileSupported reports whether sendfile(2) can be used to
+// write to a stream socket of the given network.
+func sendFileSupported(network string) bool {
+	return isUnixOrTCP(network)
+}
@@ -11,10 +11,6 @@ import (
 	"syscall"
 )
 
-func (f *File) writeTo(w io.Writer) (written int64, handled bool, err error) {
-	return 0, false, nil
-}
-
 // readFrom is basically a refactor of net.sendFile, but adapted to work for the target of *File.
 func (f *File) readFrom(r io.Reader) (written int64, handled bool, err error) {
 	var remain int64 = 0 // 0 indicates sending until EOF
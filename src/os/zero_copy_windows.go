@@ -0,0 +1,22 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "internal/syscall/windows"
+
+// sendFileSupported reports whether TransmitFile can be used to
+// write to a stream socket of the given network.
+//
+// Workstation and client versions of Windows limit the number
+// of concurrent TransmitFile operations allowed on the system
+// to a maximum of two, see https://go.dev/issue/73746.
+func sendFileSupported(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return windows.SupportUnlimitedTransmitFile()
+	default:
+		return false
+	}
+}
@@ -344,6 +344,18 @@ type opendir struct {
 // as mount points at sub paths of the root.
 var preopens []opendir
 
+// preopen is accessed from internal/syscall/unix
+//go:linkname preopen
+
+// preopen returns the file descriptor and name of the i'th
+// preopened directory, and reports whether it exists.
+func preopen(i int) (fd int, name string, ok bool) {
+	if i < 0 || i >= len(preopens) {
+		return -1, "", false
+	}
+	return int(preopens[i].fd), preopens[i].name, true
+}
+
 // Current working directory. We maintain this as a string and resolve paths in
 // the code because wasmtime does not allow relative path lookups outside of the
 // scope of a directory; a previous approach we tried consisted in maintaining
@@ -575,6 +587,7 @@ func openat(dirFd int32, pathPtr *byte, pathLen size, openmode int, perm uint32)
 		lflags = LOOKUP_SYMLINK_FOLLOW
 	}
 
+	inheriting := fileRights
 	var fd int32
 	errno := path_open(
 		dirFd,
@@ -583,10 +596,32 @@ func openat(dirFd int32, pathPtr *byte, pathLen size, openmode int, perm uint32)
 		pathLen,
 		oflags,
 		rights,
-		fileRights,
+		inheriting,
 		fdflags,
 		&fd,
 	)
+	if errno == ENOTCAPABLE {
+		// The rights of a descriptor opened with path_open must be a
+		// subset of the inheriting rights of the directory it is opened
+		// relative to. A directory's rights may have been restricted
+		// (for example, by os.Root), so request only the rights it permits.
+		var stat fdstat
+		if fd_fdstat_get(dirFd, &stat) == 0 {
+			rights &= stat.rightsInheriting
+			inheriting &= stat.rightsInheriting
+			errno = path_open(
+				dirFd,
+				lflags,
+				pathPtr,
+				pathLen,
+				oflags,
+				rights,
+				inheriting,
+				fdflags,
+				&fd,
+			)
+		}
+	}
 	if errno == EISDIR && oflags == 0 && fdflags == 0 && ((rights & writeRights) == 0) {
 		// wasmtime and wasmedge will error if attempting to open a directory
 		// because we are asking for too many rights. However, we cannot
@@ -604,7 +639,7 @@ func openat(dirFd int32, pathPtr *byte, pathLen size, openmode int, perm uint32)
 			pathLen,
 			oflags|OFLAG_DIRECTORY,
 			rights&dirRights,
-			fileRights,
+			inheriting,
 			fdflags,
 			&fd,
 		)
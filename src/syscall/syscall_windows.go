@@ -363,6 +363,13 @@ func makeInheritSa() *SecurityAttributes {
 }
 
 func Open(name string, flag int, perm uint32) (fd Handle, err error) {
+	return openWithOptions(name, flag, perm, FILE_SHARE_READ|FILE_SHARE_WRITE, 0)
+}
+
+// openWithOptions implements Open, opening the file with the given
+// share mode and passing any additional FILE_FLAG_* values in
+// fileFlags to CreateFile.
+func openWithOptions(name string, flag int, perm uint32, sharemode uint32, fileFlags uint32) (fd Handle, err error) {
 	if len(name) == 0 {
 		return InvalidHandle, ERROR_FILE_NOT_FOUND
 	}
@@ -393,7 +400,6 @@ func Open(name string, flag int, perm uint32) (fd Handle, err error) {
 		// Set all access rights granted by GENERIC_WRITE except for FILE_WRITE_DATA.
 		access |= FILE_APPEND_DATA | FILE_WRITE_ATTRIBUTES | _FILE_WRITE_EA | STANDARD_RIGHTS_WRITE | SYNCHRONIZE
 	}
-	sharemode := uint32(FILE_SHARE_READ | FILE_SHARE_WRITE)
 	var sa *SecurityAttributes
 	if flag&O_CLOEXEC == 0 {
 		sa = makeInheritSa()
@@ -402,6 +408,7 @@ func Open(name string, flag int, perm uint32) (fd Handle, err error) {
 	if perm&S_IWRITE == 0 {
 		attrs = FILE_ATTRIBUTE_READONLY
 	}
+	attrs |= fileFlags
 	switch accessFlags {
 	case O_WRONLY, O_RDWR:
 		// Unix doesn't allow opening a directory with O_WRONLY